@@ -1,12 +1,18 @@
 package main
 
 import (
+	"crypto/subtle"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/render-examples/go-gin-web-server/tokenizer"
@@ -24,8 +30,30 @@ type Tool struct {
 // TokenizerRequest 表示tokenizer请求的结构
 type TokenizerRequest struct {
 	Text     string `json:"text"`
-	Mode     string `json:"mode"`                // encode, decode, tokenize
+	Mode     string `json:"mode"`                // encode, decode, tokenize, compare, chat, batch, chunk
+	Model    string `json:"model,omitempty"`     // 模型名称，为空时使用默认tokenizer
 	TokenIDs []int  `json:"token_ids,omitempty"` // 用于解码
+
+	Texts []string `json:"texts,omitempty"` // batch模式下批量编码的文本
+
+	ChunkSize    int `json:"chunk_size,omitempty"`    // chunk模式下每块最多的token数
+	ChunkOverlap int `json:"chunk_overlap,omitempty"` // chunk模式下相邻块重叠的token数
+
+	TextPair          string `json:"text_pair,omitempty"`           // 句对编码时的第二段文本
+	AddSpecialTokens  bool   `json:"add_special_tokens,omitempty"`  // 编码时添加BOS/EOS等特殊token
+	SkipSpecialTokens bool   `json:"skip_special_tokens,omitempty"` // 解码时跳过特殊token
+
+	Truncation *tokenizer.TruncationOptions `json:"truncation,omitempty"` // 编码时截断到最大长度
+	Padding    *tokenizer.PaddingOptions    `json:"padding,omitempty"`    // 编码时填充到固定长度
+
+	Models   []string `json:"models,omitempty"`   // compare模式下参与对比的模型，为空表示全部模型
+	Baseline string   `json:"baseline,omitempty"` // compare模式下的基准模型，为空表示第一个模型
+
+	Messages            []json.RawMessage `json:"messages,omitempty"`              // chat模式下OpenAI格式的消息列表
+	Tools               []json.RawMessage `json:"tools,omitempty"`                 // chat模式下OpenAI格式的工具定义
+	AddGenerationPrompt bool              `json:"add_generation_prompt,omitempty"` // chat模式下追加助手回复的起始标记
+	ChatTemplate        string            `json:"chat_template,omitempty"`         // 覆盖模型自带的chat template
+	ChatTemplateKwargs  json.RawMessage   `json:"chat_template_kwargs,omitempty"`  // 传给chat template的额外变量
 }
 
 // TokenizerResponse 表示tokenizer响应的结构
@@ -34,6 +62,13 @@ type TokenizerResponse struct {
 	Message     string                     `json:"message,omitempty"`
 	Data        *tokenizer.TokenizerResult `json:"data,omitempty"`
 	DecodedText string                     `json:"decoded_text,omitempty"`
+	Comparison  *tokenizer.CompareResult   `json:"comparison,omitempty"`
+	Chat        *tokenizer.ChatResult      `json:"chat,omitempty"`
+	Batch       *tokenizer.BatchResult     `json:"batch,omitempty"`
+	Count       *tokenizer.CountResult     `json:"count,omitempty"`
+	Chunks      *tokenizer.ChunkResult     `json:"chunks,omitempty"`
+	Vocab       *tokenizer.VocabPage       `json:"vocab,omitempty"`
+	Model       *tokenizer.ModelInfo       `json:"model,omitempty"`
 }
 
 // 模拟的工具数据
@@ -112,7 +147,7 @@ var tools = []Tool{
 
 func main() {
 	ConfigRuntime()
-	initTokenizer() // 初始化tokenizer
+	initTokenizers() // 初始化tokenizer
 	StartGin()
 }
 
@@ -188,32 +223,76 @@ func tokenizerHandler(c *gin.Context) {
 	c.File("resources/static/tokenizer/index.html")
 }
 
-// 全局tokenizer实例
-var globalTokenizer *tokenizer.Tokenizer
+// 全局tokenizer注册表
+var tokenizerRegistry = tokenizer.NewRegistry()
+
+// initTokenizers 加载TOKENIZER_DIR（默认tokenizers）下的全部tokenizer，
+// 目录为空时回退到 tokenizer 模型目录
+func initTokenizers() {
+	dir := os.Getenv("TOKENIZER_DIR")
+	if dir == "" {
+		dir = "tokenizers"
+	}
 
-// 初始化tokenizer
-func initTokenizer() {
-	configPath := filepath.Join("tokenizer", "tokenizer.json")
-	tk, err := tokenizer.NewTokenizer(configPath)
+	registry, err := tokenizer.LoadRegistry(dir)
 	if err != nil {
-		log.Printf("Failed to initialize tokenizer: %v", err)
-		// 创建基础tokenizer作为后备
-		tk = createBasicTokenizer()
+		log.Printf("Failed to load tokenizers from %s: %v", dir, err)
+	}
+	if registry != nil {
+		tokenizerRegistry = registry
+	}
+
+	if tokenizerRegistry.Len() == 0 {
+		tk, err := tokenizer.NewTokenizer("tokenizer")
+		if err != nil {
+			log.Printf("Failed to initialize tokenizer: %v", err)
+			// 使用内置的字节级tokenizer作为后备，保证服务可以启动
+			tk = tokenizer.NewFallbackTokenizer()
+			log.Printf("Using built-in %s tokenizer, token counts are approximate", tk.GetModelName())
+		}
+		tokenizerRegistry.Register(tk.GetModelName(), tk)
+	}
+
+	if name := os.Getenv("TOKENIZER_DEFAULT_MODEL"); name != "" {
+		if err := tokenizerRegistry.SetDefault(name); err != nil {
+			log.Printf("Failed to set default tokenizer: %v", err)
+		}
+	}
+
+	for _, model := range tokenizerRegistry.Models() {
+		log.Printf("Tokenizer %s initialized, type: %s, vocab size: %d", model.Name, model.Type, model.VocabSize)
+	}
+
+	// 定期检查模型目录，文件变化时重新加载，TOKENIZER_WATCH_INTERVAL为0时关闭
+	interval := 5 * time.Second
+	if value := os.Getenv("TOKENIZER_WATCH_INTERVAL"); value != "" {
+		if interval, err = time.ParseDuration(value); err != nil {
+			log.Printf("Invalid TOKENIZER_WATCH_INTERVAL %q: %v", value, err)
+			interval = 0
+		}
+	}
+	if interval > 0 {
+		tokenizerRegistry.Watch(interval, func(err error) {
+			if err != nil {
+				log.Printf("Failed to reload tokenizers from %s: %v", dir, err)
+				return
+			}
+			log.Printf("Tokenizers reloaded from %s: %v", dir, tokenizerRegistry.Names())
+		})
 	}
-	globalTokenizer = tk
-	log.Printf("Tokenizer initialized, vocab size: %d", tk.GetVocabSize())
 }
 
-// 创建基础tokenizer
-func createBasicTokenizer() *tokenizer.Tokenizer {
-	// 这里可以创建一个基础的tokenizer实例
-	// 简化实现，返回nil
-	return nil
+// setTokenizerHeaders 在响应头中返回处理请求的tokenizer，后备tokenizer同时标记为近似结果
+func setTokenizerHeaders(c *gin.Context, tk *tokenizer.Tokenizer) {
+	c.Header("X-Tokenizer-Model", tk.GetModelName())
+	if tk.IsApproximate() {
+		c.Header("X-Tokenizer-Approximate", "true")
+	}
 }
 
 // tokenizerAPI 处理tokenizer API请求
 func tokenizerAPI(c *gin.Context) {
-	if globalTokenizer == nil {
+	if tokenizerRegistry.Len() == 0 {
 		c.JSON(http.StatusInternalServerError, TokenizerResponse{
 			Success: false,
 			Message: "Tokenizer未初始化",
@@ -230,6 +309,16 @@ func tokenizerAPI(c *gin.Context) {
 		return
 	}
 
+	tk, ok := tokenizerRegistry.Get(req.Model)
+	if !ok {
+		c.JSON(http.StatusNotFound, TokenizerResponse{
+			Success: false,
+			Message: fmt.Sprintf("未知的模型: %s", req.Model),
+		})
+		return
+	}
+	setTokenizerHeaders(c, tk)
+
 	switch req.Mode {
 	case "tokenize":
 		if req.Text == "" {
@@ -240,7 +329,7 @@ func tokenizerAPI(c *gin.Context) {
 			return
 		}
 
-		result, err := globalTokenizer.Tokenize(req.Text)
+		result, err := tk.TokenizeWithOptions(req.Text, encodeOptions(&req))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, TokenizerResponse{
 				Success: false,
@@ -263,7 +352,8 @@ func tokenizerAPI(c *gin.Context) {
 			return
 		}
 
-		tokenIDs, err := globalTokenizer.Encode(req.Text)
+		// 结果中同时包含tokens用于显示
+		result, err := tk.TokenizeWithOptions(req.Text, encodeOptions(&req))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, TokenizerResponse{
 				Success: false,
@@ -272,19 +362,6 @@ func tokenizerAPI(c *gin.Context) {
 			return
 		}
 
-		// 获取tokens用于显示
-		result, err := globalTokenizer.Tokenize(req.Text)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, TokenizerResponse{
-				Success: false,
-				Message: fmt.Sprintf("Tokenization失败: %v", err),
-			})
-			return
-		}
-
-		// 添加token IDs到结果中
-		result.TokenIDs = tokenIDs
-
 		c.JSON(http.StatusOK, TokenizerResponse{
 			Success: true,
 			Data:    result,
@@ -299,7 +376,9 @@ func tokenizerAPI(c *gin.Context) {
 			return
 		}
 
-		decodedText, err := globalTokenizer.Decode(req.TokenIDs)
+		decodedText, err := tk.DecodeWithOptions(req.TokenIDs, tokenizer.DecodeOptions{
+			SkipSpecialTokens: req.SkipSpecialTokens,
+		})
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, TokenizerResponse{
 				Success: false,
@@ -313,11 +392,377 @@ func tokenizerAPI(c *gin.Context) {
 			DecodedText: decodedText,
 		})
 
+	case "compare":
+		if req.Text == "" {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: "文本内容不能为空",
+			})
+			return
+		}
+
+		comparison, err := tokenizerRegistry.Compare(req.Text, req.Models, req.Baseline, encodeOptions(&req))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: fmt.Sprintf("对比失败: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, TokenizerResponse{
+			Success:    true,
+			Comparison: comparison,
+		})
+
+	case "chat":
+		if len(req.Messages) == 0 {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: "消息列表不能为空",
+			})
+			return
+		}
+
+		chat, err := tk.ApplyChatTemplate(tokenizer.ChatOptions{
+			Messages:            req.Messages,
+			Tools:               req.Tools,
+			AddGenerationPrompt: req.AddGenerationPrompt,
+			Template:            req.ChatTemplate,
+			TemplateKwargs:      req.ChatTemplateKwargs,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: fmt.Sprintf("Chat模板渲染失败: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, TokenizerResponse{
+			Success: true,
+			Chat:    chat,
+		})
+
+	case "batch":
+		if len(req.Texts) == 0 {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: "文本列表不能为空",
+			})
+			return
+		}
+
+		batch, err := tk.EncodeBatch(req.Texts, encodeOptions(&req))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: fmt.Sprintf("批量编码失败: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, TokenizerResponse{
+			Success: true,
+			Batch:   batch,
+		})
+
+	case "chunk":
+		if req.Text == "" {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: "文本内容不能为空",
+			})
+			return
+		}
+
+		chunks, err := tk.Chunk(req.Text, tokenizer.ChunkOptions{
+			MaxTokens: req.ChunkSize,
+			Overlap:   req.ChunkOverlap,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{
+				Success: false,
+				Message: fmt.Sprintf("分块失败: %v", err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, TokenizerResponse{
+			Success: true,
+			Chunks:  chunks,
+		})
+
+	default:
+		c.JSON(http.StatusBadRequest, TokenizerResponse{
+			Success: false,
+			Message: "不支持的模式，支持：tokenize, encode, decode, compare, chat, batch, chunk",
+		})
+	}
+}
+
+// tokenizerModelsAPI 返回已加载的tokenizer列表
+func tokenizerModelsAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tokenizerRegistry.Models()})
+}
+
+// tokenizerCountAPI 流式统计上传文件（multipart/form-data的file字段）或NDJSON请求体的token数，
+// 选项通过查询参数传递：model、format（text或ndjson）、field、add_special_tokens
+func tokenizerCountAPI(c *gin.Context) {
+	if tokenizerRegistry.Len() == 0 {
+		c.JSON(http.StatusInternalServerError, TokenizerResponse{
+			Success: false,
+			Message: "Tokenizer未初始化",
+		})
+		return
+	}
+
+	tk, ok := tokenizerRegistry.Get(c.Query("model"))
+	if !ok {
+		c.JSON(http.StatusNotFound, TokenizerResponse{
+			Success: false,
+			Message: fmt.Sprintf("未知的模型: %s", c.Query("model")),
+		})
+		return
+	}
+	setTokenizerHeaders(c, tk)
+
+	opts := tokenizer.CountOptions{Format: c.Query("format"), Field: c.Query("field")}
+	opts.AddSpecialTokens, _ = strconv.ParseBool(c.Query("add_special_tokens"))
+
+	var body io.Reader
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		// 逐个读取part，直到找到file字段，不把整个文件缓存到内存或磁盘
+		reader, err := c.Request.MultipartReader()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "请求格式错误"})
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "缺少上传文件file"})
+				return
+			}
+			if part.FormName() == "file" {
+				if ext := filepath.Ext(part.FileName()); opts.Format == "" && (ext == ".jsonl" || ext == ".ndjson") {
+					opts.Format = "ndjson"
+				}
+				body = part
+				break
+			}
+		}
+	case "application/x-ndjson", "application/jsonl":
+		if opts.Format == "" {
+			opts.Format = "ndjson"
+		}
+		body = c.Request.Body
+	case "text/plain":
+		body = c.Request.Body
 	default:
+		c.JSON(http.StatusUnsupportedMediaType, TokenizerResponse{
+			Success: false,
+			Message: "不支持的Content-Type，支持：multipart/form-data, application/x-ndjson, text/plain",
+		})
+		return
+	}
+
+	count, err := tk.CountReader(body, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{
+			Success: false,
+			Message: fmt.Sprintf("统计失败: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, TokenizerResponse{
+		Success: true,
+		Count:   count,
+	})
+}
+
+// maxTokenizerUploadSize 上传tokenizer请求体的大小上限
+const maxTokenizerUploadSize = 256 << 20
+
+// tokenizerAdminAuth 校验请求头X-Admin-Token与环境变量TOKENIZER_ADMIN_TOKEN，未设置该变量时禁用管理接口
+func tokenizerAdminAuth(c *gin.Context) {
+	token := os.Getenv("TOKENIZER_ADMIN_TOKEN")
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, TokenizerResponse{
+			Success: false,
+			Message: "管理接口未启用，请设置TOKENIZER_ADMIN_TOKEN",
+		})
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(token)) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, TokenizerResponse{
+			Success: false,
+			Message: "管理令牌无效",
+		})
+		return
+	}
+	c.Next()
+}
+
+// tokenizerUploadAPI 上传tokenizer（multipart/form-data）：name为模型名称，tokenizer为tokenizer.json，
+// 可选tokenizer_config为tokenizer_config.json，default为true时设为默认模型。
+// 校验通过后写入模型目录并替换同名模型，正在处理的请求继续使用原tokenizer
+func tokenizerUploadAPI(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxTokenizerUploadSize)
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "请求格式错误"})
+		return
+	}
+	defer form.RemoveAll()
+
+	name := c.PostForm("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "缺少模型名称name"})
+		return
+	}
+	files := make(map[string][]byte)
+	for field, file := range map[string]string{"tokenizer": "tokenizer.json", "tokenizer_config": "tokenizer_config.json"} {
+		headers := form.File[field]
+		if len(headers) == 0 {
+			continue
+		}
+		f, err := headers[0].Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "读取上传文件失败"})
+			return
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "读取上传文件失败"})
+			return
+		}
+		files[file] = data
+	}
+	if _, exists := files["tokenizer.json"]; !exists {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{Success: false, Message: "缺少上传文件tokenizer"})
+		return
+	}
+
+	tk, err := tokenizerRegistry.Install(name, files)
+	if tk == nil {
 		c.JSON(http.StatusBadRequest, TokenizerResponse{
 			Success: false,
-			Message: "不支持的模式，支持：tokenize, encode, decode",
+			Message: fmt.Sprintf("安装tokenizer失败: %v", err),
 		})
+		return
+	}
+	log.Printf("Tokenizer %s uploaded, type: %s, vocab size: %d", name, tk.GetModelType(), tk.GetVocabSize())
+
+	// 模型已安装，只是原模型目录未能删除
+	var message string
+	if err != nil {
+		log.Printf("Failed to clean up after uploading tokenizer %s: %v", name, err)
+		message = fmt.Sprintf("tokenizer已安装，但删除原模型目录失败: %v", err)
+	}
+	info := tk.Info()
+	if isDefault, _ := strconv.ParseBool(c.PostForm("default")); isDefault {
+		// 模型已安装，设置默认模型失败（如同时重新加载时被移除）时仍返回模型信息
+		if err := tokenizerRegistry.SetDefault(name); err != nil {
+			log.Printf("Failed to set default tokenizer: %v", err)
+			c.JSON(http.StatusInternalServerError, TokenizerResponse{
+				Success: false,
+				Message: fmt.Sprintf("设置默认模型失败: %v", err),
+				Model:   &info,
+			})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, TokenizerResponse{Success: true, Message: message, Model: &info})
+}
+
+// tokenizerReloadAPI 重新加载模型目录中的全部tokenizer
+func tokenizerReloadAPI(c *gin.Context) {
+	if err := tokenizerRegistry.Reload(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": fmt.Sprintf("重新加载失败: %v", err),
+			"data":    tokenizerRegistry.Models(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": tokenizerRegistry.Models()})
+}
+
+// tokenizerVocabAPI 分页浏览与搜索词汇表，查询参数：model、id、token、min_id、max_id、prefix、contains、regex、offset、limit
+func tokenizerVocabAPI(c *gin.Context) {
+	tk, ok := tokenizerRegistry.Get(c.Query("model"))
+	if !ok {
+		c.JSON(http.StatusNotFound, TokenizerResponse{
+			Success: false,
+			Message: fmt.Sprintf("未知的模型: %s", c.Query("model")),
+		})
+		return
+	}
+	setTokenizerHeaders(c, tk)
+
+	query := tokenizer.VocabQuery{
+		Token:    c.Query("token"),
+		Prefix:   c.Query("prefix"),
+		Contains: c.Query("contains"),
+		Regex:    c.Query("regex"),
+	}
+	var err error
+	if value := c.Query("id"); value != "" {
+		var id int
+		if id, err = strconv.Atoi(value); err == nil {
+			query.ID = &id
+		}
+	}
+	if value := c.Query("min_id"); value != "" && err == nil {
+		query.MinID, err = strconv.Atoi(value)
+	}
+	if value := c.Query("max_id"); value != "" && err == nil {
+		var maxID int
+		if maxID, err = strconv.Atoi(value); err == nil {
+			query.MaxID = &maxID
+		}
+	}
+	if value := c.Query("offset"); value != "" && err == nil {
+		query.Offset, err = strconv.Atoi(value)
+	}
+	if value := c.Query("limit"); value != "" && err == nil {
+		query.Limit, err = strconv.Atoi(value)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{
+			Success: false,
+			Message: "id、min_id、max_id、offset、limit必须是整数",
+		})
+		return
+	}
+
+	vocab, err := tk.SearchVocab(query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, TokenizerResponse{
+			Success: false,
+			Message: fmt.Sprintf("查询词汇表失败: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, TokenizerResponse{
+		Success: true,
+		Vocab:   vocab,
+	})
+}
+
+// encodeOptions 根据请求构造编码选项
+func encodeOptions(req *TokenizerRequest) tokenizer.EncodeOptions {
+	return tokenizer.EncodeOptions{
+		AddSpecialTokens: req.AddSpecialTokens,
+		Pair:             req.TextPair,
+		Truncation:       req.Truncation,
+		Padding:          req.Padding,
 	}
 }
 
@@ -343,6 +788,12 @@ func StartGin() {
 	router.GET("/color-picker", colorPickerHandler)
 	router.GET("/tokenizer", tokenizerHandler)
 	router.POST("/api/tokenizer", tokenizerAPI)
+	router.GET("/api/tokenizer/models", tokenizerModelsAPI)
+	router.POST("/api/tokenizer/count", tokenizerCountAPI)
+	router.GET("/api/tokenizer/vocab", tokenizerVocabAPI)
+	admin := router.Group("/api/tokenizer/admin", tokenizerAdminAuth)
+	admin.POST("/upload", tokenizerUploadAPI)
+	admin.POST("/reload", tokenizerReloadAPI)
 	// router.GET("/room/:roomid", roomGET)
 	// router.POST("/room-post/:roomid", roomPOST)
 	// router.GET("/stream/:roomid", streamRoom)
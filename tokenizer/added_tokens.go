@@ -0,0 +1,165 @@
+package tokenizer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// addedVocabulary 添加的token（含特殊token），在模型分词之前从文本中切分出来。
+// normalized为false的token在原始文本上匹配，为true的token在规范化后的文本上匹配
+type addedVocabulary struct {
+	raw        addedTokenMatcher
+	normalized addedTokenMatcher
+}
+
+// addedSegment 切分得到的片段，token非nil表示该片段匹配了一个added token
+type addedSegment struct {
+	text  *normalizedString
+	token *AddedToken
+}
+
+// addedTokenCandidate 待匹配的added token，pattern为实际匹配的文本
+type addedTokenCandidate struct {
+	pattern string
+	token   *AddedToken
+}
+
+// addedTokenMatcher 按首字节分组、组内按长度降序，实现最左最长匹配
+type addedTokenMatcher map[byte][]addedTokenCandidate
+
+// newAddedVocabulary 构建added token匹配器，normalized token的内容会先经过规范化
+func newAddedVocabulary(tokens []AddedToken, n normalizer) *addedVocabulary {
+	vocab := &addedVocabulary{
+		raw:        make(addedTokenMatcher),
+		normalized: make(addedTokenMatcher),
+	}
+
+	for i := range tokens {
+		token := &tokens[i]
+		matcher, pattern := vocab.raw, token.Content
+		if token.Normalized {
+			matcher = vocab.normalized
+			if n != nil {
+				pattern = n.normalize(newNormalizedString(pattern)).text
+			}
+		}
+		if pattern != "" {
+			matcher[pattern[0]] = append(matcher[pattern[0]], addedTokenCandidate{pattern: pattern, token: token})
+		}
+	}
+
+	for _, matcher := range []addedTokenMatcher{vocab.raw, vocab.normalized} {
+		for _, candidates := range matcher {
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return len(candidates[i].pattern) > len(candidates[j].pattern)
+			})
+		}
+	}
+	return vocab
+}
+
+// isEmpty 是否没有任何added token
+func (m addedTokenMatcher) isEmpty() bool {
+	return len(m) == 0
+}
+
+// longestMatch 返回在text的pos位置开始的最长匹配
+func (m addedTokenMatcher) longestMatch(text string, pos int) (addedTokenCandidate, bool) {
+	for _, candidate := range m[text[pos]] {
+		if strings.HasPrefix(text[pos:], candidate.pattern) {
+			return candidate, true
+		}
+	}
+	return addedTokenCandidate{}, false
+}
+
+// split 从文本中切分出added token，遵循single_word、lstrip、rstrip规则
+func (m addedTokenMatcher) split(n *normalizedString) []addedSegment {
+	if m.isEmpty() || n.text == "" {
+		return []addedSegment{{text: n}}
+	}
+
+	text := n.text
+	var segments []addedSegment
+	offset := 0 // 上一个匹配的结束位置
+	for pos := 0; pos < len(text); {
+		candidate, ok := m.longestMatch(text, pos)
+		if !ok {
+			pos++
+			continue
+		}
+
+		start, stop := pos, pos+len(candidate.pattern)
+		pos = stop
+		if start < offset {
+			continue
+		}
+
+		token := candidate.token
+		if token.SingleWord && (endsWithWordChar(text[:start]) || startsWithWordChar(text[stop:])) {
+			continue
+		}
+		if token.LStrip {
+			trimmed := len(strings.TrimRightFunc(text[:start], unicode.IsSpace))
+			if trimmed > offset {
+				start = trimmed
+			} else {
+				start = offset
+			}
+		}
+		if token.RStrip {
+			stop = len(text) - len(strings.TrimLeftFunc(text[stop:], unicode.IsSpace))
+		}
+
+		if offset < start {
+			segments = append(segments, addedSegment{text: n.slice(offset, start)})
+		}
+		segments = append(segments, addedSegment{text: n.slice(start, stop), token: token})
+		offset = stop
+	}
+	if offset < len(text) {
+		segments = append(segments, addedSegment{text: n.slice(offset, len(text))})
+	}
+	return segments
+}
+
+// isWordChar 判断是否为单词字符
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// endsWithWordChar 文本是否以单词字符结尾
+func endsWithWordChar(text string) bool {
+	r, size := utf8.DecodeLastRuneInString(text)
+	return size > 0 && isWordChar(r)
+}
+
+// startsWithWordChar 文本是否以单词字符开头
+func startsWithWordChar(text string) bool {
+	r, size := utf8.DecodeRuneInString(text)
+	return size > 0 && isWordChar(r)
+}
+
+// splitAddedTokens 先在原始文本上切分非规范化的added token，
+// 其余片段规范化后再切分规范化的added token
+func (t *Tokenizer) splitAddedTokens(text string) []addedSegment {
+	var segments []addedSegment
+	for _, segment := range t.addedVocab.raw.split(newNormalizedString(text)) {
+		if segment.token != nil {
+			segments = append(segments, segment)
+			continue
+		}
+		normalized := segment.text
+		if t.normalizer != nil {
+			normalized = t.normalizer.normalize(normalized)
+		}
+		for _, s := range t.addedVocab.normalized.split(normalized) {
+			if s.token != nil || s.text.text != "" {
+				segments = append(segments, s)
+			}
+		}
+	}
+	return segments
+}
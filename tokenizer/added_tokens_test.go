@@ -0,0 +1,76 @@
+package tokenizer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// segmentTexts 将切分结果格式化为便于比较的字符串，added token用方括号标出
+func segmentTexts(segments []addedSegment) []string {
+	var texts []string
+	for _, segment := range segments {
+		if segment.token != nil {
+			texts = append(texts, "["+segment.text.text+"]")
+		} else {
+			texts = append(texts, segment.text.text)
+		}
+	}
+	return texts
+}
+
+// TestAddedTokenSplit 测试added token的最长匹配与lstrip/rstrip/single_word规则
+func TestAddedTokenSplit(t *testing.T) {
+	tokens := []AddedToken{
+		{ID: 1, Content: "<|user|>", Special: true},
+		{ID: 2, Content: "<|u", Special: true},
+		{ID: 3, Content: "<mask>", LStrip: true, Special: true},
+		{ID: 4, Content: "<sep>", RStrip: true, Special: true},
+		{ID: 5, Content: "ab", SingleWord: true},
+		{ID: 6, Content: "Hello", Normalized: true},
+	}
+	vocab := newAddedVocabulary(tokens, lowercaseNormalizer{})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"<|user|>hi<|u", []string{"[<|user|>]", "hi", "[<|u]"}},
+		{"a <mask> b", []string{"a", "[ <mask>]", " b"}},
+		{"x<sep>  y", []string{"x", "[<sep>  ]", "y"}},
+		{"ab cab abc ab", []string{"[ab]", " cab abc ", "[ab]"}},
+		{"no tokens", []string{"no tokens"}},
+	}
+	for _, tt := range tests {
+		got := segmentTexts(vocab.raw.split(newNormalizedString(tt.text)))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("split(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	// normalized token在规范化后的文本上匹配
+	normalized := lowercaseNormalizer{}.normalize(newNormalizedString("HELLO world"))
+	got := segmentTexts(vocab.normalized.split(normalized))
+	if want := []string{"[hello]", " world"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("normalized split = %q, want %q", got, want)
+	}
+}
+
+// TestEncodeAddedTokens 测试特殊token不会被模型拆散，并在结果中标记
+func TestEncodeAddedTokens(t *testing.T) {
+	tk, err := NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	result, err := tk.Tokenize("<|endoftext|>hello<|endoftext|>")
+	if err != nil {
+		t.Fatalf("Tokenization failed: %v", err)
+	}
+	if want := []string{"<|endoftext|>", "hello", "<|endoftext|>"}; !reflect.DeepEqual(result.Tokens, want) {
+		t.Errorf("tokens = %q, want %q", result.Tokens, want)
+	}
+	if want := []int{1, 0, 1}; !reflect.DeepEqual(result.SpecialTokensMask, want) {
+		t.Errorf("special tokens mask = %v, want %v", result.SpecialTokensMask, want)
+	}
+}
@@ -0,0 +1,84 @@
+package tokenizer
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchItem 批量编码中一条文本的结果，编码失败时Error非空
+type BatchItem struct {
+	Index  int              `json:"index"`
+	Result *TokenizerResult `json:"result,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// BatchResult 批量编码结果，Items与输入文本顺序一致
+type BatchResult struct {
+	Items      []BatchItem `json:"items"`
+	TokenCount int         `json:"token_count"` // 全部成功条目的token总数
+	ErrorCount int         `json:"error_count"`
+	ModelName  string      `json:"model_name"`
+}
+
+// EncodeBatch 使用runtime.NumCPU()个goroutine并行编码多条文本，单条文本的错误记录在对应条目中。
+// 指定填充且未给出固定长度时，与HF一致填充到批次中最长的序列
+func (t *Tokenizer) EncodeBatch(texts []string, opts EncodeOptions) (*BatchResult, error) {
+	var padding PaddingOptions
+	if opts.Padding != nil {
+		var err error
+		if padding, err = t.paddingOptions(*opts.Padding); err != nil {
+			return nil, err
+		}
+	}
+	encodeOpts := opts
+	encodeOpts.Padding = nil
+
+	encodings := make([]*Encoding, len(texts))
+	errs := make([]error, len(texts))
+	parallel(len(texts), func(i int) {
+		encodings[i], errs[i] = t.EncodeWithOptions(texts[i], encodeOpts)
+	})
+
+	if opts.Padding != nil {
+		var encoded []*Encoding
+		for _, encoding := range encodings {
+			if encoding != nil {
+				encoded = append(encoded, encoding)
+			}
+		}
+		padEncodings(encoded, padding)
+	}
+
+	result := &BatchResult{Items: make([]BatchItem, len(texts)), ModelName: t.config.ModelName}
+	for i, encoding := range encodings {
+		result.Items[i].Index = i
+		if errs[i] != nil {
+			result.Items[i].Error = errs[i].Error()
+			result.ErrorCount++
+			continue
+		}
+		result.Items[i].Result = t.newResult(texts[i], encoding)
+		result.TokenCount += encoding.Len()
+	}
+	return result, nil
+}
+
+// parallel 使用runtime.NumCPU()个goroutine对0..n-1执行fn，全部完成后返回
+func parallel(n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < minInt(runtime.NumCPU(), n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
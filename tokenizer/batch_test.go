@@ -0,0 +1,59 @@
+package tokenizer_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestEncodeBatch 测试批量编码的结果顺序、单条错误与批次内填充
+func TestEncodeBatch(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+
+	texts := make([]string, 100)
+	for i := range texts {
+		texts[i] = "is good" + fmt.Sprint(" .", i%3)
+	}
+	batch, err := tk.EncodeBatch(texts, tokenizer.EncodeOptions{})
+	if err != nil {
+		t.Fatalf("EncodeBatch failed: %v", err)
+	}
+	if len(batch.Items) != len(texts) || batch.ErrorCount != 0 {
+		t.Fatalf("items = %d, errors = %d", len(batch.Items), batch.ErrorCount)
+	}
+	total := 0
+	for i, item := range batch.Items {
+		want, err := tk.Tokenize(texts[i])
+		if err != nil {
+			t.Fatalf("Tokenize failed: %v", err)
+		}
+		if item.Index != i || !reflect.DeepEqual(item.Result.TokenIDs, want.TokenIDs) {
+			t.Errorf("item %d = %+v, want ids %v", i, item, want.TokenIDs)
+		}
+		total += want.TokenCount
+	}
+	if batch.TokenCount != total {
+		t.Errorf("token count = %d, want %d", batch.TokenCount, total)
+	}
+
+	// 未指定长度时填充到批次中最长的序列，截断失败的条目单独报错
+	batch, err = tk.EncodeBatch([]string{"is", "is good .", "Unaffable is good."}, tokenizer.EncodeOptions{
+		Truncation: &tokenizer.TruncationOptions{MaxLength: 4, Stride: 4},
+		Padding:    &tokenizer.PaddingOptions{PadToken: "[UNK]"},
+	})
+	if err != nil {
+		t.Fatalf("EncodeBatch failed: %v", err)
+	}
+	if batch.ErrorCount != 1 || batch.Items[2].Error == "" {
+		t.Errorf("expected error for the last item: %+v", batch.Items[2])
+	}
+	if want := []int{6, 0, 0}; !reflect.DeepEqual(batch.Items[0].Result.TokenIDs, want) {
+		t.Errorf("padded ids = %v, want %v", batch.Items[0].Result.TokenIDs, want)
+	}
+
+	if _, err := tk.EncodeBatch([]string{"is"}, tokenizer.EncodeOptions{Padding: &tokenizer.PaddingOptions{}}); err == nil {
+		t.Error("expected error for padding without pad token")
+	}
+}
@@ -0,0 +1,96 @@
+package tokenizer_test
+
+import (
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// corpusSize 基准测试语料的大小
+const corpusSize = 1 << 20
+
+// benchmarkCorpus 生成约1MB的语料。random为true时由随机字母组成的词构成，
+// 几乎不会命中词缓存；否则为重复的自然语言文本
+func benchmarkCorpus(random bool) string {
+	sentences := []string{
+		"The quick brown fox jumps over the lazy dog.",
+		"Hello, world! Tokenizers split text into tokens with merges.",
+		"你好，世界。分词器将文本切分为token。",
+		"func main() { fmt.Println(\"hello\") }\n",
+	}
+	rng := rand.New(rand.NewSource(1))
+	var sb strings.Builder
+	for sb.Len() < corpusSize {
+		if !random {
+			sb.WriteString(sentences[rng.Intn(len(sentences))])
+			sb.WriteByte(' ')
+			continue
+		}
+		for i := 3 + rng.Intn(8); i > 0; i-- {
+			sb.WriteByte(byte('a' + rng.Intn(26)))
+		}
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+// loadBenchmarkTokenizer 加载测试用的字节级BPE tokenizer
+func loadBenchmarkTokenizer(b *testing.B) *tokenizer.Tokenizer {
+	b.Helper()
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		b.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	return tk
+}
+
+func benchmarkEncode(b *testing.B, random bool) {
+	tk := loadBenchmarkTokenizer(b)
+	corpus := benchmarkCorpus(random)
+	b.SetBytes(int64(len(corpus)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tk.Encode(corpus); err != nil {
+			b.Fatalf("Encode failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkEncode 编码1MB自然语言文本
+func BenchmarkEncode(b *testing.B) { benchmarkEncode(b, false) }
+
+// BenchmarkEncodeRandomWords 编码1MB随机词，测量未命中缓存时的BPE合并
+func BenchmarkEncodeRandomWords(b *testing.B) { benchmarkEncode(b, true) }
+
+// BenchmarkTokenize 对1MB文本执行完整的tokenize（含统计信息）
+func BenchmarkTokenize(b *testing.B) {
+	tk := loadBenchmarkTokenizer(b)
+	corpus := benchmarkCorpus(false)
+	b.SetBytes(int64(len(corpus)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tk.Tokenize(corpus); err != nil {
+			b.Fatalf("Tokenize failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkEncodeBatch 并行编码1MB文本的各行
+func BenchmarkEncodeBatch(b *testing.B) {
+	tk := loadBenchmarkTokenizer(b)
+	corpus := benchmarkCorpus(false)
+	texts := strings.SplitAfter(corpus, ". ")
+	b.SetBytes(int64(len(corpus)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tk.EncodeBatch(texts, tokenizer.EncodeOptions{}); err != nil {
+			b.Fatalf("EncodeBatch failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// byteEncoder 将每个字节映射为一个可见的unicode字符（GPT-2的bytes_to_unicode），
+// byteDecoder 为其逆映射
+var byteEncoder, byteDecoder = newByteLevelAlphabet()
+
+// newByteLevelAlphabet 构建GPT-2字节级字母表：可打印字节保持不变，
+// 其余字节按顺序映射到256之后的码位（空格变为Ġ，换行变为Ċ）
+func newByteLevelAlphabet() ([256]rune, map[rune]byte) {
+	var encoder [256]rune
+	decoder := make(map[rune]byte, 256)
+	n := 0
+	for b := 0; b < 256; b++ {
+		if (b >= '!' && b <= '~') || (b >= 0xA1 && b <= 0xAC) || (b >= 0xAE && b <= 0xFF) {
+			encoder[b] = rune(b)
+		} else {
+			encoder[b] = rune(256 + n)
+			n++
+		}
+		decoder[encoder[b]] = byte(b)
+	}
+	return encoder, decoder
+}
+
+// MergeList BPE merges列表，兼容 [["a","b"]] 与 ["a b"] 两种格式。
+// 数组格式的token可以包含空格，因此始终按两个token保存，不再拼接为字符串
+type MergeList [][2]string
+
+// UnmarshalJSON 解析两种格式的merges
+func (m *MergeList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	merges := make([][2]string, 0, len(raw))
+	for _, item := range raw {
+		var pair []string
+		if err := json.Unmarshal(item, &pair); err == nil {
+			if len(pair) != 2 {
+				return fmt.Errorf("invalid merge: %s", item)
+			}
+			merges = append(merges, [2]string{pair[0], pair[1]})
+			continue
+		}
+		var merge string
+		if err := json.Unmarshal(item, &merge); err != nil {
+			return err
+		}
+		parts := strings.SplitN(merge, " ", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid merge: %q", merge)
+		}
+		merges = append(merges, [2]string{parts[0], parts[1]})
+	}
+
+	*m = merges
+	return nil
+}
+
+// MergeRanks BPE merges的rank，键为合并的两个token，值越小越先合并。
+// JSON中以"a b"为键，与旧格式的配置文件兼容
+type MergeRanks map[[2]string]int
+
+// MarshalJSON 以"a b"为键输出
+func (m MergeRanks) MarshalJSON() ([]byte, error) {
+	ranks := make(map[string]int, len(m))
+	for pair, rank := range m {
+		ranks[pair[0]+" "+pair[1]] = rank
+	}
+	return json.Marshal(ranks)
+}
+
+// UnmarshalJSON 解析以"a b"为键的merges
+func (m *MergeRanks) UnmarshalJSON(data []byte) error {
+	var ranks map[string]int
+	if err := json.Unmarshal(data, &ranks); err != nil {
+		return err
+	}
+	*m = make(MergeRanks, len(ranks))
+	for merge, rank := range ranks {
+		parts := strings.SplitN(merge, " ", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid merge: %q", merge)
+		}
+		(*m)[[2]string{parts[0], parts[1]}] = rank
+	}
+	return nil
+}
+
+// bpeCacheSize 词分词结果缓存的容量，maxCachedWordLength 以上字节数的词不缓存
+const (
+	bpeCacheSize        = 10000
+	maxCachedWordLength = 256
+)
+
+// bpeMerge 一对相邻token合并后的token ID，rank越小越先合并
+type bpeMerge struct {
+	rank  int
+	newID int
+}
+
+// bpeModel 加载时由merges构建的BPE模型：符号与合并都以token ID表示，避免合并时拼接字符串
+type bpeModel struct {
+	merges map[uint64]bpeMerge // 键为pairKey(左token ID, 右token ID)
+	cache  *lruCache           // 词到分词结果的缓存
+}
+
+// pairKey 将相邻两个token ID组合为merges的键
+func pairKey(left, right int) uint64 {
+	return uint64(uint32(left))<<32 | uint64(uint32(right))
+}
+
+// newBPEModel 由配置中的merges构建BPE模型，忽略涉及词汇表外token的merge。
+// 同一对token出现多次时取rank最小的
+func newBPEModel(config *TokenizerConfig) *bpeModel {
+	m := &bpeModel{merges: make(map[uint64]bpeMerge, len(config.Merges)), cache: newLRUCache(bpeCacheSize)}
+	if config.RankedMerges {
+		m.addRankedMerges(config)
+	}
+	for pair, rank := range config.Merges {
+		left, leftExists := config.Vocabulary[pair[0]]
+		right, rightExists := config.Vocabulary[pair[1]]
+		merged, mergedExists := config.Vocabulary[pair[0]+strings.TrimPrefix(pair[1], config.ContinuingSubwordPrefix)]
+		if !leftExists || !rightExists || !mergedExists {
+			continue
+		}
+		key := pairKey(left, right)
+		if existing, exists := m.merges[key]; !exists || rank < existing.rank {
+			m.merges[key] = bpeMerge{rank: rank, newID: merged}
+		}
+	}
+	return m
+}
+
+// addRankedMerges 由词汇表推导merges（tiktoken）：token的每种两段拆分中，两段都在词汇表中时
+// 这对token可以合并为该token，rank即该token的ID。added token不参与合并
+func (m *bpeModel) addRankedMerges(config *TokenizerConfig) {
+	added := make(map[int]bool, len(config.AddedTokens))
+	for _, token := range config.AddedTokens {
+		added[token.ID] = true
+	}
+	for token, id := range config.Vocabulary {
+		if added[id] {
+			continue
+		}
+		for i := range token {
+			if i == 0 {
+				continue
+			}
+			left, leftExists := config.Vocabulary[token[:i]]
+			right, rightExists := config.Vocabulary[token[i:]]
+			if leftExists && rightExists && !added[left] && !added[right] {
+				m.merges[pairKey(left, right)] = bpeMerge{rank: id, newID: id}
+			}
+		}
+	}
+}
+
+// bpeSymbol 合并过程中的一个符号，以双向链表连接，被合并掉的符号start等于end
+type bpeSymbol struct {
+	id         int // 不在词汇表中时为-1
+	start, end int // 在词中的字节区间
+	prev, next int // 相邻符号的下标，-1表示没有
+}
+
+// bpeCandidate 优先队列中待合并的符号对：pos处的符号与其后一个符号合并为newID
+type bpeCandidate struct {
+	rank, pos, newID int
+}
+
+// bpeWorkspace 单次合并使用的缓冲区，通过bpeWorkspaces复用
+type bpeWorkspace struct {
+	symbols []bpeSymbol
+	queue   []bpeCandidate // 按(rank, pos)排列的最小堆
+}
+
+var bpeWorkspaces = sync.Pool{New: func() interface{} { return &bpeWorkspace{} }}
+
+// less 先比较rank，rank相同时取靠左的符号对
+func (c bpeCandidate) less(other bpeCandidate) bool {
+	return c.rank < other.rank || (c.rank == other.rank && c.pos < other.pos)
+}
+
+// push 如果pos处的符号与其后一个符号可以合并，将其加入优先队列
+func (w *bpeWorkspace) push(merges map[uint64]bpeMerge, pos int) {
+	next := w.symbols[pos].next
+	if next < 0 {
+		return
+	}
+	merge, exists := merges[pairKey(w.symbols[pos].id, w.symbols[next].id)]
+	if !exists {
+		return
+	}
+	w.queue = append(w.queue, bpeCandidate{rank: merge.rank, pos: pos, newID: merge.newID})
+	for i := len(w.queue) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if !w.queue[i].less(w.queue[parent]) {
+			break
+		}
+		w.queue[i], w.queue[parent] = w.queue[parent], w.queue[i]
+		i = parent
+	}
+}
+
+// pop 取出优先级最高的符号对
+func (w *bpeWorkspace) pop() bpeCandidate {
+	top := w.queue[0]
+	last := len(w.queue) - 1
+	w.queue[0] = w.queue[last]
+	w.queue = w.queue[:last]
+	for i := 0; ; {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < last && w.queue[left].less(w.queue[smallest]) {
+			smallest = left
+		}
+		if right < last && w.queue[right].less(w.queue[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		w.queue[i], w.queue[smallest] = w.queue[smallest], w.queue[i]
+		i = smallest
+	}
+	return top
+}
+
+// symbolValue 返回词中[start, end)区间对应的token：非词首加子词前缀，词尾加后缀
+func (t *Tokenizer) symbolValue(word string, start, end int) string {
+	value := word[start:end]
+	if start > 0 && t.config.ContinuingSubwordPrefix != "" {
+		value = t.config.ContinuingSubwordPrefix + value
+	}
+	if end == len(word) && t.config.EndOfWordSuffix != "" {
+		value += t.config.EndOfWordSuffix
+	}
+	return value
+}
+
+// applyBPE 应用BPE算法：每次合并rank最小（优先级最高）的相邻符号对，
+// rank相同时取最左侧的一对，直到没有可合并的符号对。
+// 符号对放在按(rank, 位置)排列的优先队列中，合并后只需检查新符号与两侧的符号对
+func (t *Tokenizer) applyBPE(word string) []modelToken {
+	w := bpeWorkspaces.Get().(*bpeWorkspace)
+	defer bpeWorkspaces.Put(w)
+
+	w.symbols, w.queue = w.symbols[:0], w.queue[:0]
+	for i, r := range word {
+		end := i + utf8.RuneLen(r)
+		id, exists := t.config.Vocabulary[t.symbolValue(word, i, end)]
+		if !exists {
+			id = -1
+		}
+		w.symbols = append(w.symbols, bpeSymbol{id: id, start: i, end: end, prev: len(w.symbols) - 1, next: len(w.symbols) + 1})
+	}
+	if len(w.symbols) == 0 {
+		return nil
+	}
+	w.symbols[len(w.symbols)-1].next = -1
+
+	if t.bpe != nil {
+		for i := range w.symbols {
+			w.push(t.bpe.merges, i)
+		}
+		for len(w.queue) > 0 {
+			candidate := w.pop()
+			symbol := &w.symbols[candidate.pos]
+			if symbol.start == symbol.end || symbol.next < 0 {
+				continue
+			}
+			// 符号对在入队后可能已因其他合并而改变
+			next := &w.symbols[symbol.next]
+			merge, exists := t.bpe.merges[pairKey(symbol.id, next.id)]
+			if !exists || merge.newID != candidate.newID {
+				continue
+			}
+
+			symbol.id, symbol.end, symbol.next = merge.newID, next.end, next.next
+			next.end = next.start
+			if symbol.next >= 0 {
+				w.symbols[symbol.next].prev = candidate.pos
+			}
+			if symbol.prev >= 0 {
+				w.push(t.bpe.merges, symbol.prev)
+			}
+			w.push(t.bpe.merges, candidate.pos)
+		}
+	}
+
+	var tokens []modelToken
+	for i := 0; i >= 0; i = w.symbols[i].next {
+		symbol := w.symbols[i]
+		token := modelToken{value: t.symbolValue(word, symbol.start, symbol.end), offsets: [2]int{symbol.start, symbol.end}}
+		if symbol.id >= 0 {
+			token.id, token.hasID = symbol.id, true
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,128 @@
+package tokenizer_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// writeConfig 将配置写入临时目录并加载tokenizer
+func writeConfig(t *testing.T, config string) *tokenizer.Tokenizer {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "tokenizer.json")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tk, err := tokenizer.NewTokenizer(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	return tk
+}
+
+// TestByteLevelBPE 测试字节级BPE的tokens与IDs
+func TestByteLevelBPE(t *testing.T) {
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	tests := []struct {
+		text   string
+		tokens []string
+		ids    []int
+	}{
+		{
+			text:   "Hello, world! This is a test.",
+			tokens: []string{"Hello", ",", "Ġworld", "!", "ĠThis", "Ġis", "Ġa", "Ġtest", "."},
+			ids:    []int{282, 11, 270, 0, 301, 304, 276, 277, 13},
+		},
+		{
+			text:   "héllo 123",
+			tokens: []string{"h", "Ã", "©", "llo", "Ġ", "1", "2", "3"},
+			ids:    []int{71, 127, 102, 265, 220, 16, 17, 18},
+		},
+	}
+
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenization failed: %v", err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) {
+			t.Errorf("Tokenize(%q) tokens = %q, want %q", tt.text, result.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(result.TokenIDs, tt.ids) {
+			t.Errorf("Tokenize(%q) ids = %v, want %v", tt.text, result.TokenIDs, tt.ids)
+		}
+	}
+}
+
+// TestBPEMergeRank 测试按rank而非出现位置合并
+func TestBPEMergeRank(t *testing.T) {
+	tk := writeConfig(t, `{
+		"model": {
+			"type": "BPE",
+			"vocab": {"a": 0, "b": 1, "c": 2, "ab": 3, "bc": 4},
+			"merges": ["b c", ["a", "b"]]
+		}
+	}`)
+
+	result, err := tk.Tokenize("abc")
+	if err != nil {
+		t.Fatalf("Tokenization failed: %v", err)
+	}
+
+	want := []string{"a", "bc"}
+	if !reflect.DeepEqual(result.Tokens, want) {
+		t.Errorf("Tokens = %q, want %q", result.Tokens, want)
+	}
+}
+
+// TestBPEMergeWithSpaces 测试数组格式的merges中包含空格的token
+func TestBPEMergeWithSpaces(t *testing.T) {
+	tk := writeConfig(t, `{
+		"model": {
+			"type": "BPE",
+			"vocab": {"a": 0, " ": 1, "b": 2, "a ": 3, "a b": 4},
+			"merges": [["a", " "], ["a ", "b"]]
+		}
+	}`)
+
+	result, err := tk.Tokenize("a b")
+	if err != nil {
+		t.Fatalf("Tokenization failed: %v", err)
+	}
+	if want := []string{"a b"}; !reflect.DeepEqual(result.Tokens, want) {
+		t.Errorf("Tokens = %q, want %q", result.Tokens, want)
+	}
+}
+
+// TestBPEWithoutUnk 测试没有unknown token的模型：不虚构unknown token，未知字符被丢弃
+func TestBPEWithoutUnk(t *testing.T) {
+	tk := writeConfig(t, `{
+		"pre_tokenizer": {"type": "ByteLevel", "add_prefix_space": false, "use_regex": true},
+		"model": {
+			"type": "BPE",
+			"unk_token": null,
+			"vocab": {"h": 0, "i": 1, "Ġ": 2, "hi": 3},
+			"merges": [["h", "i"]]
+		}
+	}`)
+
+	result, err := tk.Tokenize("hi x hi")
+	if err != nil {
+		t.Fatalf("Tokenization failed: %v", err)
+	}
+	if want := []int{3, 2, 2, 3}; !reflect.DeepEqual(result.TokenIDs, want) {
+		t.Errorf("TokenIDs = %v, want %v", result.TokenIDs, want)
+	}
+	if size := tk.GetVocabSize(); size != 4 {
+		t.Errorf("GetVocabSize() = %d, want 4", size)
+	}
+}
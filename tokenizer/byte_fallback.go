@@ -0,0 +1,61 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// resolveUnknown 处理模型输出中不在词汇表的token：启用byte_fallback且对应的 <0xNN> token
+// 都存在时拆为字节token，否则在fuse_unk时将相邻的unknown合并为一个
+func (t *Tokenizer) resolveUnknown(tokens []modelToken) []modelToken {
+	if !t.config.ByteFallback && !t.config.FuseUnk {
+		return tokens
+	}
+
+	result := make([]modelToken, 0, len(tokens))
+	prevUnknown := false
+	for _, token := range tokens {
+		if _, exists := t.tokenID(token); exists {
+			result = append(result, token)
+			prevUnknown = false
+			continue
+		}
+
+		if t.config.ByteFallback {
+			if byteTokens, ok := t.byteFallbackTokens(token.value); ok {
+				// 每个字节token都对应整个字符所在的区间
+				for _, byteToken := range byteTokens {
+					result = append(result, modelToken{value: byteToken, offsets: token.offsets})
+				}
+				prevUnknown = false
+				continue
+			}
+		}
+
+		if t.config.FuseUnk && prevUnknown {
+			last := &result[len(result)-1]
+			last.value += strings.TrimPrefix(token.value, t.config.ContinuingSubwordPrefix)
+			last.offsets[1] = token.offsets[1]
+			continue
+		}
+		result = append(result, token)
+		prevUnknown = true
+	}
+	return result
+}
+
+// byteFallbackTokens 将token的UTF-8字节转换为 <0xNN> token，任一字节不在词汇表中时返回false
+func (t *Tokenizer) byteFallbackTokens(token string) ([]string, bool) {
+	text := strings.TrimPrefix(token, t.config.ContinuingSubwordPrefix)
+	text = strings.TrimSuffix(text, t.config.EndOfWordSuffix)
+
+	byteTokens := make([]string, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		byteToken := fmt.Sprintf("<0x%02X>", text[i])
+		if _, exists := t.config.Vocabulary[byteToken]; !exists {
+			return nil, false
+		}
+		byteTokens = append(byteTokens, byteToken)
+	}
+	return byteTokens, len(byteTokens) > 0
+}
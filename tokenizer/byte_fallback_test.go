@@ -0,0 +1,76 @@
+package tokenizer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// llamaConfig Llama风格的BPE配置：Metaspace预分词，未知字符回退为 <0xNN> 字节token
+const llamaConfig = `{
+	"added_tokens": [
+		{"id": 0, "content": "<unk>", "special": true},
+		{"id": 1, "content": "<s>", "special": true},
+		{"id": 2, "content": "</s>", "special": true}
+	],
+	"normalizer": null,
+	"pre_tokenizer": {"type": "Metaspace", "replacement": "▁", "prepend_scheme": "first", "split": false},
+	"decoder": {"type": "Sequence", "decoders": [
+		{"type": "Replace", "pattern": {"String": "▁"}, "content": " "},
+		{"type": "ByteFallback"},
+		{"type": "Fuse"},
+		{"type": "Strip", "content": " ", "start": 1, "stop": 0}
+	]},
+	"model": {"type": "BPE", "unk_token": "<unk>", "byte_fallback": true, "fuse_unk": true,
+		"vocab": {"<unk>": 0, "<s>": 1, "</s>": 2, "<0xC3>": 3, "<0xA9>": 4, "▁": 5, "h": 6, "i": 7, "▁h": 8, "▁hi": 9},
+		"merges": ["▁ h", "▁h i"]}
+}`
+
+// TestByteFallback 测试未知字符拆为字节token、无法回退时的unknown合并以及统计
+func TestByteFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       string
+		text         string
+		tokens       []string
+		ids          []int
+		unknown      int
+		byteFallback int
+	}{
+		{"byte fallback", llamaConfig, "hi é",
+			[]string{"▁hi", "▁", "<0xC3>", "<0xA9>"}, []int{9, 5, 3, 4}, 2, 2},
+		{"fuse unk", strings.Replace(llamaConfig, `"byte_fallback": true`, `"byte_fallback": false`, 1), "hi éé",
+			[]string{"▁hi", "▁", "éé"}, []int{9, 5, 0}, 1, 0},
+		{"no fuse", strings.Replace(llamaConfig, `"byte_fallback": true, "fuse_unk": true`, `"byte_fallback": false, "fuse_unk": false`, 1), "hi éé",
+			[]string{"▁hi", "▁", "é", "é"}, []int{9, 5, 0, 0}, 2, 0},
+		{"unigram", strings.Replace(strings.Replace(t5Config, `"unk_id": 0`, `"unk_id": 0, "byte_fallback": true`, 1),
+			`["d", -5.0]`, `["d", -5.0], ["<0xE6>", 0.0], ["<0x98>", 0.0], ["<0xAF>", 0.0]`, 1), "world是",
+			[]string{"▁world", "<0xE6>", "<0x98>", "<0xAF>"}, []int{9, 13, 14, 15}, 3, 3},
+	}
+	for _, tt := range tests {
+		tk := writeConfig(t, tt.config)
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("%s: tokenization failed: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) {
+			t.Errorf("%s: tokens = %q, want %q", tt.name, result.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(result.TokenIDs, tt.ids) {
+			t.Errorf("%s: ids = %v, want %v", tt.name, result.TokenIDs, tt.ids)
+		}
+		if result.UnknownCount != tt.unknown || result.ByteFallbackCount != tt.byteFallback {
+			t.Errorf("%s: unknown = %d, byte fallback = %d, want %d, %d",
+				tt.name, result.UnknownCount, result.ByteFallbackCount, tt.unknown, tt.byteFallback)
+		}
+	}
+
+	tk := writeConfig(t, llamaConfig)
+	text, err := tk.Decode([]int{9, 5, 3, 4})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if text != "hi é" {
+		t.Errorf("Decode = %q, want %q", text, "hi é")
+	}
+}
@@ -0,0 +1,57 @@
+package tokenizer
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache 并发安全、容量有限的LRU缓存，保存词到模型分词结果的映射。
+// 缓存的切片由多个调用方共享，不能被修改
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // 最近使用的在前
+}
+
+// lruEntry 缓存条目
+type lruEntry struct {
+	key    string
+	tokens []modelToken
+}
+
+// newLRUCache 创建容量为capacity的LRU缓存
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{capacity: capacity, items: make(map[string]*list.Element), order: list.New()}
+}
+
+// get 查找缓存，命中时将其标记为最近使用
+func (c *lruCache) get(key string) ([]modelToken, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, exists := c.items[key]
+	if !exists {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry).tokens, true
+}
+
+// add 加入缓存，超出容量时淘汰最久未使用的条目
+func (c *lruCache) add(key string, tokens []modelToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, exists := c.items[key]; exists {
+		element.Value.(*lruEntry).tokens = tokens
+		c.order.MoveToFront(element)
+		return
+	}
+	// 复制key，避免缓存引用所在的整段文本
+	key = string([]byte(key))
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, tokens: tokens})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,47 @@
+package tokenizer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLRUCache 测试LRU缓存的命中与淘汰顺序
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.add("a", []modelToken{{value: "a"}})
+	cache.add("b", []modelToken{{value: "b"}})
+	if _, ok := cache.get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.add("c", []modelToken{{value: "c"}}) // 淘汰最久未使用的b
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if tokens, ok := cache.get(key); !ok || tokens[0].value != key {
+			t.Errorf("get(%q) = %v, %v", key, tokens, ok)
+		}
+	}
+}
+
+// TestBPEWordCache 测试命中词缓存时的分词结果与首次分词一致
+func TestBPEWordCache(t *testing.T) {
+	tk, err := NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	for _, word := range []string{"Ġworld", "Hello", "ĠThis", "Ã©llo"} {
+		first := tk.bpeWord(word)
+		if _, ok := tk.bpe.cache.get(word); !ok {
+			t.Errorf("expected %q to be cached", word)
+		}
+		if cached := tk.bpeWord(word); !reflect.DeepEqual(cached, first) {
+			t.Errorf("bpeWord(%q) cached = %+v, want %+v", word, cached, first)
+		}
+		if !reflect.DeepEqual(first, tk.resolveUnknown(tk.applyBPE(word))) {
+			t.Errorf("bpeWord(%q) = %+v differs from applyBPE", word, first)
+		}
+	}
+}
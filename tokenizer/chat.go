@@ -0,0 +1,230 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ChatOptions chat模式的渲染选项，消息与工具为OpenAI格式的JSON对象
+type ChatOptions struct {
+	Messages            []json.RawMessage
+	Tools               []json.RawMessage
+	AddGenerationPrompt bool
+	Template            string          // 覆盖模型自带的chat template
+	TemplateKwargs      json.RawMessage // 传给模板的额外变量，如 {"enable_thinking": false}
+}
+
+// MessageTokenCount 单条消息占用的token数
+type MessageTokenCount struct {
+	Index      int    `json:"index"`
+	Role       string `json:"role"`
+	TokenCount int    `json:"token_count"`
+}
+
+// ChatResult chat模板的渲染与编码结果
+type ChatResult struct {
+	Prompt                 string              `json:"prompt"`
+	Tokens                 []string            `json:"tokens"`
+	TokenIDs               []int               `json:"token_ids"`
+	TokenCount             int                 `json:"token_count"`
+	MessageTokens          []MessageTokenCount `json:"message_tokens"`
+	GenerationPromptTokens int                 `json:"generation_prompt_tokens"` // add_generation_prompt追加的token数
+	ModelName              string              `json:"model_name"`
+}
+
+// parseChatTemplates 解析 tokenizer_config.json 中的 chat_template：
+// 为列表 [{"name": ..., "template": ...}] 时按name保存，单个模板保存为default
+func parseChatTemplates(raw json.RawMessage) (map[string]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var template string
+	if err := json.Unmarshal(raw, &template); err == nil {
+		return map[string]string{"default": template}, nil
+	}
+	var named []struct {
+		Name     string `json:"name"`
+		Template string `json:"template"`
+	}
+	if err := json.Unmarshal(raw, &named); err != nil {
+		return nil, fmt.Errorf("invalid chat_template: %v", err)
+	}
+	templates := make(map[string]string, len(named))
+	for _, t := range named {
+		templates[t.Name] = t.Template
+	}
+	return templates, nil
+}
+
+// loadChatTemplateFile 读取模型目录下的 chat_template.jinja，它优先于 tokenizer_config.json 中的模板
+func loadChatTemplateFile(config *TokenizerConfig, dir string) {
+	if data, err := os.ReadFile(filepath.Join(dir, "chat_template.jinja")); err == nil {
+		config.ChatTemplates = map[string]string{"default": string(data)}
+	}
+}
+
+// HasChatTemplate 模型是否自带chat template
+func (t *Tokenizer) HasChatTemplate() bool {
+	return len(t.config.ChatTemplates) > 0
+}
+
+// chatTemplate 选择要使用的模板：有工具时优先tool_use，其次default，只有一个模板时直接使用
+func (t *Tokenizer) chatTemplate(opts ChatOptions) (*jinjaTemplate, error) {
+	source := opts.Template
+	if source == "" {
+		templates := t.config.ChatTemplates
+		var ok bool
+		if len(opts.Tools) > 0 {
+			source, ok = templates["tool_use"]
+		}
+		if !ok {
+			source, ok = templates["default"]
+		}
+		if !ok && len(templates) == 1 {
+			for _, only := range templates {
+				source, ok = only, true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("model %s has no chat template", t.config.ModelName)
+		}
+	}
+
+	template, err := parseJinja(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chat template: %v", err)
+	}
+	return template, nil
+}
+
+// ApplyChatTemplate 用chat template渲染消息列表并编码（不再添加特殊token，与HF apply_chat_template一致）。
+// 模板只渲染一次：for循环遍历到某条消息时记录输出位置，该位置到下一条消息（或循环结束）之间的输出属于这条消息，
+// token按起始字节归属。消息循环之前的输出（如系统提示、工具定义）计入之后的第一条消息，
+// 最后一次消息循环之后的输出在add_generation_prompt时为生成提示，否则计入最后一条消息
+func (t *Tokenizer) ApplyChatTemplate(opts ChatOptions) (*ChatResult, error) {
+	if len(opts.Messages) == 0 {
+		return nil, fmt.Errorf("messages must not be empty")
+	}
+	template, err := t.chatTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := t.chatVariables(opts)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]interface{}, len(opts.Messages))
+	indices := make(map[interface{}]int, len(opts.Messages))
+	result := &ChatResult{ModelName: t.config.ModelName, MessageTokens: make([]MessageTokenCount, len(opts.Messages))}
+	for i, raw := range opts.Messages {
+		message, err := decodeJinjaValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message %d: %v", i, err)
+		}
+		dict, ok := message.(*jinjaDict)
+		if !ok {
+			return nil, fmt.Errorf("invalid message %d: expected an object", i)
+		}
+		result.MessageTokens[i].Index = i
+		if role, ok := dict.get("role"); ok {
+			result.MessageTokens[i].Role = toString(role)
+		}
+		messages[i] = dict
+		indices[dict] = i
+	}
+	vars["messages"] = messages
+	vars["add_generation_prompt"] = opts.AddGenerationPrompt
+
+	prompt, marks, err := template.renderTracked(vars, indices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chat template: %v", err)
+	}
+	encoding, err := t.EncodeWithOptions(prompt, EncodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result.Prompt = prompt
+	result.Tokens = encoding.Tokens
+	result.TokenIDs = encoding.IDs
+	result.TokenCount = encoding.Len()
+
+	owners := chatMarkOwners(marks, len(messages), opts.AddGenerationPrompt)
+	m := 0
+	for _, offsets := range encoding.ByteOffsets {
+		for m+1 < len(marks) && marks[m+1].offset <= offsets[0] {
+			m++
+		}
+		owner := owners[0]
+		if len(marks) > 0 && marks[0].offset <= offsets[0] {
+			owner = owners[m+1]
+		}
+		if owner < 0 {
+			result.GenerationPromptTokens++
+		} else {
+			result.MessageTokens[owner].TokenCount++
+		}
+	}
+	return result, nil
+}
+
+// chatMarkOwners 计算各段输出所属的消息：owners[0]为第一个位置之前的输出，owners[i+1]为marks[i]起的输出，
+// -1表示生成提示。循环结束处之后的输出属于之后的第一条消息，没有时属于生成提示或最后一条消息
+func chatMarkOwners(marks []jinjaMark, count int, addGenerationPrompt bool) []int {
+	trailing := count - 1
+	if addGenerationPrompt {
+		trailing = -1
+	}
+	owners := make([]int, len(marks)+1)
+	next := trailing
+	if len(marks) == 0 {
+		next = 0 // 模板没有遍历消息时无法区分，全部计入第一条消息
+	}
+	for i := len(marks) - 1; i >= -1; i-- {
+		if i >= 0 && marks[i].index >= 0 {
+			owners[i+1] = marks[i].index
+			next = marks[i].index
+			continue
+		}
+		owners[i+1] = next
+	}
+	return owners
+}
+
+// chatVariables 模板变量：tools、特殊token（bos_token、eos_token等）与额外参数
+func (t *Tokenizer) chatVariables(opts ChatOptions) (map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	for role, token := range t.config.SpecialTokens {
+		vars[role+"_token"] = token
+	}
+
+	if len(opts.Tools) > 0 {
+		tools := make([]interface{}, len(opts.Tools))
+		for i, raw := range opts.Tools {
+			tool, err := decodeJinjaValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tool %d: %v", i, err)
+			}
+			tools[i] = tool
+		}
+		vars["tools"] = tools
+	}
+
+	if len(opts.TemplateKwargs) > 0 && string(opts.TemplateKwargs) != "null" {
+		kwargs, err := decodeJinjaValue(opts.TemplateKwargs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chat_template_kwargs: %v", err)
+		}
+		dict, ok := kwargs.(*jinjaDict)
+		if !ok {
+			return nil, fmt.Errorf("invalid chat_template_kwargs: expected an object")
+		}
+		for _, key := range dict.keys {
+			vars[key] = dict.values[key]
+		}
+	}
+	return vars, nil
+}
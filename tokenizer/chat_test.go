@@ -0,0 +1,229 @@
+package tokenizer_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// chatMLTemplate Qwen2.5风格的ChatML模板，包含工具定义、工具调用与工具结果
+const chatMLTemplate = `{%- if tools %}
+    {{- '<|im_start|>system\n' }}
+    {%- if messages[0]['role'] == 'system' %}
+        {{- messages[0]['content'] }}
+    {%- else %}
+        {{- 'You are a helpful assistant.' }}
+    {%- endif %}
+    {{- "\n\n# Tools\n<tools>" }}
+    {%- for tool in tools %}
+        {{- "\n" }}
+        {{- tool | tojson }}
+    {%- endfor %}
+    {{- "\n</tools><|im_end|>\n" }}
+{%- else %}
+    {%- if messages[0]['role'] == 'system' %}
+        {{- '<|im_start|>system\n' + messages[0]['content'] + '<|im_end|>\n' }}
+    {%- endif %}
+{%- endif %}
+{%- for message in messages %}
+    {%- if (message.role == "user") or (message.role == "system" and not loop.first) or (message.role == "assistant" and not message.tool_calls) %}
+        {{- '<|im_start|>' + message.role + '\n' + message.content + '<|im_end|>' + '\n' }}
+    {%- elif message.role == "assistant" %}
+        {{- '<|im_start|>' + message.role }}
+        {%- if message.content %}
+            {{- '\n' + message.content }}
+        {%- endif %}
+        {%- for tool_call in message.tool_calls %}
+            {%- if tool_call.function is defined %}
+                {%- set tool_call = tool_call.function %}
+            {%- endif %}
+            {{- '\n<tool_call>\n{"name": "' }}
+            {{- tool_call.name }}
+            {{- '", "arguments": ' }}
+            {{- tool_call.arguments | tojson }}
+            {{- '}\n</tool_call>' }}
+        {%- endfor %}
+        {{- '<|im_end|>\n' }}
+    {%- elif message.role == "tool" %}
+        {%- if (loop.index0 == 0) or (messages[loop.index0 - 1].role != "tool") %}
+            {{- '<|im_start|>user' }}
+        {%- endif %}
+        {{- '\n<tool_response>\n' }}
+        {{- message.content }}
+        {{- '\n</tool_response>' }}
+        {%- if loop.last or (messages[loop.index0 + 1].role != "tool") %}
+            {{- '<|im_end|>\n' }}
+        {%- endif %}
+    {%- endif %}
+{%- endfor %}
+{%- if add_generation_prompt %}
+    {{- '<|im_start|>assistant\n' }}
+{%- endif %}
+`
+
+// writeChatModel 在临时目录中写入 tokenizer.json 与带chat template的 tokenizer_config.json
+func writeChatModel(t *testing.T, chatTemplate string) *tokenizer.Tokenizer {
+	t.Helper()
+
+	dir := t.TempDir()
+	fixture, err := os.ReadFile(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	config, _ := json.Marshal(map[string]interface{}{"chat_template": chatTemplate})
+	files := map[string][]byte{"tokenizer.json": fixture, "tokenizer_config.json": config}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tk, err := tokenizer.NewTokenizer(filepath.Join(dir, "tokenizer.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	return tk
+}
+
+func rawMessages(t *testing.T, messages string) []json.RawMessage {
+	t.Helper()
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(messages), &raw); err != nil {
+		t.Fatalf("invalid messages: %v", err)
+	}
+	return raw
+}
+
+// TestApplyChatTemplate 测试渲染带工具的对话，以及每条消息与生成提示的token数
+func TestApplyChatTemplate(t *testing.T) {
+	tk := writeChatModel(t, chatMLTemplate)
+	if !tk.HasChatTemplate() {
+		t.Fatal("expected chat template from tokenizer_config.json")
+	}
+
+	opts := tokenizer.ChatOptions{
+		Messages: rawMessages(t, `[
+			{"role": "system", "content": "You are helpful."},
+			{"role": "user", "content": "Weather in Paris?"},
+			{"role": "assistant", "content": "", "tool_calls": [
+				{"type": "function", "function": {"name": "get_weather", "arguments": {"city": "Paris"}}}
+			]},
+			{"role": "tool", "content": "22°C"}
+		]`),
+		Tools: rawMessages(t, `[
+			{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}
+		]`),
+		AddGenerationPrompt: true,
+	}
+	result, err := tk.ApplyChatTemplate(opts)
+	if err != nil {
+		t.Fatalf("ApplyChatTemplate failed: %v", err)
+	}
+
+	want := "<|im_start|>system\nYou are helpful.\n\n# Tools\n<tools>\n" +
+		`{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}` +
+		"\n</tools><|im_end|>\n" +
+		"<|im_start|>user\nWeather in Paris?<|im_end|>\n" +
+		"<|im_start|>assistant\n<tool_call>\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Paris\"}}\n</tool_call><|im_end|>\n" +
+		"<|im_start|>user\n<tool_response>\n22°C\n</tool_response><|im_end|>\n" +
+		"<|im_start|>assistant\n"
+	if result.Prompt != want {
+		t.Errorf("prompt = %q, want %q", result.Prompt, want)
+	}
+
+	ids, err := tk.Encode(want)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if result.TokenCount != len(ids) || len(result.TokenIDs) != len(ids) || len(result.Tokens) != len(ids) {
+		t.Errorf("token count = %d, want %d", result.TokenCount, len(ids))
+	}
+
+	if len(result.MessageTokens) != 4 {
+		t.Fatalf("message tokens = %+v", result.MessageTokens)
+	}
+	total := result.GenerationPromptTokens
+	for i, count := range result.MessageTokens {
+		if count.Index != i || count.TokenCount <= 0 {
+			t.Errorf("unexpected message count: %+v", count)
+		}
+		total += count.TokenCount
+	}
+	if total != result.TokenCount || result.GenerationPromptTokens <= 0 {
+		t.Errorf("message tokens %+v + generation %d do not add up to %d",
+			result.MessageTokens, result.GenerationPromptTokens, result.TokenCount)
+	}
+	if result.MessageTokens[3].Role != "tool" {
+		t.Errorf("role = %q, want tool", result.MessageTokens[3].Role)
+	}
+
+	// 不添加生成提示时提示词以最后一条消息结束
+	opts.AddGenerationPrompt = false
+	result, err = tk.ApplyChatTemplate(opts)
+	if err != nil {
+		t.Fatalf("ApplyChatTemplate failed: %v", err)
+	}
+	if result.GenerationPromptTokens != 0 || result.Prompt != want[:len(want)-len("<|im_start|>assistant\n")] {
+		t.Errorf("unexpected prompt without generation prompt: %q", result.Prompt)
+	}
+}
+
+// TestApplyChatTemplateOverride 测试请求中的模板、模板参数与缺少模板时的错误
+func TestApplyChatTemplateOverride(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+	messages := rawMessages(t, `[{"role": "user", "content": "hello"}]`)
+	if _, err := tk.ApplyChatTemplate(tokenizer.ChatOptions{Messages: messages}); err == nil {
+		t.Error("expected error for model without chat template")
+	}
+
+	result, err := tk.ApplyChatTemplate(tokenizer.ChatOptions{
+		Messages:       messages,
+		Template:       `{% for m in messages %}{{ m.content }}{% endfor %}{% if enable_thinking is false %} good{% endif %}`,
+		TemplateKwargs: json.RawMessage(`{"enable_thinking": false}`),
+	})
+	if err != nil {
+		t.Fatalf("ApplyChatTemplate failed: %v", err)
+	}
+	if result.Prompt != "hello good" || result.TokenCount != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if _, err := tk.ApplyChatTemplate(tokenizer.ChatOptions{Messages: messages, Template: "{% if %}"}); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+// TestApplyChatTemplateMessageTokens 测试依赖loop.last的模板（系统提示注入最后一轮）按单次渲染统计每条消息的token数
+func TestApplyChatTemplateMessageTokens(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+	result, err := tk.ApplyChatTemplate(tokenizer.ChatOptions{
+		Messages: rawMessages(t, `[
+			{"role": "user", "content": "play"},
+			{"role": "assistant", "content": "is good"},
+			{"role": "user", "content": "plays"}
+		]`),
+		Template: `{{ 'is ' }}{% for m in messages %}{% if loop.last %}{{ system }} {% endif %}{{ m.content }} .{% endfor %}` +
+			`{% if add_generation_prompt %} play{% endif %}`,
+		TemplateKwargs:      json.RawMessage(`{"system": "good good"}`),
+		AddGenerationPrompt: true,
+	})
+	if err != nil {
+		t.Fatalf("ApplyChatTemplate failed: %v", err)
+	}
+	if want := "is play .is good .good good plays . play"; result.Prompt != want {
+		t.Errorf("prompt = %q, want %q", result.Prompt, want)
+	}
+
+	var counts []int
+	for _, count := range result.MessageTokens {
+		counts = append(counts, count.TokenCount)
+	}
+	if want := []int{3, 3, 5}; !reflect.DeepEqual(counts, want) || result.GenerationPromptTokens != 1 || result.TokenCount != 12 {
+		t.Errorf("message tokens = %v, generation = %d, total = %d, want %v, 1, 12",
+			counts, result.GenerationPromptTokens, result.TokenCount, want)
+	}
+}
@@ -0,0 +1,177 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChunkOptions 分块选项
+type ChunkOptions struct {
+	MaxTokens int // 每块最多的token数
+	Overlap   int // 相邻块重叠的token数
+}
+
+// Chunk 分块结果中的一块
+type Chunk struct {
+	Index       int    `json:"index"`
+	Text        string `json:"text"`
+	TokenCount  int    `json:"token_count"`
+	Offsets     [2]int `json:"offsets"`               // 在原始文本中的字符(rune)区间
+	ByteOffsets [2]int `json:"byte_offsets"`          // 在原始文本中的字节区间
+	Break       string `json:"break"`                 // 块的结束位置：paragraph、sentence、newline、word、token或end
+	OverBudget  bool   `json:"over_budget,omitempty"` // 单个字符的token数超过预算，块的token数大于MaxTokens
+}
+
+// ChunkResult 分块结果
+type ChunkResult struct {
+	Chunks     []Chunk `json:"chunks"`
+	TokenCount int     `json:"token_count"` // 整段文本的token数
+	ModelName  string  `json:"model_name"`
+}
+
+// breakKinds 断点的优先级，越靠前越优先。较强的断点同时满足较弱的类别，如段落结尾也是句末
+var breakKinds = []string{"paragraph", "sentence", "newline", "word"}
+
+// sentenceEnds 句末标点
+const sentenceEnds = ".!?。！？；;…"
+
+// Chunk 将文本按token预算切分为多块：每块最多MaxTokens个token，相邻块重叠Overlap个token。
+// 在预算内优先于段落、句子、换行、空白处断开（断点不早于预算的一半），否则按token切分。
+// 块只在字符边界处断开，每块的token数按单独编码块文本计算，不超过预算；
+// 单个字符的token数超过预算时该字符单独成块并标记OverBudget
+func (t *Tokenizer) Chunk(text string, opts ChunkOptions) (*ChunkResult, error) {
+	if opts.MaxTokens <= 0 {
+		return nil, fmt.Errorf("chunk size must be positive")
+	}
+	if opts.Overlap < 0 || opts.Overlap >= opts.MaxTokens {
+		return nil, fmt.Errorf("chunk overlap must be in [0, %d)", opts.MaxTokens)
+	}
+	encoding, err := t.EncodeWithOptions(text, EncodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChunkResult{Chunks: []Chunk{}, TokenCount: encoding.Len(), ModelName: t.config.ModelName}
+	n := encoding.Len()
+	for start := 0; start < n; {
+		var chunk Chunk
+		var end int
+		// 单独编码块文本可能因边界处的合并多出token，此时缩小预算重试，直到只剩第一个字符
+		first := nextCharBoundary(encoding, start+1)
+		for limit := minInt(start+opts.MaxTokens, n); ; limit = end - 1 {
+			end, chunk.Break = chunkEnd(text, encoding, start, limit, opts.MaxTokens)
+			byteStart, byteEnd := encoding.ByteOffsets[start][0], encoding.ByteOffsets[end-1][1]
+			chunk.Text = text[byteStart:byteEnd]
+			chunk.ByteOffsets = [2]int{byteStart, byteEnd}
+			chunk.Offsets = [2]int{encoding.Offsets[start][0], encoding.Offsets[end-1][1]}
+
+			chunkEncoding, err := t.EncodeWithOptions(chunk.Text, EncodeOptions{})
+			if err != nil {
+				return nil, err
+			}
+			chunk.TokenCount = chunkEncoding.Len()
+			if chunk.TokenCount <= opts.MaxTokens || end <= first {
+				break
+			}
+		}
+		chunk.OverBudget = chunk.TokenCount > opts.MaxTokens
+		chunk.Index = len(result.Chunks)
+		result.Chunks = append(result.Chunks, chunk)
+
+		if end == n {
+			break
+		}
+		// 重叠部分从字符边界开始，跳过与上一块共享同一字符的token
+		start = nextCharBoundary(encoding, maxInt(end-opts.Overlap, start+1))
+	}
+	return result, nil
+}
+
+// isCharBoundary 判断第i个token之前是否为字符边界：字节级token可能拆分同一个字符，
+// 此时相邻token的字节区间重叠
+func isCharBoundary(encoding *Encoding, i int) bool {
+	return i <= 0 || i >= encoding.Len() || encoding.ByteOffsets[i][0] >= encoding.ByteOffsets[i-1][1]
+}
+
+// nextCharBoundary 返回不小于i的第一个字符边界
+func nextCharBoundary(encoding *Encoding, i int) int {
+	for !isCharBoundary(encoding, i) {
+		i++
+	}
+	return i
+}
+
+// chunkEnd 在(start, limit]中选择块的结束token位置：按breakKinds的优先级取最靠后的断点，
+// 断点距start不少于预算的一半；没有合适断点时在limit之前最近的字符边界处按token切分，
+// (start, limit]中没有字符边界时返回start之后的第一个字符边界
+func chunkEnd(text string, encoding *Encoding, start, limit, maxTokens int) (int, string) {
+	if limit == encoding.Len() {
+		return limit, "end"
+	}
+	// ends[i] 为满足breakKinds[i]的最靠后的断点
+	ends := make([]int, len(breakKinds))
+	for end := limit; end > start && end-start >= (maxTokens+1)/2; end-- {
+		if !isCharBoundary(encoding, end) {
+			continue
+		}
+		rank := breakRank(breakKind(text, encoding.ByteOffsets[end][0]))
+		for i := rank; i < len(breakKinds); i++ {
+			if ends[i] == 0 {
+				ends[i] = end
+			}
+		}
+	}
+	for i, end := range ends {
+		if end > 0 {
+			return end, breakKinds[i]
+		}
+	}
+	for end := limit; end > start; end-- {
+		if isCharBoundary(encoding, end) {
+			return end, "token"
+		}
+	}
+	end := nextCharBoundary(encoding, start+1)
+	if end == encoding.Len() {
+		return end, "end"
+	}
+	return end, "token"
+}
+
+// breakRank 返回断点类别在breakKinds中的位置，不是断点时返回len(breakKinds)
+func breakRank(kind string) int {
+	for i, k := range breakKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(breakKinds)
+}
+
+// breakKind 判断在字节位置pos处断开属于哪类断点，pos之前的空格与制表符不影响判断
+func breakKind(text string, pos int) string {
+	before := strings.TrimRight(text[:pos], " \t")
+	after := text[pos:]
+	switch {
+	case strings.HasSuffix(before, "\n\n") || strings.HasSuffix(before, "\n\r\n"):
+		return "paragraph"
+	case strings.HasSuffix(before, "\n"):
+		line := strings.TrimRight(before, " \t\r\n")
+		if last, _ := utf8.DecodeLastRuneInString(line); line != "" && strings.ContainsRune(sentenceEnds, last) {
+			return "sentence"
+		}
+		return "newline"
+	}
+
+	last, _ := utf8.DecodeLastRuneInString(before)
+	next, _ := utf8.DecodeRuneInString(after)
+	if strings.ContainsRune(sentenceEnds, last) && (last > unicode.MaxASCII || len(before) < pos || unicode.IsSpace(next)) {
+		return "sentence"
+	}
+	if len(before) < pos || unicode.IsSpace(next) {
+		return "word"
+	}
+	return ""
+}
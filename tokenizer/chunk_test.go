@@ -0,0 +1,87 @@
+package tokenizer_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestChunk 测试按token预算分块时的断点选择与重叠
+func TestChunk(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+	text := "is good. is good.\n\nplays good"
+
+	tests := []struct {
+		opts   tokenizer.ChunkOptions
+		texts  []string
+		breaks []string
+	}{
+		{tokenizer.ChunkOptions{MaxTokens: 4}, []string{"is good.", "is good.", "plays good"}, []string{"sentence", "paragraph", "end"}},
+		{tokenizer.ChunkOptions{MaxTokens: 4, Overlap: 1}, []string{"is good.", ". is good.", ".\n\nplays good"},
+			[]string{"sentence", "paragraph", "end"}},
+		{tokenizer.ChunkOptions{MaxTokens: 20}, []string{text}, []string{"end"}},
+		{tokenizer.ChunkOptions{MaxTokens: 1}, []string{"is", "good", ".", "is", "good", ".", "play", "s", "good"}, nil},
+	}
+	for _, tt := range tests {
+		result, err := tk.Chunk(text, tt.opts)
+		if err != nil {
+			t.Fatalf("Chunk(%+v) failed: %v", tt.opts, err)
+		}
+		var texts, breaks []string
+		for _, chunk := range result.Chunks {
+			texts = append(texts, chunk.Text)
+			breaks = append(breaks, chunk.Break)
+			if chunk.TokenCount > tt.opts.MaxTokens {
+				t.Errorf("Chunk(%+v) chunk %q has %d tokens", tt.opts, chunk.Text, chunk.TokenCount)
+			}
+			if got := text[chunk.ByteOffsets[0]:chunk.ByteOffsets[1]]; got != chunk.Text {
+				t.Errorf("Chunk(%+v) byte offsets %v = %q, want %q", tt.opts, chunk.ByteOffsets, got, chunk.Text)
+			}
+		}
+		if !reflect.DeepEqual(texts, tt.texts) {
+			t.Errorf("Chunk(%+v) texts = %q, want %q", tt.opts, texts, tt.texts)
+		}
+		if tt.breaks != nil && !reflect.DeepEqual(breaks, tt.breaks) {
+			t.Errorf("Chunk(%+v) breaks = %q, want %q", tt.opts, breaks, tt.breaks)
+		}
+		if result.TokenCount != 9 {
+			t.Errorf("token count = %d, want 9", result.TokenCount)
+		}
+	}
+
+	for _, opts := range []tokenizer.ChunkOptions{{}, {MaxTokens: 2, Overlap: 2}, {MaxTokens: 2, Overlap: -1}} {
+		if _, err := tk.Chunk(text, opts); err == nil {
+			t.Errorf("expected error for options %+v", opts)
+		}
+	}
+}
+
+// TestChunkByteLevel 测试字节级token拆分同一字符时只在字符边界处断开，超过预算的单个字符单独成块
+func TestChunkByteLevel(t *testing.T) {
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "testdata", "golden", "bpe"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	result, err := tk.Chunk("a😀b😀c", tokenizer.ChunkOptions{MaxTokens: 2, Overlap: 1})
+	if err != nil {
+		t.Fatalf("Chunk failed: %v", err)
+	}
+
+	var texts []string
+	var overBudget []bool
+	for _, chunk := range result.Chunks {
+		texts = append(texts, chunk.Text)
+		overBudget = append(overBudget, chunk.OverBudget)
+		if !chunk.OverBudget && chunk.TokenCount > 2 {
+			t.Errorf("chunk %q has %d tokens", chunk.Text, chunk.TokenCount)
+		}
+	}
+	if want := []string{"a", "😀", "b", "😀", "c"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("texts = %q, want %q", texts, want)
+	}
+	if want := []bool{false, true, false, true, false}; !reflect.DeepEqual(overBudget, want) {
+		t.Errorf("over budget = %v, want %v", overBudget, want)
+	}
+}
@@ -0,0 +1,87 @@
+package tokenizer
+
+import (
+	"fmt"
+	"math"
+)
+
+// ModelComparison 单个模型的对比结果
+type ModelComparison struct {
+	Model         string   `json:"model"`
+	TokenCount    int      `json:"token_count"`
+	Tokens        []string `json:"tokens"`
+	TokenIDs      []int    `json:"token_ids"`
+	CharsPerToken float64  `json:"chars_per_token"`
+	DiffTokens    int      `json:"diff_tokens"`  // 与基准模型的token数之差
+	DiffPercent   float64  `json:"diff_percent"` // 相对基准模型token数的百分比差异
+}
+
+// CompareResult 多个模型对同一文本的分词对比
+type CompareResult struct {
+	Baseline  string            `json:"baseline"`
+	CharCount int               `json:"char_count"`
+	Results   []ModelComparison `json:"results"`
+}
+
+// Compare 用names指定的模型（为空表示全部模型）对文本分词并与基准模型比较，
+// baseline为空时以第一个模型为基准
+func (r *Registry) Compare(text string, names []string, baseline string, opts EncodeOptions) (*CompareResult, error) {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no tokenizer loaded")
+	}
+	if baseline == "" {
+		baseline = names[0]
+	}
+
+	result := &CompareResult{
+		Baseline:  baseline,
+		CharCount: len([]rune(text)),
+	}
+	baselineIndex := -1
+	for _, name := range names {
+		tk, exists := r.Get(name)
+		if !exists {
+			return nil, fmt.Errorf("unknown model: %q", name)
+		}
+		encoding, err := tk.EncodeWithOptions(text, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+
+		comparison := ModelComparison{
+			Model:      name,
+			TokenCount: encoding.Len(),
+			Tokens:     encoding.Tokens,
+			TokenIDs:   encoding.IDs,
+		}
+		if comparison.TokenCount > 0 {
+			comparison.CharsPerToken = roundTo(float64(result.CharCount)/float64(comparison.TokenCount), 2)
+		}
+		if name == baseline {
+			baselineIndex = len(result.Results)
+		}
+		result.Results = append(result.Results, comparison)
+	}
+	if baselineIndex == -1 {
+		return nil, fmt.Errorf("baseline %q is not among the compared models", baseline)
+	}
+
+	base := result.Results[baselineIndex].TokenCount
+	for i := range result.Results {
+		comparison := &result.Results[i]
+		comparison.DiffTokens = comparison.TokenCount - base
+		if base > 0 {
+			comparison.DiffPercent = roundTo(float64(comparison.DiffTokens)*100/float64(base), 2)
+		}
+	}
+	return result, nil
+}
+
+// roundTo 四舍五入保留digits位小数
+func roundTo(value float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	return math.Round(value*scale) / scale
+}
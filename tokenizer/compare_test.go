@@ -0,0 +1,46 @@
+package tokenizer_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestCompare 测试多个模型的token数对比及与基准模型的差异
+func TestCompare(t *testing.T) {
+	gpt2, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	registry := tokenizer.NewRegistry()
+	registry.Register("gpt2", gpt2)
+	registry.Register("bert", writeConfig(t, bertConfig))
+
+	// bert: un ##aff ##able is good . (6)；gpt2按字节级BPE切分
+	text := "Unaffable is good."
+	result, err := registry.Compare(text, []string{"gpt2", "bert"}, "bert", tokenizer.EncodeOptions{})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if result.Baseline != "bert" || result.CharCount != 18 || len(result.Results) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	bert := result.Results[1]
+	if bert.Model != "bert" || bert.TokenCount != 6 || bert.CharsPerToken != 3 || bert.DiffTokens != 0 || bert.DiffPercent != 0 {
+		t.Errorf("unexpected baseline comparison: %+v", bert)
+	}
+	gpt2Result := result.Results[0]
+	ids, _ := gpt2.Encode(text)
+	if gpt2Result.TokenCount != len(ids) || gpt2Result.DiffTokens != len(ids)-6 {
+		t.Errorf("unexpected gpt2 comparison: %+v", gpt2Result)
+	}
+
+	if _, err := registry.Compare(text, []string{"gpt2", "llama"}, "", tokenizer.EncodeOptions{}); err == nil {
+		t.Error("expected error for unknown model")
+	}
+	if _, err := registry.Compare(text, []string{"gpt2"}, "bert", tokenizer.EncodeOptions{}); err == nil {
+		t.Error("expected error for baseline outside the compared models")
+	}
+}
@@ -0,0 +1,161 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// countBatchSize 流式计数时每批并行编码的行数，限制同时驻留内存的文本量
+const countBatchSize = 1024
+
+// CountOptions 流式计数选项
+type CountOptions struct {
+	Format           string // text（默认）每行一条记录；ndjson每行一个JSON记录，空行跳过
+	Field            string // ndjson记录为对象时计数的文本字段，默认text；记录为字符串时直接计数
+	AddSpecialTokens bool
+}
+
+// CountError 无法计数的记录
+type CountError struct {
+	Line  int    `json:"line"` // 从1开始的行号
+	Error string `json:"error"`
+}
+
+// HistogramBucket token数直方图的区间[Min, Max)
+type HistogramBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+// CountResult 流式计数结果
+type CountResult struct {
+	TotalTokens int               `json:"total_tokens"`
+	Lines       int               `json:"lines"`
+	Records     int               `json:"records"`
+	Counts      []int             `json:"counts"` // 按顺序为每条记录的token数，无法计数的记录为-1
+	Errors      []CountError      `json:"errors,omitempty"`
+	MaxTokens   int               `json:"max_tokens"` // 单条记录的最大token数
+	Histogram   []HistogramBucket `json:"histogram"`  // 按2的幂划分区间：[0,1)、[1,2)、[2,4)...
+	ModelName   string            `json:"model_name"`
+}
+
+// countRecord 待计数的一行
+type countRecord struct {
+	line int
+	text string
+	err  error
+}
+
+// CountReader 逐行读取r并统计token数，每countBatchSize行并行编码一次，不会一次性读入全部内容
+func (t *Tokenizer) CountReader(r io.Reader, opts CountOptions) (*CountResult, error) {
+	format := opts.Format
+	if format == "" {
+		format = "text"
+	}
+	if format != "text" && format != "ndjson" {
+		return nil, fmt.Errorf("unsupported count format: %q", format)
+	}
+	field := opts.Field
+	if field == "" {
+		field = "text"
+	}
+
+	result := &CountResult{Counts: []int{}, ModelName: t.config.ModelName}
+	batch := make([]countRecord, 0, countBatchSize)
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line != "" {
+			result.Lines++
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			if format == "text" {
+				batch = append(batch, countRecord{line: result.Lines, text: line})
+			} else if strings.TrimSpace(line) != "" {
+				text, recordErr := recordText(line, field)
+				batch = append(batch, countRecord{line: result.Lines, text: text, err: recordErr})
+			}
+		}
+		if len(batch) == countBatchSize || (err == io.EOF && len(batch) > 0) {
+			t.countBatch(batch, opts.AddSpecialTokens, result)
+			batch = batch[:0]
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return result, nil
+}
+
+// countBatch 并行编码一批记录并累加到result
+func (t *Tokenizer) countBatch(batch []countRecord, addSpecialTokens bool, result *CountResult) {
+	counts := make([]int, len(batch))
+	parallel(len(batch), func(i int) {
+		if batch[i].err != nil {
+			return
+		}
+		encoding, err := t.EncodeWithOptions(batch[i].text, EncodeOptions{AddSpecialTokens: addSpecialTokens})
+		if err != nil {
+			batch[i].err = err
+			return
+		}
+		counts[i] = encoding.Len()
+	})
+
+	for i, record := range batch {
+		result.Records++
+		if record.err != nil {
+			result.Errors = append(result.Errors, CountError{Line: record.line, Error: record.err.Error()})
+			result.Counts = append(result.Counts, -1)
+			continue
+		}
+		count := counts[i]
+		result.Counts = append(result.Counts, count)
+		result.TotalTokens += count
+		result.MaxTokens = maxInt(result.MaxTokens, count)
+		result.addToHistogram(count)
+	}
+}
+
+// addToHistogram 将一条记录的token数计入直方图，按需追加区间
+func (r *CountResult) addToHistogram(count int) {
+	for len(r.Histogram) == 0 || r.Histogram[len(r.Histogram)-1].Max <= count {
+		bucket := HistogramBucket{Min: 0, Max: 1}
+		if n := len(r.Histogram); n > 0 {
+			bucket = HistogramBucket{Min: r.Histogram[n-1].Max, Max: r.Histogram[n-1].Max * 2}
+		}
+		r.Histogram = append(r.Histogram, bucket)
+	}
+	for i := range r.Histogram {
+		if count < r.Histogram[i].Max {
+			r.Histogram[i].Count++
+			return
+		}
+	}
+}
+
+// recordText 取出ndjson记录中的文本：记录为字符串时直接返回，为对象时取field字段
+func recordText(line, field string) (string, error) {
+	var text string
+	if err := json.Unmarshal([]byte(line), &text); err == nil {
+		return text, nil
+	}
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return "", fmt.Errorf("invalid JSON record: %v", err)
+	}
+	value, exists := record[field]
+	if !exists {
+		return "", fmt.Errorf("record has no %q field", field)
+	}
+	if err := json.Unmarshal(value, &text); err != nil {
+		return "", fmt.Errorf("field %q is not a string", field)
+	}
+	return text, nil
+}
@@ -0,0 +1,54 @@
+package tokenizer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestCountReader 测试逐行流式计数、NDJSON记录解析与直方图
+func TestCountReader(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+
+	result, err := tk.CountReader(strings.NewReader("is good\r\n\nUnaffable is good.\n"), tokenizer.CountOptions{})
+	if err != nil {
+		t.Fatalf("CountReader failed: %v", err)
+	}
+	if want := []int{2, 0, 6}; !reflect.DeepEqual(result.Counts, want) {
+		t.Errorf("counts = %v, want %v", result.Counts, want)
+	}
+	if result.TotalTokens != 8 || result.Lines != 3 || result.MaxTokens != 6 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	wantHistogram := []tokenizer.HistogramBucket{{Min: 0, Max: 1, Count: 1}, {Min: 1, Max: 2}, {Min: 2, Max: 4, Count: 1}, {Min: 4, Max: 8, Count: 1}}
+	if !reflect.DeepEqual(result.Histogram, wantHistogram) {
+		t.Errorf("histogram = %+v, want %+v", result.Histogram, wantHistogram)
+	}
+
+	ndjson := `{"text": "is good", "id": 1}` + "\n\n" + `"plays"` + "\n" + `{"id": 2}` + "\n" + `{bad`
+	result, err = tk.CountReader(strings.NewReader(ndjson), tokenizer.CountOptions{Format: "ndjson", AddSpecialTokens: true})
+	if err != nil {
+		t.Fatalf("CountReader failed: %v", err)
+	}
+	if want := []int{4, 4, -1, -1}; !reflect.DeepEqual(result.Counts, want) {
+		t.Errorf("counts = %v, want %v", result.Counts, want)
+	}
+	if result.Records != 4 || result.Lines != 5 || len(result.Errors) != 2 || result.Errors[0].Line != 4 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// 超过一个批次的输入
+	result, err = tk.CountReader(strings.NewReader(strings.Repeat("is good .\n", 3000)), tokenizer.CountOptions{})
+	if err != nil {
+		t.Fatalf("CountReader failed: %v", err)
+	}
+	if result.Records != 3000 || result.TotalTokens != 9000 {
+		t.Errorf("records = %d, total = %d", result.Records, result.TotalTokens)
+	}
+
+	if _, err := tk.CountReader(strings.NewReader("x"), tokenizer.CountOptions{Format: "csv"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
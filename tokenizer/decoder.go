@@ -0,0 +1,355 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dlclark/regexp2"
+)
+
+// decoder 解码器，将tokens还原为文本（对应HF的decoder）。
+// decodeChain逐步变换token列表，最终结果直接拼接
+type decoder interface {
+	decodeChain(tokens []string) []string
+}
+
+// newDecoder 根据HF配置创建解码器
+func newDecoder(config map[string]interface{}) (decoder, error) {
+	typ, _ := config["type"].(string)
+	switch typ {
+	case "Sequence":
+		items, _ := config["decoders"].([]interface{})
+		var sequence sequenceDecoder
+		for _, item := range items {
+			child, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid decoder in sequence: %v", item)
+			}
+			d, err := newDecoder(child)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, d)
+		}
+		return sequence, nil
+
+	case "ByteLevel":
+		return byteLevelDecoder{}, nil
+
+	case "Metaspace":
+		m := newMetaspacePreTokenizer(config)
+		return metaspaceDecoder{replacement: m.replacement, prependScheme: m.prependScheme}, nil
+
+	case "WordPiece":
+		return wordPieceDecoder{
+			prefix:  stringOption(config, "prefix", "##"),
+			cleanup: boolOption(config, "cleanup", true),
+		}, nil
+
+	case "BPEDecoder":
+		return bpeDecoder{suffix: stringOption(config, "suffix", "</w>")}, nil
+
+	case "ByteFallback":
+		return byteFallbackDecoder{}, nil
+
+	case "Fuse":
+		return fuseDecoder{}, nil
+
+	case "Strip":
+		return stripDecoder{
+			content: stringOption(config, "content", " "),
+			start:   intOption(config, "start", 0),
+			stop:    intOption(config, "stop", 0),
+		}, nil
+
+	case "Replace":
+		pattern, _ := config["pattern"].(map[string]interface{})
+		replace := &replaceDecoder{content: stringOption(config, "content", "")}
+		if re, ok := pattern["Regex"].(string); ok {
+			compiled, err := regexp2.Compile(re, regexp2.None)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Replace pattern %q: %v", re, err)
+			}
+			replace.regexp = compiled
+		} else if literal, ok := pattern["String"].(string); ok {
+			replace.literal = literal
+		} else {
+			return nil, fmt.Errorf("invalid Replace pattern: %v", config["pattern"])
+		}
+		return replace, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported decoder type: %q", typ)
+	}
+}
+
+// intOption 读取整数配置项（JSON数字解析为float64）
+func intOption(config map[string]interface{}, key string, fallback int) int {
+	if value, ok := config[key].(float64); ok {
+		return int(value)
+	}
+	return fallback
+}
+
+// sequenceDecoder 依次应用多个解码器
+type sequenceDecoder []decoder
+
+func (s sequenceDecoder) decodeChain(tokens []string) []string {
+	for _, d := range s {
+		tokens = d.decodeChain(tokens)
+	}
+	return tokens
+}
+
+// byteLevelDecoder 将字节级字母表中的字符还原为原始字节，
+// 包含字母表以外字符的token（如特殊token）按原样输出
+type byteLevelDecoder struct{}
+
+func (byteLevelDecoder) decodeChain(tokens []string) []string {
+	var buf []byte
+	for _, token := range tokens {
+		start := len(buf)
+		for _, r := range token {
+			b, ok := byteDecoder[r]
+			if !ok {
+				buf = append(buf[:start], token...)
+				break
+			}
+			buf = append(buf, b)
+		}
+	}
+	return []string{decodeUTF8Lossy(buf)}
+}
+
+// decodeUTF8Lossy 与Rust的String::from_utf8_lossy一致：逐个解码字符，
+// 每个无效序列（有效序列的最长前缀或单个无效字节）替换为一个U+FFFD
+func decodeUTF8Lossy(buf []byte) string {
+	if utf8.Valid(buf) {
+		return string(buf)
+	}
+	var sb strings.Builder
+	for i := 0; i < len(buf); {
+		r, size := utf8.DecodeRune(buf[i:])
+		if r == utf8.RuneError && size == 1 {
+			size = invalidUTF8Length(buf[i:])
+		}
+		sb.WriteRune(r)
+		i += size
+	}
+	return sb.String()
+}
+
+// invalidUTF8Length 返回以buf开头的无效序列的长度：按首字节允许的第二字节范围，
+// 计算能作为有效序列前缀的字节数，至少为1
+func invalidUTF8Length(buf []byte) int {
+	var need int
+	lo, hi := byte(0x80), byte(0xBF)
+	switch b := buf[0]; {
+	case b >= 0xC2 && b <= 0xDF:
+		need = 2
+	case b == 0xE0:
+		need, lo = 3, 0xA0
+	case b == 0xED:
+		need, hi = 3, 0x9F
+	case b >= 0xE1 && b <= 0xEF:
+		need = 3
+	case b == 0xF0:
+		need, lo = 4, 0x90
+	case b == 0xF4:
+		need, hi = 4, 0x8F
+	case b >= 0xF1 && b <= 0xF3:
+		need = 4
+	default:
+		return 1
+	}
+	n := 1
+	for ; n < need && n < len(buf); n++ {
+		if buf[n] < lo || buf[n] > hi {
+			break
+		}
+		lo, hi = 0x80, 0xBF
+	}
+	return n
+}
+
+// metaspaceDecoder 将▁还原为空格，并去掉预分词时补在开头的▁
+type metaspaceDecoder struct {
+	replacement   string
+	prependScheme string
+}
+
+func (m metaspaceDecoder) decodeChain(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		// 与HF一致：第一个token中的▁全部去掉，其余还原为空格
+		if i == 0 && m.prependScheme != "never" {
+			result[i] = strings.ReplaceAll(token, m.replacement, "")
+		} else {
+			result[i] = strings.ReplaceAll(token, m.replacement, " ")
+		}
+	}
+	return result
+}
+
+// wordPieceDecoder 去掉子词前缀，在完整词之间补空格
+type wordPieceDecoder struct {
+	prefix  string
+	cleanup bool
+}
+
+func (w wordPieceDecoder) decodeChain(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		if i != 0 {
+			if strings.HasPrefix(token, w.prefix) {
+				token = strings.Replace(token, w.prefix, "", 1)
+			} else {
+				token = " " + token
+			}
+		}
+		if w.cleanup {
+			token = replaceInOrder(token, wordPieceCleanupRules)
+		}
+		result[i] = token
+	}
+	return result
+}
+
+// tokenizationSpaceRules transformers中clean_up_tokenization的替换规则，按顺序依次替换
+var tokenizationSpaceRules = [][2]string{
+	{" .", "."}, {" ?", "?"}, {" !", "!"}, {" ,", ","}, {" ' ", "'"},
+	{" n't", "n't"}, {" 'm", "'m"}, {" 's", "'s"}, {" 've", "'ve"}, {" 're", "'re"},
+}
+
+// wordPieceCleanupRules HF WordPiece解码器的cleanup规则，比clean_up_tokenization多出 do not → don't
+var wordPieceCleanupRules = [][2]string{
+	{" .", "."}, {" ?", "?"}, {" !", "!"}, {" ,", ","}, {" ' ", "'"},
+	{" n't", "n't"}, {" 'm", "'m"}, {" do not", " don't"}, {" 's", "'s"}, {" 've", "'ve"}, {" 're", "'re"},
+}
+
+// cleanupTokenizationSpaces 去除标点和英文缩写前多余的空格（tokenizer_config.json的clean_up_tokenization_spaces）
+func cleanupTokenizationSpaces(text string) string {
+	return replaceInOrder(text, tokenizationSpaceRules)
+}
+
+// replaceInOrder 与Python的str.replace链一致，逐条规则替换，前一条的结果参与后一条的匹配
+func replaceInOrder(text string, rules [][2]string) string {
+	for _, rule := range rules {
+		text = strings.ReplaceAll(text, rule[0], rule[1])
+	}
+	return text
+}
+
+// bpeDecoder 将词尾后缀还原为空格
+type bpeDecoder struct {
+	suffix string
+}
+
+func (b bpeDecoder) decodeChain(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		replacement := " "
+		if i == len(tokens)-1 {
+			replacement = ""
+		}
+		result[i] = strings.ReplaceAll(token, b.suffix, replacement)
+	}
+	return result
+}
+
+// byteFallbackDecoder 将连续的<0xNN>字节token合并为UTF-8文本，无效字节输出为U+FFFD
+type byteFallbackDecoder struct{}
+
+func (byteFallbackDecoder) decodeChain(tokens []string) []string {
+	var result []string
+	var pending []byte
+
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if utf8.Valid(pending) {
+			result = append(result, string(pending))
+		} else {
+			for range pending {
+				result = append(result, "�")
+			}
+		}
+		pending = pending[:0]
+	}
+
+	for _, token := range tokens {
+		if b, ok := parseByteToken(token); ok {
+			pending = append(pending, b)
+			continue
+		}
+		flush()
+		result = append(result, token)
+	}
+	flush()
+	return result
+}
+
+// parseByteToken 解析 <0xNN> 形式的字节token
+func parseByteToken(token string) (byte, bool) {
+	if len(token) != 6 || !strings.HasPrefix(token, "<0x") || token[5] != '>' {
+		return 0, false
+	}
+	b, err := strconv.ParseUint(token[3:5], 16, 8)
+	if err != nil {
+		return 0, false
+	}
+	return byte(b), true
+}
+
+// fuseDecoder 将所有token合并为一个
+type fuseDecoder struct{}
+
+func (fuseDecoder) decodeChain(tokens []string) []string {
+	return []string{strings.Join(tokens, "")}
+}
+
+// stripDecoder 从每个token的开头和结尾各去掉至多start/stop个指定字符
+type stripDecoder struct {
+	content     string
+	start, stop int
+}
+
+func (s stripDecoder) decodeChain(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		for n := 0; n < s.start && strings.HasPrefix(token, s.content); n++ {
+			token = token[len(s.content):]
+		}
+		for n := 0; n < s.stop && strings.HasSuffix(token, s.content); n++ {
+			token = token[:len(token)-len(s.content)]
+		}
+		result[i] = token
+	}
+	return result
+}
+
+// replaceDecoder 在每个token中将匹配内容替换为指定文本
+type replaceDecoder struct {
+	regexp  *regexp2.Regexp
+	literal string
+	content string
+}
+
+func (r *replaceDecoder) decodeChain(tokens []string) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		if r.regexp != nil {
+			replaced, err := r.regexp.Replace(token, r.content, -1, -1)
+			if err == nil {
+				token = replaced
+			}
+		} else if r.literal != "" {
+			token = strings.ReplaceAll(token, r.literal, r.content)
+		}
+		result[i] = token
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+// TestDecoders 测试由配置驱动的解码器
+func TestDecoders(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		tokens []string
+		want   string
+	}{
+		{"bytelevel", `{"type": "ByteLevel"}`, []string{"Hello", "Ġworld", "Ċ", "<|endoftext|>"}, "Hello world\n<|endoftext|>"},
+		{"bytelevel cjk", `{"type": "ByteLevel"}`, []string{"ä¸Ń", "æĸĩ"}, "中文"},
+		{"metaspace", `{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always"}`, []string{"▁Hello", "▁world"}, "Hello world"},
+		{"metaspace leading", `{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always"}`, []string{"▁▁foo", "▁▁bar"}, "foo  bar"},
+		{"metaspace never", `{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "never"}`, []string{"▁▁foo", "▁bar"}, "  foo bar"},
+		{"bytelevel invalid", `{"type": "ByteLevel"}`, []string{"ä¸", "ÿa"}, "\ufffd\ufffda"},
+		{"wordpiece", `{"type": "WordPiece", "prefix": "##", "cleanup": true}`, []string{"un", "##aff", "##able", "is", "good", "."}, "unaffable is good."},
+		{"bpe suffix", `{"type": "BPEDecoder", "suffix": "</w>"}`, []string{"hel", "lo</w>", "world</w>"}, "hello world"},
+		{"byte fallback invalid", `{"type": "ByteFallback"}`, []string{"<0xFF>", "a"}, "�a"},
+		{"llama", `{"type": "Sequence", "decoders": [
+			{"type": "Replace", "pattern": {"String": "▁"}, "content": " "},
+			{"type": "ByteFallback"},
+			{"type": "Fuse"},
+			{"type": "Strip", "content": " ", "start": 1, "stop": 0}
+		]}`, []string{"▁Hello", "<0xE4>", "<0xBD>", "<0xA0>", "▁x"}, "Hello你 x"},
+	}
+
+	for _, tt := range tests {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.config), &config); err != nil {
+			t.Fatalf("%s: invalid config: %v", tt.name, err)
+		}
+		d, err := newDecoder(config)
+		if err != nil {
+			t.Fatalf("%s: failed to build decoder: %v", tt.name, err)
+		}
+
+		got := ""
+		for _, part := range d.decodeChain(tt.tokens) {
+			got += part
+		}
+		if got != tt.want {
+			t.Errorf("%s: decode(%q) = %q, want %q", tt.name, tt.tokens, got, tt.want)
+		}
+	}
+}
+
+// TestDecodeUTF8Lossy 测试每个无效序列替换为一个U+FFFD
+func TestDecodeUTF8Lossy(t *testing.T) {
+	tests := []struct {
+		buf  []byte
+		want string
+	}{
+		{[]byte("héllo"), "héllo"},
+		{[]byte{0xE4, 0xB8, 'a'}, "\ufffda"},
+		{[]byte{0xFF, 0xFE}, "\ufffd\ufffd"},
+		{[]byte{0xF0, 0x9F, 0x98, 'b', 0xF0, 0x9F, 0x98, 0x80}, "\ufffdb😀"},
+		{[]byte{0xED, 0xA0, 0x80}, "\ufffd\ufffd\ufffd"},
+		{[]byte{0xC3}, "\ufffd"},
+	}
+	for _, tt := range tests {
+		if got := decodeUTF8Lossy(tt.buf); got != tt.want {
+			t.Errorf("decodeUTF8Lossy(%x) = %q, want %q", tt.buf, got, tt.want)
+		}
+	}
+}
+
+// TestCleanupTokenizationSpaces 测试clean_up_tokenization按顺序替换，且不含WordPiece解码器的 do not 规则
+func TestCleanupTokenizationSpaces(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"I do not know , he said .", "I do not know, he said."},
+		{"it is n't ours", "it isn't ours"},
+		// 前一条规则的结果参与后一条的匹配：" ' "替换后形成" 's"
+		{"a  ' s", "a's"},
+	}
+	for _, tt := range tests {
+		if got := cleanupTokenizationSpaces(tt.text); got != tt.want {
+			t.Errorf("cleanupTokenizationSpaces(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if got := replaceInOrder("I do not know .", wordPieceCleanupRules); got != "I don't know." {
+		t.Errorf("WordPiece cleanup = %q, want %q", got, "I don't know.")
+	}
+}
+
+// TestDecodeRoundTrip 测试字节级模型 Decode(Encode(x)) == x
+func TestDecodeRoundTrip(t *testing.T) {
+	tk, err := NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	texts := []string{
+		"Hello world",
+		"  multiple   spaces\n\ttabs\r\n",
+		"中文测试 🎉 emoji",
+		"",
+	}
+	for _, text := range texts {
+		ids, err := tk.Encode(text)
+		if err != nil {
+			t.Fatalf("Encoding failed: %v", err)
+		}
+		decoded, err := tk.Decode(ids)
+		if err != nil {
+			t.Fatalf("Decoding failed: %v", err)
+		}
+		if decoded != text {
+			t.Errorf("Decode(Encode(%q)) = %q", text, decoded)
+		}
+	}
+}
+
+// TestSkipSpecialTokens 测试解码时跳过特殊token
+func TestSkipSpecialTokens(t *testing.T) {
+	tk, err := NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	ids, err := tk.Encode("hi")
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	ids = append(ids, tk.config.Vocabulary["<|endoftext|>"])
+
+	decoded, _ := tk.DecodeWithOptions(ids, DecodeOptions{})
+	if decoded != "hi<|endoftext|>" {
+		t.Errorf("decoded = %q, want %q", decoded, "hi<|endoftext|>")
+	}
+	decoded, _ = tk.DecodeWithOptions(ids, DecodeOptions{SkipSpecialTokens: true})
+	if decoded != "hi" {
+		t.Errorf("decoded with skip = %q, want %q", decoded, "hi")
+	}
+
+	// 词汇表中没有的ID被跳过
+	decoded, _ = tk.DecodeWithOptions(append(ids[:len(ids)-1:len(ids)-1], -1, 1<<30), DecodeOptions{})
+	if decoded != "hi" {
+		t.Errorf("decoded with unknown ids = %q, want %q", decoded, "hi")
+	}
+}
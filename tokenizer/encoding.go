@@ -0,0 +1,137 @@
+package tokenizer
+
+import (
+	"unicode/utf8"
+)
+
+// Encoding 编码结果，各字段按token一一对应
+type Encoding struct {
+	IDs               []int    `json:"ids"`
+	Tokens            []string `json:"tokens"`
+	TypeIDs           []int    `json:"type_ids"`
+	SpecialTokensMask []int    `json:"special_tokens_mask"` // 1表示特殊token
+	Offsets           [][2]int `json:"offsets"`             // token在原始文本中的字符(rune)区间
+	ByteOffsets       [][2]int `json:"byte_offsets"`        // token在原始文本中的字节区间
+	WordIDs           []int    `json:"word_ids"`            // token所属词（预分词片段）的序号，-1表示后处理添加的特殊token
+	AttentionMask     []int    `json:"attention_mask"`      // 0表示填充的token
+
+	Overflowing []*Encoding `json:"overflowing,omitempty"` // 截断后溢出的片段，各自经过后处理与填充
+}
+
+// EncodeOptions 编码选项
+type EncodeOptions struct {
+	AddSpecialTokens bool   // 按post_processor添加特殊token（如BOS/EOS、[gMASK]<sop>）
+	Pair             string // 句对编码时的第二段文本，为空表示单句
+
+	Truncation *TruncationOptions // 为nil时不截断
+	Padding    *PaddingOptions    // 为nil时不填充
+}
+
+// encodingBytesPerToken 按文本字节数预估token数时，平均每个token对应的字节数
+const encodingBytesPerToken = 3
+
+// newEncoding 创建预留capacity个token空间的编码结果
+func newEncoding(capacity int) *Encoding {
+	e := &Encoding{}
+	e.grow(capacity)
+	return e
+}
+
+// grow 将各字段的容量扩大到capacity。长编码结果逐个追加token时按倍数扩容，
+// 避免append对大切片按较小比例反复扩容复制
+func (e *Encoding) grow(capacity int) {
+	e.IDs = append(make([]int, 0, capacity), e.IDs...)
+	e.Tokens = append(make([]string, 0, capacity), e.Tokens...)
+	e.TypeIDs = append(make([]int, 0, capacity), e.TypeIDs...)
+	e.SpecialTokensMask = append(make([]int, 0, capacity), e.SpecialTokensMask...)
+	e.Offsets = append(make([][2]int, 0, capacity), e.Offsets...)
+	e.ByteOffsets = append(make([][2]int, 0, capacity), e.ByteOffsets...)
+	e.WordIDs = append(make([]int, 0, capacity), e.WordIDs...)
+	e.AttentionMask = append(make([]int, 0, capacity), e.AttentionMask...)
+}
+
+// Len 返回token数量
+func (e *Encoding) Len() int {
+	return len(e.IDs)
+}
+
+// appendToken 追加一个token，byteOffsets为原始文本中的字节区间，字符区间由setCharOffsets计算
+func (e *Encoding) appendToken(id int, token string, typeID int, special bool, byteOffsets [2]int, wordID int) {
+	mask := 0
+	if special {
+		mask = 1
+	}
+	if len(e.IDs) == cap(e.IDs) {
+		e.grow(2*len(e.IDs) + 16)
+	}
+	e.IDs = append(e.IDs, id)
+	e.Tokens = append(e.Tokens, token)
+	e.TypeIDs = append(e.TypeIDs, typeID)
+	e.SpecialTokensMask = append(e.SpecialTokensMask, mask)
+	e.Offsets = append(e.Offsets, byteOffsets)
+	e.ByteOffsets = append(e.ByteOffsets, byteOffsets)
+	e.WordIDs = append(e.WordIDs, wordID)
+	e.AttentionMask = append(e.AttentionMask, 1)
+}
+
+// appendSpecialToken 追加一个由后处理器添加的特殊token，不对应原始文本中的位置
+func (e *Encoding) appendSpecialToken(id int, token string, typeID int) {
+	e.appendToken(id, token, typeID, true, [2]int{}, -1)
+}
+
+// appendEncoding 追加另一个编码结果的全部token
+func (e *Encoding) appendEncoding(other *Encoding) {
+	e.IDs = append(e.IDs, other.IDs...)
+	e.Tokens = append(e.Tokens, other.Tokens...)
+	e.TypeIDs = append(e.TypeIDs, other.TypeIDs...)
+	e.SpecialTokensMask = append(e.SpecialTokensMask, other.SpecialTokensMask...)
+	e.Offsets = append(e.Offsets, other.Offsets...)
+	e.ByteOffsets = append(e.ByteOffsets, other.ByteOffsets...)
+	e.WordIDs = append(e.WordIDs, other.WordIDs...)
+	e.AttentionMask = append(e.AttentionMask, other.AttentionMask...)
+}
+
+// setCharOffsets 根据原始文本将字节区间转换为字符区间：
+// 起点取其所在字符，终点向后取整到字符边界
+func (e *Encoding) setCharOffsets(text string) {
+	// charIndex[i] 为text[:i]中的字符数
+	charIndex := make([]int, len(text)+1)
+	count := 0
+	for i := 0; i < len(text); {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		for j := i; j < i+size; j++ {
+			charIndex[j] = count
+		}
+		count++
+		i += size
+	}
+	charIndex[len(text)] = count
+
+	for i, offsets := range e.ByteOffsets {
+		start, end := offsets[0], offsets[1]
+		charEnd := charIndex[end]
+		if end > start && end < len(text) && charIndex[end-1] == charEnd {
+			charEnd++ // 终点落在字符中间
+		}
+		e.Offsets[i] = [2]int{charIndex[start], charEnd}
+	}
+}
+
+// setTypeID 将全部token的类型ID设为typeID
+func (e *Encoding) setTypeID(typeID int) {
+	for i := range e.TypeIDs {
+		e.TypeIDs[i] = typeID
+	}
+}
+
+// mergeEncodings 按顺序合并多个编码结果，只有一个时直接返回
+func mergeEncodings(encodings []*Encoding) *Encoding {
+	if len(encodings) == 1 {
+		return encodings[0]
+	}
+	merged := &Encoding{}
+	for _, encoding := range encodings {
+		merged.appendEncoding(encoding)
+	}
+	return merged
+}
@@ -0,0 +1,72 @@
+package tokenizer_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestOffsets 测试经过规范化、预分词与模型后每个token在原始文本中的字符/字节区间及所属词
+func TestOffsets(t *testing.T) {
+	gpt2, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		tk          *tokenizer.Tokenizer
+		text        string
+		opts        tokenizer.EncodeOptions
+		tokens      []string
+		offsets     [][2]int
+		byteOffsets [][2]int
+		wordIDs     []int
+	}{
+		{"byte level", gpt2, "héllo 123", tokenizer.EncodeOptions{},
+			[]string{"h", "Ã", "©", "llo", "Ġ", "1", "2", "3"},
+			[][2]int{{0, 1}, {1, 2}, {1, 2}, {2, 5}, {5, 6}, {6, 7}, {7, 8}, {8, 9}},
+			[][2]int{{0, 1}, {1, 3}, {1, 3}, {3, 6}, {6, 7}, {7, 8}, {8, 9}, {9, 10}},
+			[]int{0, 0, 0, 0, 1, 1, 1, 1}},
+		{"added token", gpt2, "<|endoftext|>Hello, world!", tokenizer.EncodeOptions{},
+			[]string{"<|endoftext|>", "Hello", ",", "Ġworld", "!"},
+			[][2]int{{0, 13}, {13, 18}, {18, 19}, {19, 25}, {25, 26}},
+			[][2]int{{0, 13}, {13, 18}, {18, 19}, {19, 25}, {25, 26}},
+			[]int{0, 1, 2, 3, 4}},
+		{"wordpiece", writeConfig(t, bertConfig), "Unaffable is good.", tokenizer.EncodeOptions{AddSpecialTokens: true},
+			[]string{"[CLS]", "un", "##aff", "##able", "is", "good", ".", "[SEP]"},
+			[][2]int{{0, 0}, {0, 2}, {2, 5}, {5, 9}, {10, 12}, {13, 17}, {17, 18}, {0, 0}},
+			[][2]int{{0, 0}, {0, 2}, {2, 5}, {5, 9}, {10, 12}, {13, 17}, {17, 18}, {0, 0}},
+			[]int{-1, 0, 0, 0, 1, 2, 3, -1}},
+		{"metaspace byte fallback", writeConfig(t, llamaConfig), "hi é", tokenizer.EncodeOptions{},
+			[]string{"▁hi", "▁", "<0xC3>", "<0xA9>"},
+			[][2]int{{0, 2}, {2, 3}, {3, 4}, {3, 4}},
+			[][2]int{{0, 2}, {2, 3}, {3, 5}, {3, 5}},
+			[]int{0, 0, 0, 0}},
+		{"unigram", writeConfig(t, t5Config), "hello wörld", tokenizer.EncodeOptions{},
+			[]string{"▁hello", "▁", "w", "ö", "r", "l", "d"},
+			[][2]int{{0, 5}, {5, 6}, {6, 7}, {7, 8}, {8, 9}, {9, 10}, {10, 11}},
+			[][2]int{{0, 5}, {5, 6}, {6, 7}, {7, 9}, {9, 10}, {10, 11}, {11, 12}},
+			[]int{0, 1, 1, 1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		encoding, err := tt.tk.EncodeWithOptions(tt.text, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: encoding failed: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(encoding.Tokens, tt.tokens) {
+			t.Errorf("%s: tokens = %q, want %q", tt.name, encoding.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(encoding.Offsets, tt.offsets) {
+			t.Errorf("%s: offsets = %v, want %v", tt.name, encoding.Offsets, tt.offsets)
+		}
+		if !reflect.DeepEqual(encoding.ByteOffsets, tt.byteOffsets) {
+			t.Errorf("%s: byte offsets = %v, want %v", tt.name, encoding.ByteOffsets, tt.byteOffsets)
+		}
+		if !reflect.DeepEqual(encoding.WordIDs, tt.wordIDs) {
+			t.Errorf("%s: word ids = %v, want %v", tt.name, encoding.WordIDs, tt.wordIDs)
+		}
+	}
+}
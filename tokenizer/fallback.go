@@ -0,0 +1,32 @@
+package tokenizer
+
+// FallbackModelName 内置后备tokenizer的模型名称
+const FallbackModelName = "byte-level-fallback"
+
+// NewFallbackTokenizer 创建不依赖任何配置文件的字节级tokenizer：按GPT-2的正则预分词后，
+// 每个UTF-8字节为一个token（ID即字节值），可以无损解码。没有merges，token数约为BPE模型的3~4倍，
+// 结果只是近似值，Info与tokenize结果中的approximate为true
+func NewFallbackTokenizer() *Tokenizer {
+	config := &TokenizerConfig{
+		Vocabulary:    make(map[string]int, 256),
+		ReverseVocab:  make(map[int]string, 256),
+		SpecialTokens: make(map[string]string),
+		Merges:        make(MergeRanks),
+		ModelName:     FallbackModelName,
+		IsBPE:         true,
+		ModelType:     "BPE",
+		PreTokenizer:  map[string]interface{}{"type": "ByteLevel", "add_prefix_space": false, "use_regex": true},
+		Decoder:       map[string]interface{}{"type": "ByteLevel"},
+		Approximate:   true,
+	}
+	for b, r := range byteEncoder {
+		config.Vocabulary[string(r)] = b
+		config.ReverseVocab[b] = string(r)
+	}
+
+	tk, err := newTokenizerFromConfig(config)
+	if err != nil {
+		panic("tokenizer: invalid fallback config: " + err.Error())
+	}
+	return tk
+}
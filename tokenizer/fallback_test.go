@@ -0,0 +1,40 @@
+package tokenizer_test
+
+import (
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestFallbackTokenizer 测试内置后备tokenizer按字节编码、无损解码并标记为近似结果
+func TestFallbackTokenizer(t *testing.T) {
+	tk := tokenizer.NewFallbackTokenizer()
+	info := tk.Info()
+	if info.Name != tokenizer.FallbackModelName || info.VocabSize != 256 || !info.Approximate {
+		t.Errorf("unexpected model info: %+v", info)
+	}
+
+	text := "Hello, 世界! 👋\n"
+	result, err := tk.Tokenize(text)
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	if result.TokenCount != len(text) || !result.Approximate || result.UnknownCount != 0 {
+		t.Errorf("token_count = %d, approximate = %v, unknown = %d, want %d, true, 0",
+			result.TokenCount, result.Approximate, result.UnknownCount, len(text))
+	}
+	if result.TokenIDs[0] != 'H' || result.Tokens[6] != "Ġ" {
+		t.Errorf("unexpected tokens: %q %v", result.Tokens, result.TokenIDs)
+	}
+	// 与HF一致，多字节字符拆出的每个字节token都对应整个字符
+	for i := 7; i < 10; i++ {
+		if got := result.ByteOffsets[i]; got != [2]int{7, 10} {
+			t.Errorf("byte offsets of token %d = %v, want [7 10]", i, got)
+		}
+	}
+
+	decoded, err := tk.Decode(result.TokenIDs)
+	if err != nil || decoded != text {
+		t.Errorf("Decode = %q, %v, want %q", decoded, err, text)
+	}
+}
@@ -0,0 +1,78 @@
+package tokenizer_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// goldenCase HF tokenizers的参考输出，由testdata/golden/generate.py生成
+type goldenCase struct {
+	Text               string   `json:"text"`
+	Pair               string   `json:"pair"`
+	AddSpecialTokens   bool     `json:"add_special_tokens"`
+	IDs                []int    `json:"ids"`
+	Tokens             []string `json:"tokens"`
+	TypeIDs            []int    `json:"type_ids"`
+	Offsets            [][2]int `json:"offsets"`
+	Decoded            string   `json:"decoded"`
+	DecodedSkipSpecial string   `json:"decoded_skip_special"`
+}
+
+// TestGolden 对比BPE、WordPiece、Unigram三种tokenizer的编码与解码结果和HF tokenizers的参考输出
+func TestGolden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("tokenizer", "testdata", "golden", "*", "expected.json"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no golden files found: %v", err)
+	}
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			tk, err := tokenizer.NewTokenizer(dir)
+			if err != nil {
+				t.Fatalf("Failed to load tokenizer: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			var cases []goldenCase
+			if err := json.Unmarshal(data, &cases); err != nil {
+				t.Fatalf("Failed to parse golden file: %v", err)
+			}
+
+			for _, tc := range cases {
+				encoding, err := tk.EncodeWithOptions(tc.Text, tokenizer.EncodeOptions{AddSpecialTokens: tc.AddSpecialTokens, Pair: tc.Pair})
+				if err != nil {
+					t.Errorf("Encode(%q, %q) failed: %v", tc.Text, tc.Pair, err)
+					continue
+				}
+				if !reflect.DeepEqual(encoding.IDs, tc.IDs) {
+					t.Errorf("Encode(%q, %q) ids = %v, want %v", tc.Text, tc.Pair, encoding.IDs, tc.IDs)
+				}
+				if !reflect.DeepEqual(encoding.Tokens, tc.Tokens) {
+					t.Errorf("Encode(%q, %q) tokens = %q, want %q", tc.Text, tc.Pair, encoding.Tokens, tc.Tokens)
+				}
+				if !reflect.DeepEqual(encoding.TypeIDs, tc.TypeIDs) {
+					t.Errorf("Encode(%q, %q) type ids = %v, want %v", tc.Text, tc.Pair, encoding.TypeIDs, tc.TypeIDs)
+				}
+				if !reflect.DeepEqual(encoding.Offsets, tc.Offsets) {
+					t.Errorf("Encode(%q, %q) offsets = %v, want %v", tc.Text, tc.Pair, encoding.Offsets, tc.Offsets)
+				}
+
+				decoded, err := tk.Decode(tc.IDs)
+				if err != nil || decoded != tc.Decoded {
+					t.Errorf("Decode(%v) = %q, %v, want %q", tc.IDs, decoded, err, tc.Decoded)
+				}
+				decoded, err = tk.DecodeWithOptions(tc.IDs, tokenizer.DecodeOptions{SkipSpecialTokens: true})
+				if err != nil || decoded != tc.DecodedSkipSpecial {
+					t.Errorf("Decode(%v, skip special) = %q, %v, want %q", tc.IDs, decoded, err, tc.DecodedSkipSpecial)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package tokenizer
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// graphemeCat 字素簇断点属性，对应unicode-segmentation的GraphemeCat
+type graphemeCat uint8
+
+const (
+	gcAny graphemeCat = iota
+	gcCR
+	gcLF
+	gcControl
+	gcExtend
+	gcZWJ
+	gcExtPict
+	gcInCBConsonant
+	gcL
+	gcV
+	gcT
+	gcLV
+	gcLVT
+	gcPrepend
+	gcRegionalIndicator
+	gcSpacingMark
+)
+
+// graphemeRange graphemeCatTable中的一个区间
+type graphemeRange struct {
+	lo, hi rune
+	cat    graphemeCat
+}
+
+// graphemeCategory 查找字符的字素簇断点属性
+func graphemeCategory(r rune) graphemeCat {
+	i := sort.Search(len(graphemeCatTable), func(i int) bool { return graphemeCatTable[i].hi >= r })
+	if i < len(graphemeCatTable) && graphemeCatTable[i].lo <= r {
+		return graphemeCatTable[i].cat
+	}
+	return gcAny
+}
+
+// isInCBExtend 判断字符的Indic_Conjunct_Break是否为Extend
+func isInCBExtend(r rune) bool {
+	i := sort.Search(len(incbExtendTable), func(i int) bool { return incbExtendTable[i][1] >= r })
+	return i < len(incbExtendTable) && incbExtendTable[i][0] <= r
+}
+
+// isInCBLinker 判断字符的Indic_Conjunct_Break是否为Linker
+func isInCBLinker(r rune) bool {
+	switch r {
+	case '\u094D', '\u09CD', '\u0ACD', '\u0B4D', '\u0C4D', '\u0D4D':
+		return true
+	}
+	return false
+}
+
+// nextGrapheme 返回s中第一个扩展字素簇的字节长度，按UAX #29的GB3-GB13规则（含GB9a、GB9b与GB9c），
+// 与HF使用的unicode-segmentation 1.12.0（Unicode 16.0.0）一致。
+// s从字素簇边界开始，GB9c、GB11与GB12/13需要回看的字符都在当前簇内，因此只需记录簇内的状态
+func nextGrapheme(s string) int {
+	r, end := utf8.DecodeRuneInString(s)
+	prev := graphemeCategory(r)
+
+	var (
+		pictExtend  bool // 已读部分以ExtPict Extend*结尾
+		pictZWJ     bool // 已读部分以ExtPict Extend* ZWJ结尾
+		consonant   bool // 已读部分以InCB=Consonant [Extend Linker]*结尾
+		linker      bool // 上述Consonant之后出现过Linker
+		regionalRun int  // 已读部分末尾连续的区域指示符个数
+	)
+	update := func(r rune, cat graphemeCat) {
+		pictZWJ = cat == gcZWJ && pictExtend
+		pictExtend = cat == gcExtPict || (cat == gcExtend && pictExtend)
+
+		switch {
+		case cat == gcInCBConsonant:
+			consonant, linker = true, false
+		case isInCBLinker(r):
+			linker = linker || consonant
+		case isInCBExtend(r):
+		default:
+			consonant, linker = false, false
+		}
+
+		if cat == gcRegionalIndicator {
+			regionalRun++
+		} else {
+			regionalRun = 0
+		}
+	}
+	update(r, prev)
+
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		cat := graphemeCategory(r)
+		if graphemeBreak(prev, cat, pictZWJ, consonant && linker, regionalRun) {
+			return end
+		}
+		update(r, cat)
+		prev, end = cat, end+size
+	}
+	return end
+}
+
+// graphemeBreak 判断属性为before与after的相邻字符之间是否断开，规则顺序与unicode-segmentation的check_pair一致
+func graphemeBreak(before, after graphemeCat, pictZWJ, conjunct bool, regionalRun int) bool {
+	switch {
+	case before == gcCR && after == gcLF: // GB3
+		return false
+	case before == gcControl || before == gcCR || before == gcLF: // GB4
+		return true
+	case after == gcControl || after == gcCR || after == gcLF: // GB5
+		return true
+	case before == gcL && (after == gcL || after == gcV || after == gcLV || after == gcLVT): // GB6
+		return false
+	case (before == gcLV || before == gcV) && (after == gcV || after == gcT): // GB7
+		return false
+	case (before == gcLVT || before == gcT) && after == gcT: // GB8
+		return false
+	case after == gcExtend || after == gcZWJ: // GB9
+		return false
+	case after == gcSpacingMark: // GB9a
+		return false
+	case before == gcPrepend: // GB9b
+		return false
+	case after == gcInCBConsonant: // GB9c
+		return !conjunct
+	case before == gcZWJ && after == gcExtPict: // GB11
+		return !pictZWJ
+	case before == gcRegionalIndicator && after == gcRegionalIndicator: // GB12、GB13
+		return regionalRun%2 == 0
+	}
+	return true // GB999
+}
@@ -0,0 +1,443 @@
+package tokenizer
+
+// 以下表格由unicode-segmentation 1.12.0的src/tables.rs（Unicode 16.0.0）转换而来，升级Unicode版本时需一并重新生成
+
+// graphemeCatTable 扩展字素簇的Grapheme_Cluster_Break属性（InCB=Consonant单独列出），按区间排序，未列出的字符为Any
+var graphemeCatTable = []graphemeRange{
+	{0x0, 0x9, gcControl}, {0xA, 0xA, gcLF}, {0xB, 0xC, gcControl}, {0xD, 0xD, gcCR},
+	{0xE, 0x1F, gcControl}, {0x7F, 0x9F, gcControl}, {0xA9, 0xA9, gcExtPict}, {0xAD, 0xAD, gcControl},
+	{0xAE, 0xAE, gcExtPict}, {0x300, 0x36F, gcExtend}, {0x483, 0x489, gcExtend}, {0x591, 0x5BD, gcExtend},
+	{0x5BF, 0x5BF, gcExtend}, {0x5C1, 0x5C2, gcExtend}, {0x5C4, 0x5C5, gcExtend}, {0x5C7, 0x5C7, gcExtend},
+	{0x600, 0x605, gcPrepend}, {0x610, 0x61A, gcExtend}, {0x61C, 0x61C, gcControl}, {0x64B, 0x65F, gcExtend},
+	{0x670, 0x670, gcExtend}, {0x6D6, 0x6DC, gcExtend}, {0x6DD, 0x6DD, gcPrepend}, {0x6DF, 0x6E4, gcExtend},
+	{0x6E7, 0x6E8, gcExtend}, {0x6EA, 0x6ED, gcExtend}, {0x70F, 0x70F, gcPrepend}, {0x711, 0x711, gcExtend},
+	{0x730, 0x74A, gcExtend}, {0x7A6, 0x7B0, gcExtend}, {0x7EB, 0x7F3, gcExtend}, {0x7FD, 0x7FD, gcExtend},
+	{0x816, 0x819, gcExtend}, {0x81B, 0x823, gcExtend}, {0x825, 0x827, gcExtend}, {0x829, 0x82D, gcExtend},
+	{0x859, 0x85B, gcExtend}, {0x890, 0x891, gcPrepend}, {0x897, 0x89F, gcExtend}, {0x8CA, 0x8E1, gcExtend},
+	{0x8E2, 0x8E2, gcPrepend}, {0x8E3, 0x902, gcExtend}, {0x903, 0x903, gcSpacingMark}, {0x915, 0x939, gcInCBConsonant},
+	{0x93A, 0x93A, gcExtend}, {0x93B, 0x93B, gcSpacingMark}, {0x93C, 0x93C, gcExtend}, {0x93E, 0x940, gcSpacingMark},
+	{0x941, 0x948, gcExtend}, {0x949, 0x94C, gcSpacingMark}, {0x94D, 0x94D, gcExtend}, {0x94E, 0x94F, gcSpacingMark},
+	{0x951, 0x957, gcExtend}, {0x958, 0x95F, gcInCBConsonant}, {0x962, 0x963, gcExtend}, {0x978, 0x97F, gcInCBConsonant},
+	{0x981, 0x981, gcExtend}, {0x982, 0x983, gcSpacingMark}, {0x995, 0x9A8, gcInCBConsonant}, {0x9AA, 0x9B0, gcInCBConsonant},
+	{0x9B2, 0x9B2, gcInCBConsonant}, {0x9B6, 0x9B9, gcInCBConsonant}, {0x9BC, 0x9BC, gcExtend}, {0x9BE, 0x9BE, gcExtend},
+	{0x9BF, 0x9C0, gcSpacingMark}, {0x9C1, 0x9C4, gcExtend}, {0x9C7, 0x9C8, gcSpacingMark}, {0x9CB, 0x9CC, gcSpacingMark},
+	{0x9CD, 0x9CD, gcExtend}, {0x9D7, 0x9D7, gcExtend}, {0x9DC, 0x9DD, gcInCBConsonant}, {0x9DF, 0x9DF, gcInCBConsonant},
+	{0x9E2, 0x9E3, gcExtend}, {0x9F0, 0x9F1, gcInCBConsonant}, {0x9FE, 0x9FE, gcExtend}, {0xA01, 0xA02, gcExtend},
+	{0xA03, 0xA03, gcSpacingMark}, {0xA3C, 0xA3C, gcExtend}, {0xA3E, 0xA40, gcSpacingMark}, {0xA41, 0xA42, gcExtend},
+	{0xA47, 0xA48, gcExtend}, {0xA4B, 0xA4D, gcExtend}, {0xA51, 0xA51, gcExtend}, {0xA70, 0xA71, gcExtend},
+	{0xA75, 0xA75, gcExtend}, {0xA81, 0xA82, gcExtend}, {0xA83, 0xA83, gcSpacingMark}, {0xA95, 0xAA8, gcInCBConsonant},
+	{0xAAA, 0xAB0, gcInCBConsonant}, {0xAB2, 0xAB3, gcInCBConsonant}, {0xAB5, 0xAB9, gcInCBConsonant}, {0xABC, 0xABC, gcExtend},
+	{0xABE, 0xAC0, gcSpacingMark}, {0xAC1, 0xAC5, gcExtend}, {0xAC7, 0xAC8, gcExtend}, {0xAC9, 0xAC9, gcSpacingMark},
+	{0xACB, 0xACC, gcSpacingMark}, {0xACD, 0xACD, gcExtend}, {0xAE2, 0xAE3, gcExtend}, {0xAF9, 0xAF9, gcInCBConsonant},
+	{0xAFA, 0xAFF, gcExtend}, {0xB01, 0xB01, gcExtend}, {0xB02, 0xB03, gcSpacingMark}, {0xB15, 0xB28, gcInCBConsonant},
+	{0xB2A, 0xB30, gcInCBConsonant}, {0xB32, 0xB33, gcInCBConsonant}, {0xB35, 0xB39, gcInCBConsonant}, {0xB3C, 0xB3C, gcExtend},
+	{0xB3E, 0xB3F, gcExtend}, {0xB40, 0xB40, gcSpacingMark}, {0xB41, 0xB44, gcExtend}, {0xB47, 0xB48, gcSpacingMark},
+	{0xB4B, 0xB4C, gcSpacingMark}, {0xB4D, 0xB4D, gcExtend}, {0xB55, 0xB57, gcExtend}, {0xB5C, 0xB5D, gcInCBConsonant},
+	{0xB5F, 0xB5F, gcInCBConsonant}, {0xB62, 0xB63, gcExtend}, {0xB71, 0xB71, gcInCBConsonant}, {0xB82, 0xB82, gcExtend},
+	{0xBBE, 0xBBE, gcExtend}, {0xBBF, 0xBBF, gcSpacingMark}, {0xBC0, 0xBC0, gcExtend}, {0xBC1, 0xBC2, gcSpacingMark},
+	{0xBC6, 0xBC8, gcSpacingMark}, {0xBCA, 0xBCC, gcSpacingMark}, {0xBCD, 0xBCD, gcExtend}, {0xBD7, 0xBD7, gcExtend},
+	{0xC00, 0xC00, gcExtend}, {0xC01, 0xC03, gcSpacingMark}, {0xC04, 0xC04, gcExtend}, {0xC15, 0xC28, gcInCBConsonant},
+	{0xC2A, 0xC39, gcInCBConsonant}, {0xC3C, 0xC3C, gcExtend}, {0xC3E, 0xC40, gcExtend}, {0xC41, 0xC44, gcSpacingMark},
+	{0xC46, 0xC48, gcExtend}, {0xC4A, 0xC4D, gcExtend}, {0xC55, 0xC56, gcExtend}, {0xC58, 0xC5A, gcInCBConsonant},
+	{0xC62, 0xC63, gcExtend}, {0xC81, 0xC81, gcExtend}, {0xC82, 0xC83, gcSpacingMark}, {0xCBC, 0xCBC, gcExtend},
+	{0xCBE, 0xCBE, gcSpacingMark}, {0xCBF, 0xCC0, gcExtend}, {0xCC1, 0xCC1, gcSpacingMark}, {0xCC2, 0xCC2, gcExtend},
+	{0xCC3, 0xCC4, gcSpacingMark}, {0xCC6, 0xCC8, gcExtend}, {0xCCA, 0xCCD, gcExtend}, {0xCD5, 0xCD6, gcExtend},
+	{0xCE2, 0xCE3, gcExtend}, {0xCF3, 0xCF3, gcSpacingMark}, {0xD00, 0xD01, gcExtend}, {0xD02, 0xD03, gcSpacingMark},
+	{0xD15, 0xD3A, gcInCBConsonant}, {0xD3B, 0xD3C, gcExtend}, {0xD3E, 0xD3E, gcExtend}, {0xD3F, 0xD40, gcSpacingMark},
+	{0xD41, 0xD44, gcExtend}, {0xD46, 0xD48, gcSpacingMark}, {0xD4A, 0xD4C, gcSpacingMark}, {0xD4D, 0xD4D, gcExtend},
+	{0xD4E, 0xD4E, gcPrepend}, {0xD57, 0xD57, gcExtend}, {0xD62, 0xD63, gcExtend}, {0xD81, 0xD81, gcExtend},
+	{0xD82, 0xD83, gcSpacingMark}, {0xDCA, 0xDCA, gcExtend}, {0xDCF, 0xDCF, gcExtend}, {0xDD0, 0xDD1, gcSpacingMark},
+	{0xDD2, 0xDD4, gcExtend}, {0xDD6, 0xDD6, gcExtend}, {0xDD8, 0xDDE, gcSpacingMark}, {0xDDF, 0xDDF, gcExtend},
+	{0xDF2, 0xDF3, gcSpacingMark}, {0xE31, 0xE31, gcExtend}, {0xE33, 0xE33, gcSpacingMark}, {0xE34, 0xE3A, gcExtend},
+	{0xE47, 0xE4E, gcExtend}, {0xEB1, 0xEB1, gcExtend}, {0xEB3, 0xEB3, gcSpacingMark}, {0xEB4, 0xEBC, gcExtend},
+	{0xEC8, 0xECE, gcExtend}, {0xF18, 0xF19, gcExtend}, {0xF35, 0xF35, gcExtend}, {0xF37, 0xF37, gcExtend},
+	{0xF39, 0xF39, gcExtend}, {0xF3E, 0xF3F, gcSpacingMark}, {0xF71, 0xF7E, gcExtend}, {0xF7F, 0xF7F, gcSpacingMark},
+	{0xF80, 0xF84, gcExtend}, {0xF86, 0xF87, gcExtend}, {0xF8D, 0xF97, gcExtend}, {0xF99, 0xFBC, gcExtend},
+	{0xFC6, 0xFC6, gcExtend}, {0x102D, 0x1030, gcExtend}, {0x1031, 0x1031, gcSpacingMark}, {0x1032, 0x1037, gcExtend},
+	{0x1039, 0x103A, gcExtend}, {0x103B, 0x103C, gcSpacingMark}, {0x103D, 0x103E, gcExtend}, {0x1056, 0x1057, gcSpacingMark},
+	{0x1058, 0x1059, gcExtend}, {0x105E, 0x1060, gcExtend}, {0x1071, 0x1074, gcExtend}, {0x1082, 0x1082, gcExtend},
+	{0x1084, 0x1084, gcSpacingMark}, {0x1085, 0x1086, gcExtend}, {0x108D, 0x108D, gcExtend}, {0x109D, 0x109D, gcExtend},
+	{0x1100, 0x115F, gcL}, {0x1160, 0x11A7, gcV}, {0x11A8, 0x11FF, gcT}, {0x135D, 0x135F, gcExtend},
+	{0x1712, 0x1715, gcExtend}, {0x1732, 0x1734, gcExtend}, {0x1752, 0x1753, gcExtend}, {0x1772, 0x1773, gcExtend},
+	{0x17B4, 0x17B5, gcExtend}, {0x17B6, 0x17B6, gcSpacingMark}, {0x17B7, 0x17BD, gcExtend}, {0x17BE, 0x17C5, gcSpacingMark},
+	{0x17C6, 0x17C6, gcExtend}, {0x17C7, 0x17C8, gcSpacingMark}, {0x17C9, 0x17D3, gcExtend}, {0x17DD, 0x17DD, gcExtend},
+	{0x180B, 0x180D, gcExtend}, {0x180E, 0x180E, gcControl}, {0x180F, 0x180F, gcExtend}, {0x1885, 0x1886, gcExtend},
+	{0x18A9, 0x18A9, gcExtend}, {0x1920, 0x1922, gcExtend}, {0x1923, 0x1926, gcSpacingMark}, {0x1927, 0x1928, gcExtend},
+	{0x1929, 0x192B, gcSpacingMark}, {0x1930, 0x1931, gcSpacingMark}, {0x1932, 0x1932, gcExtend}, {0x1933, 0x1938, gcSpacingMark},
+	{0x1939, 0x193B, gcExtend}, {0x1A17, 0x1A18, gcExtend}, {0x1A19, 0x1A1A, gcSpacingMark}, {0x1A1B, 0x1A1B, gcExtend},
+	{0x1A55, 0x1A55, gcSpacingMark}, {0x1A56, 0x1A56, gcExtend}, {0x1A57, 0x1A57, gcSpacingMark}, {0x1A58, 0x1A5E, gcExtend},
+	{0x1A60, 0x1A60, gcExtend}, {0x1A62, 0x1A62, gcExtend}, {0x1A65, 0x1A6C, gcExtend}, {0x1A6D, 0x1A72, gcSpacingMark},
+	{0x1A73, 0x1A7C, gcExtend}, {0x1A7F, 0x1A7F, gcExtend}, {0x1AB0, 0x1ACE, gcExtend}, {0x1B00, 0x1B03, gcExtend},
+	{0x1B04, 0x1B04, gcSpacingMark}, {0x1B34, 0x1B3D, gcExtend}, {0x1B3E, 0x1B41, gcSpacingMark}, {0x1B42, 0x1B44, gcExtend},
+	{0x1B6B, 0x1B73, gcExtend}, {0x1B80, 0x1B81, gcExtend}, {0x1B82, 0x1B82, gcSpacingMark}, {0x1BA1, 0x1BA1, gcSpacingMark},
+	{0x1BA2, 0x1BA5, gcExtend}, {0x1BA6, 0x1BA7, gcSpacingMark}, {0x1BA8, 0x1BAD, gcExtend}, {0x1BE6, 0x1BE6, gcExtend},
+	{0x1BE7, 0x1BE7, gcSpacingMark}, {0x1BE8, 0x1BE9, gcExtend}, {0x1BEA, 0x1BEC, gcSpacingMark}, {0x1BED, 0x1BED, gcExtend},
+	{0x1BEE, 0x1BEE, gcSpacingMark}, {0x1BEF, 0x1BF3, gcExtend}, {0x1C24, 0x1C2B, gcSpacingMark}, {0x1C2C, 0x1C33, gcExtend},
+	{0x1C34, 0x1C35, gcSpacingMark}, {0x1C36, 0x1C37, gcExtend}, {0x1CD0, 0x1CD2, gcExtend}, {0x1CD4, 0x1CE0, gcExtend},
+	{0x1CE1, 0x1CE1, gcSpacingMark}, {0x1CE2, 0x1CE8, gcExtend}, {0x1CED, 0x1CED, gcExtend}, {0x1CF4, 0x1CF4, gcExtend},
+	{0x1CF7, 0x1CF7, gcSpacingMark}, {0x1CF8, 0x1CF9, gcExtend}, {0x1DC0, 0x1DFF, gcExtend}, {0x200B, 0x200B, gcControl},
+	{0x200C, 0x200C, gcExtend}, {0x200D, 0x200D, gcZWJ}, {0x200E, 0x200F, gcControl}, {0x2028, 0x202E, gcControl},
+	{0x203C, 0x203C, gcExtPict}, {0x2049, 0x2049, gcExtPict}, {0x2060, 0x206F, gcControl}, {0x20D0, 0x20F0, gcExtend},
+	{0x2122, 0x2122, gcExtPict}, {0x2139, 0x2139, gcExtPict}, {0x2194, 0x2199, gcExtPict}, {0x21A9, 0x21AA, gcExtPict},
+	{0x231A, 0x231B, gcExtPict}, {0x2328, 0x2328, gcExtPict}, {0x2388, 0x2388, gcExtPict}, {0x23CF, 0x23CF, gcExtPict},
+	{0x23E9, 0x23F3, gcExtPict}, {0x23F8, 0x23FA, gcExtPict}, {0x24C2, 0x24C2, gcExtPict}, {0x25AA, 0x25AB, gcExtPict},
+	{0x25B6, 0x25B6, gcExtPict}, {0x25C0, 0x25C0, gcExtPict}, {0x25FB, 0x25FE, gcExtPict}, {0x2600, 0x2605, gcExtPict},
+	{0x2607, 0x2612, gcExtPict}, {0x2614, 0x2685, gcExtPict}, {0x2690, 0x2705, gcExtPict}, {0x2708, 0x2712, gcExtPict},
+	{0x2714, 0x2714, gcExtPict}, {0x2716, 0x2716, gcExtPict}, {0x271D, 0x271D, gcExtPict}, {0x2721, 0x2721, gcExtPict},
+	{0x2728, 0x2728, gcExtPict}, {0x2733, 0x2734, gcExtPict}, {0x2744, 0x2744, gcExtPict}, {0x2747, 0x2747, gcExtPict},
+	{0x274C, 0x274C, gcExtPict}, {0x274E, 0x274E, gcExtPict}, {0x2753, 0x2755, gcExtPict}, {0x2757, 0x2757, gcExtPict},
+	{0x2763, 0x2767, gcExtPict}, {0x2795, 0x2797, gcExtPict}, {0x27A1, 0x27A1, gcExtPict}, {0x27B0, 0x27B0, gcExtPict},
+	{0x27BF, 0x27BF, gcExtPict}, {0x2934, 0x2935, gcExtPict}, {0x2B05, 0x2B07, gcExtPict}, {0x2B1B, 0x2B1C, gcExtPict},
+	{0x2B50, 0x2B50, gcExtPict}, {0x2B55, 0x2B55, gcExtPict}, {0x2CEF, 0x2CF1, gcExtend}, {0x2D7F, 0x2D7F, gcExtend},
+	{0x2DE0, 0x2DFF, gcExtend}, {0x302A, 0x302F, gcExtend}, {0x3030, 0x3030, gcExtPict}, {0x303D, 0x303D, gcExtPict},
+	{0x3099, 0x309A, gcExtend}, {0x3297, 0x3297, gcExtPict}, {0x3299, 0x3299, gcExtPict}, {0xA66F, 0xA672, gcExtend},
+	{0xA674, 0xA67D, gcExtend}, {0xA69E, 0xA69F, gcExtend}, {0xA6F0, 0xA6F1, gcExtend}, {0xA802, 0xA802, gcExtend},
+	{0xA806, 0xA806, gcExtend}, {0xA80B, 0xA80B, gcExtend}, {0xA823, 0xA824, gcSpacingMark}, {0xA825, 0xA826, gcExtend},
+	{0xA827, 0xA827, gcSpacingMark}, {0xA82C, 0xA82C, gcExtend}, {0xA880, 0xA881, gcSpacingMark}, {0xA8B4, 0xA8C3, gcSpacingMark},
+	{0xA8C4, 0xA8C5, gcExtend}, {0xA8E0, 0xA8F1, gcExtend}, {0xA8FF, 0xA8FF, gcExtend}, {0xA926, 0xA92D, gcExtend},
+	{0xA947, 0xA951, gcExtend}, {0xA952, 0xA952, gcSpacingMark}, {0xA953, 0xA953, gcExtend}, {0xA960, 0xA97C, gcL},
+	{0xA980, 0xA982, gcExtend}, {0xA983, 0xA983, gcSpacingMark}, {0xA9B3, 0xA9B3, gcExtend}, {0xA9B4, 0xA9B5, gcSpacingMark},
+	{0xA9B6, 0xA9B9, gcExtend}, {0xA9BA, 0xA9BB, gcSpacingMark}, {0xA9BC, 0xA9BD, gcExtend}, {0xA9BE, 0xA9BF, gcSpacingMark},
+	{0xA9C0, 0xA9C0, gcExtend}, {0xA9E5, 0xA9E5, gcExtend}, {0xAA29, 0xAA2E, gcExtend}, {0xAA2F, 0xAA30, gcSpacingMark},
+	{0xAA31, 0xAA32, gcExtend}, {0xAA33, 0xAA34, gcSpacingMark}, {0xAA35, 0xAA36, gcExtend}, {0xAA43, 0xAA43, gcExtend},
+	{0xAA4C, 0xAA4C, gcExtend}, {0xAA4D, 0xAA4D, gcSpacingMark}, {0xAA7C, 0xAA7C, gcExtend}, {0xAAB0, 0xAAB0, gcExtend},
+	{0xAAB2, 0xAAB4, gcExtend}, {0xAAB7, 0xAAB8, gcExtend}, {0xAABE, 0xAABF, gcExtend}, {0xAAC1, 0xAAC1, gcExtend},
+	{0xAAEB, 0xAAEB, gcSpacingMark}, {0xAAEC, 0xAAED, gcExtend}, {0xAAEE, 0xAAEF, gcSpacingMark}, {0xAAF5, 0xAAF5, gcSpacingMark},
+	{0xAAF6, 0xAAF6, gcExtend}, {0xABE3, 0xABE4, gcSpacingMark}, {0xABE5, 0xABE5, gcExtend}, {0xABE6, 0xABE7, gcSpacingMark},
+	{0xABE8, 0xABE8, gcExtend}, {0xABE9, 0xABEA, gcSpacingMark}, {0xABEC, 0xABEC, gcSpacingMark}, {0xABED, 0xABED, gcExtend},
+	{0xAC00, 0xAC00, gcLV}, {0xAC01, 0xAC1B, gcLVT}, {0xAC1C, 0xAC1C, gcLV}, {0xAC1D, 0xAC37, gcLVT},
+	{0xAC38, 0xAC38, gcLV}, {0xAC39, 0xAC53, gcLVT}, {0xAC54, 0xAC54, gcLV}, {0xAC55, 0xAC6F, gcLVT},
+	{0xAC70, 0xAC70, gcLV}, {0xAC71, 0xAC8B, gcLVT}, {0xAC8C, 0xAC8C, gcLV}, {0xAC8D, 0xACA7, gcLVT},
+	{0xACA8, 0xACA8, gcLV}, {0xACA9, 0xACC3, gcLVT}, {0xACC4, 0xACC4, gcLV}, {0xACC5, 0xACDF, gcLVT},
+	{0xACE0, 0xACE0, gcLV}, {0xACE1, 0xACFB, gcLVT}, {0xACFC, 0xACFC, gcLV}, {0xACFD, 0xAD17, gcLVT},
+	{0xAD18, 0xAD18, gcLV}, {0xAD19, 0xAD33, gcLVT}, {0xAD34, 0xAD34, gcLV}, {0xAD35, 0xAD4F, gcLVT},
+	{0xAD50, 0xAD50, gcLV}, {0xAD51, 0xAD6B, gcLVT}, {0xAD6C, 0xAD6C, gcLV}, {0xAD6D, 0xAD87, gcLVT},
+	{0xAD88, 0xAD88, gcLV}, {0xAD89, 0xADA3, gcLVT}, {0xADA4, 0xADA4, gcLV}, {0xADA5, 0xADBF, gcLVT},
+	{0xADC0, 0xADC0, gcLV}, {0xADC1, 0xADDB, gcLVT}, {0xADDC, 0xADDC, gcLV}, {0xADDD, 0xADF7, gcLVT},
+	{0xADF8, 0xADF8, gcLV}, {0xADF9, 0xAE13, gcLVT}, {0xAE14, 0xAE14, gcLV}, {0xAE15, 0xAE2F, gcLVT},
+	{0xAE30, 0xAE30, gcLV}, {0xAE31, 0xAE4B, gcLVT}, {0xAE4C, 0xAE4C, gcLV}, {0xAE4D, 0xAE67, gcLVT},
+	{0xAE68, 0xAE68, gcLV}, {0xAE69, 0xAE83, gcLVT}, {0xAE84, 0xAE84, gcLV}, {0xAE85, 0xAE9F, gcLVT},
+	{0xAEA0, 0xAEA0, gcLV}, {0xAEA1, 0xAEBB, gcLVT}, {0xAEBC, 0xAEBC, gcLV}, {0xAEBD, 0xAED7, gcLVT},
+	{0xAED8, 0xAED8, gcLV}, {0xAED9, 0xAEF3, gcLVT}, {0xAEF4, 0xAEF4, gcLV}, {0xAEF5, 0xAF0F, gcLVT},
+	{0xAF10, 0xAF10, gcLV}, {0xAF11, 0xAF2B, gcLVT}, {0xAF2C, 0xAF2C, gcLV}, {0xAF2D, 0xAF47, gcLVT},
+	{0xAF48, 0xAF48, gcLV}, {0xAF49, 0xAF63, gcLVT}, {0xAF64, 0xAF64, gcLV}, {0xAF65, 0xAF7F, gcLVT},
+	{0xAF80, 0xAF80, gcLV}, {0xAF81, 0xAF9B, gcLVT}, {0xAF9C, 0xAF9C, gcLV}, {0xAF9D, 0xAFB7, gcLVT},
+	{0xAFB8, 0xAFB8, gcLV}, {0xAFB9, 0xAFD3, gcLVT}, {0xAFD4, 0xAFD4, gcLV}, {0xAFD5, 0xAFEF, gcLVT},
+	{0xAFF0, 0xAFF0, gcLV}, {0xAFF1, 0xB00B, gcLVT}, {0xB00C, 0xB00C, gcLV}, {0xB00D, 0xB027, gcLVT},
+	{0xB028, 0xB028, gcLV}, {0xB029, 0xB043, gcLVT}, {0xB044, 0xB044, gcLV}, {0xB045, 0xB05F, gcLVT},
+	{0xB060, 0xB060, gcLV}, {0xB061, 0xB07B, gcLVT}, {0xB07C, 0xB07C, gcLV}, {0xB07D, 0xB097, gcLVT},
+	{0xB098, 0xB098, gcLV}, {0xB099, 0xB0B3, gcLVT}, {0xB0B4, 0xB0B4, gcLV}, {0xB0B5, 0xB0CF, gcLVT},
+	{0xB0D0, 0xB0D0, gcLV}, {0xB0D1, 0xB0EB, gcLVT}, {0xB0EC, 0xB0EC, gcLV}, {0xB0ED, 0xB107, gcLVT},
+	{0xB108, 0xB108, gcLV}, {0xB109, 0xB123, gcLVT}, {0xB124, 0xB124, gcLV}, {0xB125, 0xB13F, gcLVT},
+	{0xB140, 0xB140, gcLV}, {0xB141, 0xB15B, gcLVT}, {0xB15C, 0xB15C, gcLV}, {0xB15D, 0xB177, gcLVT},
+	{0xB178, 0xB178, gcLV}, {0xB179, 0xB193, gcLVT}, {0xB194, 0xB194, gcLV}, {0xB195, 0xB1AF, gcLVT},
+	{0xB1B0, 0xB1B0, gcLV}, {0xB1B1, 0xB1CB, gcLVT}, {0xB1CC, 0xB1CC, gcLV}, {0xB1CD, 0xB1E7, gcLVT},
+	{0xB1E8, 0xB1E8, gcLV}, {0xB1E9, 0xB203, gcLVT}, {0xB204, 0xB204, gcLV}, {0xB205, 0xB21F, gcLVT},
+	{0xB220, 0xB220, gcLV}, {0xB221, 0xB23B, gcLVT}, {0xB23C, 0xB23C, gcLV}, {0xB23D, 0xB257, gcLVT},
+	{0xB258, 0xB258, gcLV}, {0xB259, 0xB273, gcLVT}, {0xB274, 0xB274, gcLV}, {0xB275, 0xB28F, gcLVT},
+	{0xB290, 0xB290, gcLV}, {0xB291, 0xB2AB, gcLVT}, {0xB2AC, 0xB2AC, gcLV}, {0xB2AD, 0xB2C7, gcLVT},
+	{0xB2C8, 0xB2C8, gcLV}, {0xB2C9, 0xB2E3, gcLVT}, {0xB2E4, 0xB2E4, gcLV}, {0xB2E5, 0xB2FF, gcLVT},
+	{0xB300, 0xB300, gcLV}, {0xB301, 0xB31B, gcLVT}, {0xB31C, 0xB31C, gcLV}, {0xB31D, 0xB337, gcLVT},
+	{0xB338, 0xB338, gcLV}, {0xB339, 0xB353, gcLVT}, {0xB354, 0xB354, gcLV}, {0xB355, 0xB36F, gcLVT},
+	{0xB370, 0xB370, gcLV}, {0xB371, 0xB38B, gcLVT}, {0xB38C, 0xB38C, gcLV}, {0xB38D, 0xB3A7, gcLVT},
+	{0xB3A8, 0xB3A8, gcLV}, {0xB3A9, 0xB3C3, gcLVT}, {0xB3C4, 0xB3C4, gcLV}, {0xB3C5, 0xB3DF, gcLVT},
+	{0xB3E0, 0xB3E0, gcLV}, {0xB3E1, 0xB3FB, gcLVT}, {0xB3FC, 0xB3FC, gcLV}, {0xB3FD, 0xB417, gcLVT},
+	{0xB418, 0xB418, gcLV}, {0xB419, 0xB433, gcLVT}, {0xB434, 0xB434, gcLV}, {0xB435, 0xB44F, gcLVT},
+	{0xB450, 0xB450, gcLV}, {0xB451, 0xB46B, gcLVT}, {0xB46C, 0xB46C, gcLV}, {0xB46D, 0xB487, gcLVT},
+	{0xB488, 0xB488, gcLV}, {0xB489, 0xB4A3, gcLVT}, {0xB4A4, 0xB4A4, gcLV}, {0xB4A5, 0xB4BF, gcLVT},
+	{0xB4C0, 0xB4C0, gcLV}, {0xB4C1, 0xB4DB, gcLVT}, {0xB4DC, 0xB4DC, gcLV}, {0xB4DD, 0xB4F7, gcLVT},
+	{0xB4F8, 0xB4F8, gcLV}, {0xB4F9, 0xB513, gcLVT}, {0xB514, 0xB514, gcLV}, {0xB515, 0xB52F, gcLVT},
+	{0xB530, 0xB530, gcLV}, {0xB531, 0xB54B, gcLVT}, {0xB54C, 0xB54C, gcLV}, {0xB54D, 0xB567, gcLVT},
+	{0xB568, 0xB568, gcLV}, {0xB569, 0xB583, gcLVT}, {0xB584, 0xB584, gcLV}, {0xB585, 0xB59F, gcLVT},
+	{0xB5A0, 0xB5A0, gcLV}, {0xB5A1, 0xB5BB, gcLVT}, {0xB5BC, 0xB5BC, gcLV}, {0xB5BD, 0xB5D7, gcLVT},
+	{0xB5D8, 0xB5D8, gcLV}, {0xB5D9, 0xB5F3, gcLVT}, {0xB5F4, 0xB5F4, gcLV}, {0xB5F5, 0xB60F, gcLVT},
+	{0xB610, 0xB610, gcLV}, {0xB611, 0xB62B, gcLVT}, {0xB62C, 0xB62C, gcLV}, {0xB62D, 0xB647, gcLVT},
+	{0xB648, 0xB648, gcLV}, {0xB649, 0xB663, gcLVT}, {0xB664, 0xB664, gcLV}, {0xB665, 0xB67F, gcLVT},
+	{0xB680, 0xB680, gcLV}, {0xB681, 0xB69B, gcLVT}, {0xB69C, 0xB69C, gcLV}, {0xB69D, 0xB6B7, gcLVT},
+	{0xB6B8, 0xB6B8, gcLV}, {0xB6B9, 0xB6D3, gcLVT}, {0xB6D4, 0xB6D4, gcLV}, {0xB6D5, 0xB6EF, gcLVT},
+	{0xB6F0, 0xB6F0, gcLV}, {0xB6F1, 0xB70B, gcLVT}, {0xB70C, 0xB70C, gcLV}, {0xB70D, 0xB727, gcLVT},
+	{0xB728, 0xB728, gcLV}, {0xB729, 0xB743, gcLVT}, {0xB744, 0xB744, gcLV}, {0xB745, 0xB75F, gcLVT},
+	{0xB760, 0xB760, gcLV}, {0xB761, 0xB77B, gcLVT}, {0xB77C, 0xB77C, gcLV}, {0xB77D, 0xB797, gcLVT},
+	{0xB798, 0xB798, gcLV}, {0xB799, 0xB7B3, gcLVT}, {0xB7B4, 0xB7B4, gcLV}, {0xB7B5, 0xB7CF, gcLVT},
+	{0xB7D0, 0xB7D0, gcLV}, {0xB7D1, 0xB7EB, gcLVT}, {0xB7EC, 0xB7EC, gcLV}, {0xB7ED, 0xB807, gcLVT},
+	{0xB808, 0xB808, gcLV}, {0xB809, 0xB823, gcLVT}, {0xB824, 0xB824, gcLV}, {0xB825, 0xB83F, gcLVT},
+	{0xB840, 0xB840, gcLV}, {0xB841, 0xB85B, gcLVT}, {0xB85C, 0xB85C, gcLV}, {0xB85D, 0xB877, gcLVT},
+	{0xB878, 0xB878, gcLV}, {0xB879, 0xB893, gcLVT}, {0xB894, 0xB894, gcLV}, {0xB895, 0xB8AF, gcLVT},
+	{0xB8B0, 0xB8B0, gcLV}, {0xB8B1, 0xB8CB, gcLVT}, {0xB8CC, 0xB8CC, gcLV}, {0xB8CD, 0xB8E7, gcLVT},
+	{0xB8E8, 0xB8E8, gcLV}, {0xB8E9, 0xB903, gcLVT}, {0xB904, 0xB904, gcLV}, {0xB905, 0xB91F, gcLVT},
+	{0xB920, 0xB920, gcLV}, {0xB921, 0xB93B, gcLVT}, {0xB93C, 0xB93C, gcLV}, {0xB93D, 0xB957, gcLVT},
+	{0xB958, 0xB958, gcLV}, {0xB959, 0xB973, gcLVT}, {0xB974, 0xB974, gcLV}, {0xB975, 0xB98F, gcLVT},
+	{0xB990, 0xB990, gcLV}, {0xB991, 0xB9AB, gcLVT}, {0xB9AC, 0xB9AC, gcLV}, {0xB9AD, 0xB9C7, gcLVT},
+	{0xB9C8, 0xB9C8, gcLV}, {0xB9C9, 0xB9E3, gcLVT}, {0xB9E4, 0xB9E4, gcLV}, {0xB9E5, 0xB9FF, gcLVT},
+	{0xBA00, 0xBA00, gcLV}, {0xBA01, 0xBA1B, gcLVT}, {0xBA1C, 0xBA1C, gcLV}, {0xBA1D, 0xBA37, gcLVT},
+	{0xBA38, 0xBA38, gcLV}, {0xBA39, 0xBA53, gcLVT}, {0xBA54, 0xBA54, gcLV}, {0xBA55, 0xBA6F, gcLVT},
+	{0xBA70, 0xBA70, gcLV}, {0xBA71, 0xBA8B, gcLVT}, {0xBA8C, 0xBA8C, gcLV}, {0xBA8D, 0xBAA7, gcLVT},
+	{0xBAA8, 0xBAA8, gcLV}, {0xBAA9, 0xBAC3, gcLVT}, {0xBAC4, 0xBAC4, gcLV}, {0xBAC5, 0xBADF, gcLVT},
+	{0xBAE0, 0xBAE0, gcLV}, {0xBAE1, 0xBAFB, gcLVT}, {0xBAFC, 0xBAFC, gcLV}, {0xBAFD, 0xBB17, gcLVT},
+	{0xBB18, 0xBB18, gcLV}, {0xBB19, 0xBB33, gcLVT}, {0xBB34, 0xBB34, gcLV}, {0xBB35, 0xBB4F, gcLVT},
+	{0xBB50, 0xBB50, gcLV}, {0xBB51, 0xBB6B, gcLVT}, {0xBB6C, 0xBB6C, gcLV}, {0xBB6D, 0xBB87, gcLVT},
+	{0xBB88, 0xBB88, gcLV}, {0xBB89, 0xBBA3, gcLVT}, {0xBBA4, 0xBBA4, gcLV}, {0xBBA5, 0xBBBF, gcLVT},
+	{0xBBC0, 0xBBC0, gcLV}, {0xBBC1, 0xBBDB, gcLVT}, {0xBBDC, 0xBBDC, gcLV}, {0xBBDD, 0xBBF7, gcLVT},
+	{0xBBF8, 0xBBF8, gcLV}, {0xBBF9, 0xBC13, gcLVT}, {0xBC14, 0xBC14, gcLV}, {0xBC15, 0xBC2F, gcLVT},
+	{0xBC30, 0xBC30, gcLV}, {0xBC31, 0xBC4B, gcLVT}, {0xBC4C, 0xBC4C, gcLV}, {0xBC4D, 0xBC67, gcLVT},
+	{0xBC68, 0xBC68, gcLV}, {0xBC69, 0xBC83, gcLVT}, {0xBC84, 0xBC84, gcLV}, {0xBC85, 0xBC9F, gcLVT},
+	{0xBCA0, 0xBCA0, gcLV}, {0xBCA1, 0xBCBB, gcLVT}, {0xBCBC, 0xBCBC, gcLV}, {0xBCBD, 0xBCD7, gcLVT},
+	{0xBCD8, 0xBCD8, gcLV}, {0xBCD9, 0xBCF3, gcLVT}, {0xBCF4, 0xBCF4, gcLV}, {0xBCF5, 0xBD0F, gcLVT},
+	{0xBD10, 0xBD10, gcLV}, {0xBD11, 0xBD2B, gcLVT}, {0xBD2C, 0xBD2C, gcLV}, {0xBD2D, 0xBD47, gcLVT},
+	{0xBD48, 0xBD48, gcLV}, {0xBD49, 0xBD63, gcLVT}, {0xBD64, 0xBD64, gcLV}, {0xBD65, 0xBD7F, gcLVT},
+	{0xBD80, 0xBD80, gcLV}, {0xBD81, 0xBD9B, gcLVT}, {0xBD9C, 0xBD9C, gcLV}, {0xBD9D, 0xBDB7, gcLVT},
+	{0xBDB8, 0xBDB8, gcLV}, {0xBDB9, 0xBDD3, gcLVT}, {0xBDD4, 0xBDD4, gcLV}, {0xBDD5, 0xBDEF, gcLVT},
+	{0xBDF0, 0xBDF0, gcLV}, {0xBDF1, 0xBE0B, gcLVT}, {0xBE0C, 0xBE0C, gcLV}, {0xBE0D, 0xBE27, gcLVT},
+	{0xBE28, 0xBE28, gcLV}, {0xBE29, 0xBE43, gcLVT}, {0xBE44, 0xBE44, gcLV}, {0xBE45, 0xBE5F, gcLVT},
+	{0xBE60, 0xBE60, gcLV}, {0xBE61, 0xBE7B, gcLVT}, {0xBE7C, 0xBE7C, gcLV}, {0xBE7D, 0xBE97, gcLVT},
+	{0xBE98, 0xBE98, gcLV}, {0xBE99, 0xBEB3, gcLVT}, {0xBEB4, 0xBEB4, gcLV}, {0xBEB5, 0xBECF, gcLVT},
+	{0xBED0, 0xBED0, gcLV}, {0xBED1, 0xBEEB, gcLVT}, {0xBEEC, 0xBEEC, gcLV}, {0xBEED, 0xBF07, gcLVT},
+	{0xBF08, 0xBF08, gcLV}, {0xBF09, 0xBF23, gcLVT}, {0xBF24, 0xBF24, gcLV}, {0xBF25, 0xBF3F, gcLVT},
+	{0xBF40, 0xBF40, gcLV}, {0xBF41, 0xBF5B, gcLVT}, {0xBF5C, 0xBF5C, gcLV}, {0xBF5D, 0xBF77, gcLVT},
+	{0xBF78, 0xBF78, gcLV}, {0xBF79, 0xBF93, gcLVT}, {0xBF94, 0xBF94, gcLV}, {0xBF95, 0xBFAF, gcLVT},
+	{0xBFB0, 0xBFB0, gcLV}, {0xBFB1, 0xBFCB, gcLVT}, {0xBFCC, 0xBFCC, gcLV}, {0xBFCD, 0xBFE7, gcLVT},
+	{0xBFE8, 0xBFE8, gcLV}, {0xBFE9, 0xC003, gcLVT}, {0xC004, 0xC004, gcLV}, {0xC005, 0xC01F, gcLVT},
+	{0xC020, 0xC020, gcLV}, {0xC021, 0xC03B, gcLVT}, {0xC03C, 0xC03C, gcLV}, {0xC03D, 0xC057, gcLVT},
+	{0xC058, 0xC058, gcLV}, {0xC059, 0xC073, gcLVT}, {0xC074, 0xC074, gcLV}, {0xC075, 0xC08F, gcLVT},
+	{0xC090, 0xC090, gcLV}, {0xC091, 0xC0AB, gcLVT}, {0xC0AC, 0xC0AC, gcLV}, {0xC0AD, 0xC0C7, gcLVT},
+	{0xC0C8, 0xC0C8, gcLV}, {0xC0C9, 0xC0E3, gcLVT}, {0xC0E4, 0xC0E4, gcLV}, {0xC0E5, 0xC0FF, gcLVT},
+	{0xC100, 0xC100, gcLV}, {0xC101, 0xC11B, gcLVT}, {0xC11C, 0xC11C, gcLV}, {0xC11D, 0xC137, gcLVT},
+	{0xC138, 0xC138, gcLV}, {0xC139, 0xC153, gcLVT}, {0xC154, 0xC154, gcLV}, {0xC155, 0xC16F, gcLVT},
+	{0xC170, 0xC170, gcLV}, {0xC171, 0xC18B, gcLVT}, {0xC18C, 0xC18C, gcLV}, {0xC18D, 0xC1A7, gcLVT},
+	{0xC1A8, 0xC1A8, gcLV}, {0xC1A9, 0xC1C3, gcLVT}, {0xC1C4, 0xC1C4, gcLV}, {0xC1C5, 0xC1DF, gcLVT},
+	{0xC1E0, 0xC1E0, gcLV}, {0xC1E1, 0xC1FB, gcLVT}, {0xC1FC, 0xC1FC, gcLV}, {0xC1FD, 0xC217, gcLVT},
+	{0xC218, 0xC218, gcLV}, {0xC219, 0xC233, gcLVT}, {0xC234, 0xC234, gcLV}, {0xC235, 0xC24F, gcLVT},
+	{0xC250, 0xC250, gcLV}, {0xC251, 0xC26B, gcLVT}, {0xC26C, 0xC26C, gcLV}, {0xC26D, 0xC287, gcLVT},
+	{0xC288, 0xC288, gcLV}, {0xC289, 0xC2A3, gcLVT}, {0xC2A4, 0xC2A4, gcLV}, {0xC2A5, 0xC2BF, gcLVT},
+	{0xC2C0, 0xC2C0, gcLV}, {0xC2C1, 0xC2DB, gcLVT}, {0xC2DC, 0xC2DC, gcLV}, {0xC2DD, 0xC2F7, gcLVT},
+	{0xC2F8, 0xC2F8, gcLV}, {0xC2F9, 0xC313, gcLVT}, {0xC314, 0xC314, gcLV}, {0xC315, 0xC32F, gcLVT},
+	{0xC330, 0xC330, gcLV}, {0xC331, 0xC34B, gcLVT}, {0xC34C, 0xC34C, gcLV}, {0xC34D, 0xC367, gcLVT},
+	{0xC368, 0xC368, gcLV}, {0xC369, 0xC383, gcLVT}, {0xC384, 0xC384, gcLV}, {0xC385, 0xC39F, gcLVT},
+	{0xC3A0, 0xC3A0, gcLV}, {0xC3A1, 0xC3BB, gcLVT}, {0xC3BC, 0xC3BC, gcLV}, {0xC3BD, 0xC3D7, gcLVT},
+	{0xC3D8, 0xC3D8, gcLV}, {0xC3D9, 0xC3F3, gcLVT}, {0xC3F4, 0xC3F4, gcLV}, {0xC3F5, 0xC40F, gcLVT},
+	{0xC410, 0xC410, gcLV}, {0xC411, 0xC42B, gcLVT}, {0xC42C, 0xC42C, gcLV}, {0xC42D, 0xC447, gcLVT},
+	{0xC448, 0xC448, gcLV}, {0xC449, 0xC463, gcLVT}, {0xC464, 0xC464, gcLV}, {0xC465, 0xC47F, gcLVT},
+	{0xC480, 0xC480, gcLV}, {0xC481, 0xC49B, gcLVT}, {0xC49C, 0xC49C, gcLV}, {0xC49D, 0xC4B7, gcLVT},
+	{0xC4B8, 0xC4B8, gcLV}, {0xC4B9, 0xC4D3, gcLVT}, {0xC4D4, 0xC4D4, gcLV}, {0xC4D5, 0xC4EF, gcLVT},
+	{0xC4F0, 0xC4F0, gcLV}, {0xC4F1, 0xC50B, gcLVT}, {0xC50C, 0xC50C, gcLV}, {0xC50D, 0xC527, gcLVT},
+	{0xC528, 0xC528, gcLV}, {0xC529, 0xC543, gcLVT}, {0xC544, 0xC544, gcLV}, {0xC545, 0xC55F, gcLVT},
+	{0xC560, 0xC560, gcLV}, {0xC561, 0xC57B, gcLVT}, {0xC57C, 0xC57C, gcLV}, {0xC57D, 0xC597, gcLVT},
+	{0xC598, 0xC598, gcLV}, {0xC599, 0xC5B3, gcLVT}, {0xC5B4, 0xC5B4, gcLV}, {0xC5B5, 0xC5CF, gcLVT},
+	{0xC5D0, 0xC5D0, gcLV}, {0xC5D1, 0xC5EB, gcLVT}, {0xC5EC, 0xC5EC, gcLV}, {0xC5ED, 0xC607, gcLVT},
+	{0xC608, 0xC608, gcLV}, {0xC609, 0xC623, gcLVT}, {0xC624, 0xC624, gcLV}, {0xC625, 0xC63F, gcLVT},
+	{0xC640, 0xC640, gcLV}, {0xC641, 0xC65B, gcLVT}, {0xC65C, 0xC65C, gcLV}, {0xC65D, 0xC677, gcLVT},
+	{0xC678, 0xC678, gcLV}, {0xC679, 0xC693, gcLVT}, {0xC694, 0xC694, gcLV}, {0xC695, 0xC6AF, gcLVT},
+	{0xC6B0, 0xC6B0, gcLV}, {0xC6B1, 0xC6CB, gcLVT}, {0xC6CC, 0xC6CC, gcLV}, {0xC6CD, 0xC6E7, gcLVT},
+	{0xC6E8, 0xC6E8, gcLV}, {0xC6E9, 0xC703, gcLVT}, {0xC704, 0xC704, gcLV}, {0xC705, 0xC71F, gcLVT},
+	{0xC720, 0xC720, gcLV}, {0xC721, 0xC73B, gcLVT}, {0xC73C, 0xC73C, gcLV}, {0xC73D, 0xC757, gcLVT},
+	{0xC758, 0xC758, gcLV}, {0xC759, 0xC773, gcLVT}, {0xC774, 0xC774, gcLV}, {0xC775, 0xC78F, gcLVT},
+	{0xC790, 0xC790, gcLV}, {0xC791, 0xC7AB, gcLVT}, {0xC7AC, 0xC7AC, gcLV}, {0xC7AD, 0xC7C7, gcLVT},
+	{0xC7C8, 0xC7C8, gcLV}, {0xC7C9, 0xC7E3, gcLVT}, {0xC7E4, 0xC7E4, gcLV}, {0xC7E5, 0xC7FF, gcLVT},
+	{0xC800, 0xC800, gcLV}, {0xC801, 0xC81B, gcLVT}, {0xC81C, 0xC81C, gcLV}, {0xC81D, 0xC837, gcLVT},
+	{0xC838, 0xC838, gcLV}, {0xC839, 0xC853, gcLVT}, {0xC854, 0xC854, gcLV}, {0xC855, 0xC86F, gcLVT},
+	{0xC870, 0xC870, gcLV}, {0xC871, 0xC88B, gcLVT}, {0xC88C, 0xC88C, gcLV}, {0xC88D, 0xC8A7, gcLVT},
+	{0xC8A8, 0xC8A8, gcLV}, {0xC8A9, 0xC8C3, gcLVT}, {0xC8C4, 0xC8C4, gcLV}, {0xC8C5, 0xC8DF, gcLVT},
+	{0xC8E0, 0xC8E0, gcLV}, {0xC8E1, 0xC8FB, gcLVT}, {0xC8FC, 0xC8FC, gcLV}, {0xC8FD, 0xC917, gcLVT},
+	{0xC918, 0xC918, gcLV}, {0xC919, 0xC933, gcLVT}, {0xC934, 0xC934, gcLV}, {0xC935, 0xC94F, gcLVT},
+	{0xC950, 0xC950, gcLV}, {0xC951, 0xC96B, gcLVT}, {0xC96C, 0xC96C, gcLV}, {0xC96D, 0xC987, gcLVT},
+	{0xC988, 0xC988, gcLV}, {0xC989, 0xC9A3, gcLVT}, {0xC9A4, 0xC9A4, gcLV}, {0xC9A5, 0xC9BF, gcLVT},
+	{0xC9C0, 0xC9C0, gcLV}, {0xC9C1, 0xC9DB, gcLVT}, {0xC9DC, 0xC9DC, gcLV}, {0xC9DD, 0xC9F7, gcLVT},
+	{0xC9F8, 0xC9F8, gcLV}, {0xC9F9, 0xCA13, gcLVT}, {0xCA14, 0xCA14, gcLV}, {0xCA15, 0xCA2F, gcLVT},
+	{0xCA30, 0xCA30, gcLV}, {0xCA31, 0xCA4B, gcLVT}, {0xCA4C, 0xCA4C, gcLV}, {0xCA4D, 0xCA67, gcLVT},
+	{0xCA68, 0xCA68, gcLV}, {0xCA69, 0xCA83, gcLVT}, {0xCA84, 0xCA84, gcLV}, {0xCA85, 0xCA9F, gcLVT},
+	{0xCAA0, 0xCAA0, gcLV}, {0xCAA1, 0xCABB, gcLVT}, {0xCABC, 0xCABC, gcLV}, {0xCABD, 0xCAD7, gcLVT},
+	{0xCAD8, 0xCAD8, gcLV}, {0xCAD9, 0xCAF3, gcLVT}, {0xCAF4, 0xCAF4, gcLV}, {0xCAF5, 0xCB0F, gcLVT},
+	{0xCB10, 0xCB10, gcLV}, {0xCB11, 0xCB2B, gcLVT}, {0xCB2C, 0xCB2C, gcLV}, {0xCB2D, 0xCB47, gcLVT},
+	{0xCB48, 0xCB48, gcLV}, {0xCB49, 0xCB63, gcLVT}, {0xCB64, 0xCB64, gcLV}, {0xCB65, 0xCB7F, gcLVT},
+	{0xCB80, 0xCB80, gcLV}, {0xCB81, 0xCB9B, gcLVT}, {0xCB9C, 0xCB9C, gcLV}, {0xCB9D, 0xCBB7, gcLVT},
+	{0xCBB8, 0xCBB8, gcLV}, {0xCBB9, 0xCBD3, gcLVT}, {0xCBD4, 0xCBD4, gcLV}, {0xCBD5, 0xCBEF, gcLVT},
+	{0xCBF0, 0xCBF0, gcLV}, {0xCBF1, 0xCC0B, gcLVT}, {0xCC0C, 0xCC0C, gcLV}, {0xCC0D, 0xCC27, gcLVT},
+	{0xCC28, 0xCC28, gcLV}, {0xCC29, 0xCC43, gcLVT}, {0xCC44, 0xCC44, gcLV}, {0xCC45, 0xCC5F, gcLVT},
+	{0xCC60, 0xCC60, gcLV}, {0xCC61, 0xCC7B, gcLVT}, {0xCC7C, 0xCC7C, gcLV}, {0xCC7D, 0xCC97, gcLVT},
+	{0xCC98, 0xCC98, gcLV}, {0xCC99, 0xCCB3, gcLVT}, {0xCCB4, 0xCCB4, gcLV}, {0xCCB5, 0xCCCF, gcLVT},
+	{0xCCD0, 0xCCD0, gcLV}, {0xCCD1, 0xCCEB, gcLVT}, {0xCCEC, 0xCCEC, gcLV}, {0xCCED, 0xCD07, gcLVT},
+	{0xCD08, 0xCD08, gcLV}, {0xCD09, 0xCD23, gcLVT}, {0xCD24, 0xCD24, gcLV}, {0xCD25, 0xCD3F, gcLVT},
+	{0xCD40, 0xCD40, gcLV}, {0xCD41, 0xCD5B, gcLVT}, {0xCD5C, 0xCD5C, gcLV}, {0xCD5D, 0xCD77, gcLVT},
+	{0xCD78, 0xCD78, gcLV}, {0xCD79, 0xCD93, gcLVT}, {0xCD94, 0xCD94, gcLV}, {0xCD95, 0xCDAF, gcLVT},
+	{0xCDB0, 0xCDB0, gcLV}, {0xCDB1, 0xCDCB, gcLVT}, {0xCDCC, 0xCDCC, gcLV}, {0xCDCD, 0xCDE7, gcLVT},
+	{0xCDE8, 0xCDE8, gcLV}, {0xCDE9, 0xCE03, gcLVT}, {0xCE04, 0xCE04, gcLV}, {0xCE05, 0xCE1F, gcLVT},
+	{0xCE20, 0xCE20, gcLV}, {0xCE21, 0xCE3B, gcLVT}, {0xCE3C, 0xCE3C, gcLV}, {0xCE3D, 0xCE57, gcLVT},
+	{0xCE58, 0xCE58, gcLV}, {0xCE59, 0xCE73, gcLVT}, {0xCE74, 0xCE74, gcLV}, {0xCE75, 0xCE8F, gcLVT},
+	{0xCE90, 0xCE90, gcLV}, {0xCE91, 0xCEAB, gcLVT}, {0xCEAC, 0xCEAC, gcLV}, {0xCEAD, 0xCEC7, gcLVT},
+	{0xCEC8, 0xCEC8, gcLV}, {0xCEC9, 0xCEE3, gcLVT}, {0xCEE4, 0xCEE4, gcLV}, {0xCEE5, 0xCEFF, gcLVT},
+	{0xCF00, 0xCF00, gcLV}, {0xCF01, 0xCF1B, gcLVT}, {0xCF1C, 0xCF1C, gcLV}, {0xCF1D, 0xCF37, gcLVT},
+	{0xCF38, 0xCF38, gcLV}, {0xCF39, 0xCF53, gcLVT}, {0xCF54, 0xCF54, gcLV}, {0xCF55, 0xCF6F, gcLVT},
+	{0xCF70, 0xCF70, gcLV}, {0xCF71, 0xCF8B, gcLVT}, {0xCF8C, 0xCF8C, gcLV}, {0xCF8D, 0xCFA7, gcLVT},
+	{0xCFA8, 0xCFA8, gcLV}, {0xCFA9, 0xCFC3, gcLVT}, {0xCFC4, 0xCFC4, gcLV}, {0xCFC5, 0xCFDF, gcLVT},
+	{0xCFE0, 0xCFE0, gcLV}, {0xCFE1, 0xCFFB, gcLVT}, {0xCFFC, 0xCFFC, gcLV}, {0xCFFD, 0xD017, gcLVT},
+	{0xD018, 0xD018, gcLV}, {0xD019, 0xD033, gcLVT}, {0xD034, 0xD034, gcLV}, {0xD035, 0xD04F, gcLVT},
+	{0xD050, 0xD050, gcLV}, {0xD051, 0xD06B, gcLVT}, {0xD06C, 0xD06C, gcLV}, {0xD06D, 0xD087, gcLVT},
+	{0xD088, 0xD088, gcLV}, {0xD089, 0xD0A3, gcLVT}, {0xD0A4, 0xD0A4, gcLV}, {0xD0A5, 0xD0BF, gcLVT},
+	{0xD0C0, 0xD0C0, gcLV}, {0xD0C1, 0xD0DB, gcLVT}, {0xD0DC, 0xD0DC, gcLV}, {0xD0DD, 0xD0F7, gcLVT},
+	{0xD0F8, 0xD0F8, gcLV}, {0xD0F9, 0xD113, gcLVT}, {0xD114, 0xD114, gcLV}, {0xD115, 0xD12F, gcLVT},
+	{0xD130, 0xD130, gcLV}, {0xD131, 0xD14B, gcLVT}, {0xD14C, 0xD14C, gcLV}, {0xD14D, 0xD167, gcLVT},
+	{0xD168, 0xD168, gcLV}, {0xD169, 0xD183, gcLVT}, {0xD184, 0xD184, gcLV}, {0xD185, 0xD19F, gcLVT},
+	{0xD1A0, 0xD1A0, gcLV}, {0xD1A1, 0xD1BB, gcLVT}, {0xD1BC, 0xD1BC, gcLV}, {0xD1BD, 0xD1D7, gcLVT},
+	{0xD1D8, 0xD1D8, gcLV}, {0xD1D9, 0xD1F3, gcLVT}, {0xD1F4, 0xD1F4, gcLV}, {0xD1F5, 0xD20F, gcLVT},
+	{0xD210, 0xD210, gcLV}, {0xD211, 0xD22B, gcLVT}, {0xD22C, 0xD22C, gcLV}, {0xD22D, 0xD247, gcLVT},
+	{0xD248, 0xD248, gcLV}, {0xD249, 0xD263, gcLVT}, {0xD264, 0xD264, gcLV}, {0xD265, 0xD27F, gcLVT},
+	{0xD280, 0xD280, gcLV}, {0xD281, 0xD29B, gcLVT}, {0xD29C, 0xD29C, gcLV}, {0xD29D, 0xD2B7, gcLVT},
+	{0xD2B8, 0xD2B8, gcLV}, {0xD2B9, 0xD2D3, gcLVT}, {0xD2D4, 0xD2D4, gcLV}, {0xD2D5, 0xD2EF, gcLVT},
+	{0xD2F0, 0xD2F0, gcLV}, {0xD2F1, 0xD30B, gcLVT}, {0xD30C, 0xD30C, gcLV}, {0xD30D, 0xD327, gcLVT},
+	{0xD328, 0xD328, gcLV}, {0xD329, 0xD343, gcLVT}, {0xD344, 0xD344, gcLV}, {0xD345, 0xD35F, gcLVT},
+	{0xD360, 0xD360, gcLV}, {0xD361, 0xD37B, gcLVT}, {0xD37C, 0xD37C, gcLV}, {0xD37D, 0xD397, gcLVT},
+	{0xD398, 0xD398, gcLV}, {0xD399, 0xD3B3, gcLVT}, {0xD3B4, 0xD3B4, gcLV}, {0xD3B5, 0xD3CF, gcLVT},
+	{0xD3D0, 0xD3D0, gcLV}, {0xD3D1, 0xD3EB, gcLVT}, {0xD3EC, 0xD3EC, gcLV}, {0xD3ED, 0xD407, gcLVT},
+	{0xD408, 0xD408, gcLV}, {0xD409, 0xD423, gcLVT}, {0xD424, 0xD424, gcLV}, {0xD425, 0xD43F, gcLVT},
+	{0xD440, 0xD440, gcLV}, {0xD441, 0xD45B, gcLVT}, {0xD45C, 0xD45C, gcLV}, {0xD45D, 0xD477, gcLVT},
+	{0xD478, 0xD478, gcLV}, {0xD479, 0xD493, gcLVT}, {0xD494, 0xD494, gcLV}, {0xD495, 0xD4AF, gcLVT},
+	{0xD4B0, 0xD4B0, gcLV}, {0xD4B1, 0xD4CB, gcLVT}, {0xD4CC, 0xD4CC, gcLV}, {0xD4CD, 0xD4E7, gcLVT},
+	{0xD4E8, 0xD4E8, gcLV}, {0xD4E9, 0xD503, gcLVT}, {0xD504, 0xD504, gcLV}, {0xD505, 0xD51F, gcLVT},
+	{0xD520, 0xD520, gcLV}, {0xD521, 0xD53B, gcLVT}, {0xD53C, 0xD53C, gcLV}, {0xD53D, 0xD557, gcLVT},
+	{0xD558, 0xD558, gcLV}, {0xD559, 0xD573, gcLVT}, {0xD574, 0xD574, gcLV}, {0xD575, 0xD58F, gcLVT},
+	{0xD590, 0xD590, gcLV}, {0xD591, 0xD5AB, gcLVT}, {0xD5AC, 0xD5AC, gcLV}, {0xD5AD, 0xD5C7, gcLVT},
+	{0xD5C8, 0xD5C8, gcLV}, {0xD5C9, 0xD5E3, gcLVT}, {0xD5E4, 0xD5E4, gcLV}, {0xD5E5, 0xD5FF, gcLVT},
+	{0xD600, 0xD600, gcLV}, {0xD601, 0xD61B, gcLVT}, {0xD61C, 0xD61C, gcLV}, {0xD61D, 0xD637, gcLVT},
+	{0xD638, 0xD638, gcLV}, {0xD639, 0xD653, gcLVT}, {0xD654, 0xD654, gcLV}, {0xD655, 0xD66F, gcLVT},
+	{0xD670, 0xD670, gcLV}, {0xD671, 0xD68B, gcLVT}, {0xD68C, 0xD68C, gcLV}, {0xD68D, 0xD6A7, gcLVT},
+	{0xD6A8, 0xD6A8, gcLV}, {0xD6A9, 0xD6C3, gcLVT}, {0xD6C4, 0xD6C4, gcLV}, {0xD6C5, 0xD6DF, gcLVT},
+	{0xD6E0, 0xD6E0, gcLV}, {0xD6E1, 0xD6FB, gcLVT}, {0xD6FC, 0xD6FC, gcLV}, {0xD6FD, 0xD717, gcLVT},
+	{0xD718, 0xD718, gcLV}, {0xD719, 0xD733, gcLVT}, {0xD734, 0xD734, gcLV}, {0xD735, 0xD74F, gcLVT},
+	{0xD750, 0xD750, gcLV}, {0xD751, 0xD76B, gcLVT}, {0xD76C, 0xD76C, gcLV}, {0xD76D, 0xD787, gcLVT},
+	{0xD788, 0xD788, gcLV}, {0xD789, 0xD7A3, gcLVT}, {0xD7B0, 0xD7C6, gcV}, {0xD7CB, 0xD7FB, gcT},
+	{0xFB1E, 0xFB1E, gcExtend}, {0xFE00, 0xFE0F, gcExtend}, {0xFE20, 0xFE2F, gcExtend}, {0xFEFF, 0xFEFF, gcControl},
+	{0xFF9E, 0xFF9F, gcExtend}, {0xFFF0, 0xFFFB, gcControl}, {0x101FD, 0x101FD, gcExtend}, {0x102E0, 0x102E0, gcExtend},
+	{0x10376, 0x1037A, gcExtend}, {0x10A01, 0x10A03, gcExtend}, {0x10A05, 0x10A06, gcExtend}, {0x10A0C, 0x10A0F, gcExtend},
+	{0x10A38, 0x10A3A, gcExtend}, {0x10A3F, 0x10A3F, gcExtend}, {0x10AE5, 0x10AE6, gcExtend}, {0x10D24, 0x10D27, gcExtend},
+	{0x10D69, 0x10D6D, gcExtend}, {0x10EAB, 0x10EAC, gcExtend}, {0x10EFC, 0x10EFF, gcExtend}, {0x10F46, 0x10F50, gcExtend},
+	{0x10F82, 0x10F85, gcExtend}, {0x11000, 0x11000, gcSpacingMark}, {0x11001, 0x11001, gcExtend}, {0x11002, 0x11002, gcSpacingMark},
+	{0x11038, 0x11046, gcExtend}, {0x11070, 0x11070, gcExtend}, {0x11073, 0x11074, gcExtend}, {0x1107F, 0x11081, gcExtend},
+	{0x11082, 0x11082, gcSpacingMark}, {0x110B0, 0x110B2, gcSpacingMark}, {0x110B3, 0x110B6, gcExtend}, {0x110B7, 0x110B8, gcSpacingMark},
+	{0x110B9, 0x110BA, gcExtend}, {0x110BD, 0x110BD, gcPrepend}, {0x110C2, 0x110C2, gcExtend}, {0x110CD, 0x110CD, gcPrepend},
+	{0x11100, 0x11102, gcExtend}, {0x11127, 0x1112B, gcExtend}, {0x1112C, 0x1112C, gcSpacingMark}, {0x1112D, 0x11134, gcExtend},
+	{0x11145, 0x11146, gcSpacingMark}, {0x11173, 0x11173, gcExtend}, {0x11180, 0x11181, gcExtend}, {0x11182, 0x11182, gcSpacingMark},
+	{0x111B3, 0x111B5, gcSpacingMark}, {0x111B6, 0x111BE, gcExtend}, {0x111BF, 0x111BF, gcSpacingMark}, {0x111C0, 0x111C0, gcExtend},
+	{0x111C2, 0x111C3, gcPrepend}, {0x111C9, 0x111CC, gcExtend}, {0x111CE, 0x111CE, gcSpacingMark}, {0x111CF, 0x111CF, gcExtend},
+	{0x1122C, 0x1122E, gcSpacingMark}, {0x1122F, 0x11231, gcExtend}, {0x11232, 0x11233, gcSpacingMark}, {0x11234, 0x11237, gcExtend},
+	{0x1123E, 0x1123E, gcExtend}, {0x11241, 0x11241, gcExtend}, {0x112DF, 0x112DF, gcExtend}, {0x112E0, 0x112E2, gcSpacingMark},
+	{0x112E3, 0x112EA, gcExtend}, {0x11300, 0x11301, gcExtend}, {0x11302, 0x11303, gcSpacingMark}, {0x1133B, 0x1133C, gcExtend},
+	{0x1133E, 0x1133E, gcExtend}, {0x1133F, 0x1133F, gcSpacingMark}, {0x11340, 0x11340, gcExtend}, {0x11341, 0x11344, gcSpacingMark},
+	{0x11347, 0x11348, gcSpacingMark}, {0x1134B, 0x1134C, gcSpacingMark}, {0x1134D, 0x1134D, gcExtend}, {0x11357, 0x11357, gcExtend},
+	{0x11362, 0x11363, gcSpacingMark}, {0x11366, 0x1136C, gcExtend}, {0x11370, 0x11374, gcExtend}, {0x113B8, 0x113B8, gcExtend},
+	{0x113B9, 0x113BA, gcSpacingMark}, {0x113BB, 0x113C0, gcExtend}, {0x113C2, 0x113C2, gcExtend}, {0x113C5, 0x113C5, gcExtend},
+	{0x113C7, 0x113C9, gcExtend}, {0x113CA, 0x113CA, gcSpacingMark}, {0x113CC, 0x113CD, gcSpacingMark}, {0x113CE, 0x113D0, gcExtend},
+	{0x113D1, 0x113D1, gcPrepend}, {0x113D2, 0x113D2, gcExtend}, {0x113E1, 0x113E2, gcExtend}, {0x11435, 0x11437, gcSpacingMark},
+	{0x11438, 0x1143F, gcExtend}, {0x11440, 0x11441, gcSpacingMark}, {0x11442, 0x11444, gcExtend}, {0x11445, 0x11445, gcSpacingMark},
+	{0x11446, 0x11446, gcExtend}, {0x1145E, 0x1145E, gcExtend}, {0x114B0, 0x114B0, gcExtend}, {0x114B1, 0x114B2, gcSpacingMark},
+	{0x114B3, 0x114B8, gcExtend}, {0x114B9, 0x114B9, gcSpacingMark}, {0x114BA, 0x114BA, gcExtend}, {0x114BB, 0x114BC, gcSpacingMark},
+	{0x114BD, 0x114BD, gcExtend}, {0x114BE, 0x114BE, gcSpacingMark}, {0x114BF, 0x114C0, gcExtend}, {0x114C1, 0x114C1, gcSpacingMark},
+	{0x114C2, 0x114C3, gcExtend}, {0x115AF, 0x115AF, gcExtend}, {0x115B0, 0x115B1, gcSpacingMark}, {0x115B2, 0x115B5, gcExtend},
+	{0x115B8, 0x115BB, gcSpacingMark}, {0x115BC, 0x115BD, gcExtend}, {0x115BE, 0x115BE, gcSpacingMark}, {0x115BF, 0x115C0, gcExtend},
+	{0x115DC, 0x115DD, gcExtend}, {0x11630, 0x11632, gcSpacingMark}, {0x11633, 0x1163A, gcExtend}, {0x1163B, 0x1163C, gcSpacingMark},
+	{0x1163D, 0x1163D, gcExtend}, {0x1163E, 0x1163E, gcSpacingMark}, {0x1163F, 0x11640, gcExtend}, {0x116AB, 0x116AB, gcExtend},
+	{0x116AC, 0x116AC, gcSpacingMark}, {0x116AD, 0x116AD, gcExtend}, {0x116AE, 0x116AF, gcSpacingMark}, {0x116B0, 0x116B7, gcExtend},
+	{0x1171D, 0x1171D, gcExtend}, {0x1171E, 0x1171E, gcSpacingMark}, {0x1171F, 0x1171F, gcExtend}, {0x11722, 0x11725, gcExtend},
+	{0x11726, 0x11726, gcSpacingMark}, {0x11727, 0x1172B, gcExtend}, {0x1182C, 0x1182E, gcSpacingMark}, {0x1182F, 0x11837, gcExtend},
+	{0x11838, 0x11838, gcSpacingMark}, {0x11839, 0x1183A, gcExtend}, {0x11930, 0x11930, gcExtend}, {0x11931, 0x11935, gcSpacingMark},
+	{0x11937, 0x11938, gcSpacingMark}, {0x1193B, 0x1193E, gcExtend}, {0x1193F, 0x1193F, gcPrepend}, {0x11940, 0x11940, gcSpacingMark},
+	{0x11941, 0x11941, gcPrepend}, {0x11942, 0x11942, gcSpacingMark}, {0x11943, 0x11943, gcExtend}, {0x119D1, 0x119D3, gcSpacingMark},
+	{0x119D4, 0x119D7, gcExtend}, {0x119DA, 0x119DB, gcExtend}, {0x119DC, 0x119DF, gcSpacingMark}, {0x119E0, 0x119E0, gcExtend},
+	{0x119E4, 0x119E4, gcSpacingMark}, {0x11A01, 0x11A0A, gcExtend}, {0x11A33, 0x11A38, gcExtend}, {0x11A39, 0x11A39, gcSpacingMark},
+	{0x11A3A, 0x11A3A, gcPrepend}, {0x11A3B, 0x11A3E, gcExtend}, {0x11A47, 0x11A47, gcExtend}, {0x11A51, 0x11A56, gcExtend},
+	{0x11A57, 0x11A58, gcSpacingMark}, {0x11A59, 0x11A5B, gcExtend}, {0x11A84, 0x11A89, gcPrepend}, {0x11A8A, 0x11A96, gcExtend},
+	{0x11A97, 0x11A97, gcSpacingMark}, {0x11A98, 0x11A99, gcExtend}, {0x11C2F, 0x11C2F, gcSpacingMark}, {0x11C30, 0x11C36, gcExtend},
+	{0x11C38, 0x11C3D, gcExtend}, {0x11C3E, 0x11C3E, gcSpacingMark}, {0x11C3F, 0x11C3F, gcExtend}, {0x11C92, 0x11CA7, gcExtend},
+	{0x11CA9, 0x11CA9, gcSpacingMark}, {0x11CAA, 0x11CB0, gcExtend}, {0x11CB1, 0x11CB1, gcSpacingMark}, {0x11CB2, 0x11CB3, gcExtend},
+	{0x11CB4, 0x11CB4, gcSpacingMark}, {0x11CB5, 0x11CB6, gcExtend}, {0x11D31, 0x11D36, gcExtend}, {0x11D3A, 0x11D3A, gcExtend},
+	{0x11D3C, 0x11D3D, gcExtend}, {0x11D3F, 0x11D45, gcExtend}, {0x11D46, 0x11D46, gcPrepend}, {0x11D47, 0x11D47, gcExtend},
+	{0x11D8A, 0x11D8E, gcSpacingMark}, {0x11D90, 0x11D91, gcExtend}, {0x11D93, 0x11D94, gcSpacingMark}, {0x11D95, 0x11D95, gcExtend},
+	{0x11D96, 0x11D96, gcSpacingMark}, {0x11D97, 0x11D97, gcExtend}, {0x11EF3, 0x11EF4, gcExtend}, {0x11EF5, 0x11EF6, gcSpacingMark},
+	{0x11F00, 0x11F01, gcExtend}, {0x11F02, 0x11F02, gcPrepend}, {0x11F03, 0x11F03, gcSpacingMark}, {0x11F34, 0x11F35, gcSpacingMark},
+	{0x11F36, 0x11F3A, gcExtend}, {0x11F3E, 0x11F3F, gcSpacingMark}, {0x11F40, 0x11F42, gcExtend}, {0x11F5A, 0x11F5A, gcExtend},
+	{0x13430, 0x1343F, gcControl}, {0x13440, 0x13440, gcExtend}, {0x13447, 0x13455, gcExtend}, {0x1611E, 0x16129, gcExtend},
+	{0x1612A, 0x1612C, gcSpacingMark}, {0x1612D, 0x1612F, gcExtend}, {0x16AF0, 0x16AF4, gcExtend}, {0x16B30, 0x16B36, gcExtend},
+	{0x16D63, 0x16D63, gcV}, {0x16D67, 0x16D6A, gcV}, {0x16F4F, 0x16F4F, gcExtend}, {0x16F51, 0x16F87, gcSpacingMark},
+	{0x16F8F, 0x16F92, gcExtend}, {0x16FE4, 0x16FE4, gcExtend}, {0x16FF0, 0x16FF1, gcExtend}, {0x1BC9D, 0x1BC9E, gcExtend},
+	{0x1BCA0, 0x1BCA3, gcControl}, {0x1CF00, 0x1CF2D, gcExtend}, {0x1CF30, 0x1CF46, gcExtend}, {0x1D165, 0x1D169, gcExtend},
+	{0x1D16D, 0x1D172, gcExtend}, {0x1D173, 0x1D17A, gcControl}, {0x1D17B, 0x1D182, gcExtend}, {0x1D185, 0x1D18B, gcExtend},
+	{0x1D1AA, 0x1D1AD, gcExtend}, {0x1D242, 0x1D244, gcExtend}, {0x1DA00, 0x1DA36, gcExtend}, {0x1DA3B, 0x1DA6C, gcExtend},
+	{0x1DA75, 0x1DA75, gcExtend}, {0x1DA84, 0x1DA84, gcExtend}, {0x1DA9B, 0x1DA9F, gcExtend}, {0x1DAA1, 0x1DAAF, gcExtend},
+	{0x1E000, 0x1E006, gcExtend}, {0x1E008, 0x1E018, gcExtend}, {0x1E01B, 0x1E021, gcExtend}, {0x1E023, 0x1E024, gcExtend},
+	{0x1E026, 0x1E02A, gcExtend}, {0x1E08F, 0x1E08F, gcExtend}, {0x1E130, 0x1E136, gcExtend}, {0x1E2AE, 0x1E2AE, gcExtend},
+	{0x1E2EC, 0x1E2EF, gcExtend}, {0x1E4EC, 0x1E4EF, gcExtend}, {0x1E5EE, 0x1E5EF, gcExtend}, {0x1E8D0, 0x1E8D6, gcExtend},
+	{0x1E944, 0x1E94A, gcExtend}, {0x1F000, 0x1F0FF, gcExtPict}, {0x1F10D, 0x1F10F, gcExtPict}, {0x1F12F, 0x1F12F, gcExtPict},
+	{0x1F16C, 0x1F171, gcExtPict}, {0x1F17E, 0x1F17F, gcExtPict}, {0x1F18E, 0x1F18E, gcExtPict}, {0x1F191, 0x1F19A, gcExtPict},
+	{0x1F1AD, 0x1F1E5, gcExtPict}, {0x1F1E6, 0x1F1FF, gcRegionalIndicator}, {0x1F201, 0x1F20F, gcExtPict}, {0x1F21A, 0x1F21A, gcExtPict},
+	{0x1F22F, 0x1F22F, gcExtPict}, {0x1F232, 0x1F23A, gcExtPict}, {0x1F23C, 0x1F23F, gcExtPict}, {0x1F249, 0x1F3FA, gcExtPict},
+	{0x1F3FB, 0x1F3FF, gcExtend}, {0x1F400, 0x1F53D, gcExtPict}, {0x1F546, 0x1F64F, gcExtPict}, {0x1F680, 0x1F6FF, gcExtPict},
+	{0x1F774, 0x1F77F, gcExtPict}, {0x1F7D5, 0x1F7FF, gcExtPict}, {0x1F80C, 0x1F80F, gcExtPict}, {0x1F848, 0x1F84F, gcExtPict},
+	{0x1F85A, 0x1F85F, gcExtPict}, {0x1F888, 0x1F88F, gcExtPict}, {0x1F8AE, 0x1F8FF, gcExtPict}, {0x1F90C, 0x1F93A, gcExtPict},
+	{0x1F93C, 0x1F945, gcExtPict}, {0x1F947, 0x1FAFF, gcExtPict}, {0x1FC00, 0x1FFFD, gcExtPict}, {0xE0000, 0xE001F, gcControl},
+	{0xE0020, 0xE007F, gcExtend}, {0xE0080, 0xE00FF, gcControl}, {0xE0100, 0xE01EF, gcExtend}, {0xE01F0, 0xE0FFF, gcControl},
+}
+
+// incbExtendTable Indic_Conjunct_Break=Extend的字符区间（含InCB=Linker）
+var incbExtendTable = [][2]rune{
+	{0x300, 0x36F}, {0x483, 0x489}, {0x591, 0x5BD}, {0x5BF, 0x5BF}, {0x5C1, 0x5C2}, {0x5C4, 0x5C5},
+	{0x5C7, 0x5C7}, {0x610, 0x61A}, {0x64B, 0x65F}, {0x670, 0x670}, {0x6D6, 0x6DC}, {0x6DF, 0x6E4},
+	{0x6E7, 0x6E8}, {0x6EA, 0x6ED}, {0x711, 0x711}, {0x730, 0x74A}, {0x7A6, 0x7B0}, {0x7EB, 0x7F3},
+	{0x7FD, 0x7FD}, {0x816, 0x819}, {0x81B, 0x823}, {0x825, 0x827}, {0x829, 0x82D}, {0x859, 0x85B},
+	{0x897, 0x89F}, {0x8CA, 0x8E1}, {0x8E3, 0x902}, {0x93A, 0x93A}, {0x93C, 0x93C}, {0x941, 0x948},
+	{0x951, 0x957}, {0x962, 0x963}, {0x981, 0x981}, {0x9BC, 0x9BC}, {0x9BE, 0x9BE}, {0x9C1, 0x9C4},
+	{0x9D7, 0x9D7}, {0x9E2, 0x9E3}, {0x9FE, 0x9FE}, {0xA01, 0xA02}, {0xA3C, 0xA3C}, {0xA41, 0xA42},
+	{0xA47, 0xA48}, {0xA4B, 0xA4D}, {0xA51, 0xA51}, {0xA70, 0xA71}, {0xA75, 0xA75}, {0xA81, 0xA82},
+	{0xABC, 0xABC}, {0xAC1, 0xAC5}, {0xAC7, 0xAC8}, {0xAE2, 0xAE3}, {0xAFA, 0xAFF}, {0xB01, 0xB01},
+	{0xB3C, 0xB3C}, {0xB3E, 0xB3F}, {0xB41, 0xB44}, {0xB55, 0xB57}, {0xB62, 0xB63}, {0xB82, 0xB82},
+	{0xBBE, 0xBBE}, {0xBC0, 0xBC0}, {0xBCD, 0xBCD}, {0xBD7, 0xBD7}, {0xC00, 0xC00}, {0xC04, 0xC04},
+	{0xC3C, 0xC3C}, {0xC3E, 0xC40}, {0xC46, 0xC48}, {0xC4A, 0xC4C}, {0xC55, 0xC56}, {0xC62, 0xC63},
+	{0xC81, 0xC81}, {0xCBC, 0xCBC}, {0xCBF, 0xCC0}, {0xCC2, 0xCC2}, {0xCC6, 0xCC8}, {0xCCA, 0xCCD},
+	{0xCD5, 0xCD6}, {0xCE2, 0xCE3}, {0xD00, 0xD01}, {0xD3B, 0xD3C}, {0xD3E, 0xD3E}, {0xD41, 0xD44},
+	{0xD57, 0xD57}, {0xD62, 0xD63}, {0xD81, 0xD81}, {0xDCA, 0xDCA}, {0xDCF, 0xDCF}, {0xDD2, 0xDD4},
+	{0xDD6, 0xDD6}, {0xDDF, 0xDDF}, {0xE31, 0xE31}, {0xE34, 0xE3A}, {0xE47, 0xE4E}, {0xEB1, 0xEB1},
+	{0xEB4, 0xEBC}, {0xEC8, 0xECE}, {0xF18, 0xF19}, {0xF35, 0xF35}, {0xF37, 0xF37}, {0xF39, 0xF39},
+	{0xF71, 0xF7E}, {0xF80, 0xF84}, {0xF86, 0xF87}, {0xF8D, 0xF97}, {0xF99, 0xFBC}, {0xFC6, 0xFC6},
+	{0x102D, 0x1030}, {0x1032, 0x1037}, {0x1039, 0x103A}, {0x103D, 0x103E}, {0x1058, 0x1059}, {0x105E, 0x1060},
+	{0x1071, 0x1074}, {0x1082, 0x1082}, {0x1085, 0x1086}, {0x108D, 0x108D}, {0x109D, 0x109D}, {0x135D, 0x135F},
+	{0x1712, 0x1715}, {0x1732, 0x1734}, {0x1752, 0x1753}, {0x1772, 0x1773}, {0x17B4, 0x17B5}, {0x17B7, 0x17BD},
+	{0x17C6, 0x17C6}, {0x17C9, 0x17D3}, {0x17DD, 0x17DD}, {0x180B, 0x180D}, {0x180F, 0x180F}, {0x1885, 0x1886},
+	{0x18A9, 0x18A9}, {0x1920, 0x1922}, {0x1927, 0x1928}, {0x1932, 0x1932}, {0x1939, 0x193B}, {0x1A17, 0x1A18},
+	{0x1A1B, 0x1A1B}, {0x1A56, 0x1A56}, {0x1A58, 0x1A5E}, {0x1A60, 0x1A60}, {0x1A62, 0x1A62}, {0x1A65, 0x1A6C},
+	{0x1A73, 0x1A7C}, {0x1A7F, 0x1A7F}, {0x1AB0, 0x1ACE}, {0x1B00, 0x1B03}, {0x1B34, 0x1B3D}, {0x1B42, 0x1B44},
+	{0x1B6B, 0x1B73}, {0x1B80, 0x1B81}, {0x1BA2, 0x1BA5}, {0x1BA8, 0x1BAD}, {0x1BE6, 0x1BE6}, {0x1BE8, 0x1BE9},
+	{0x1BED, 0x1BED}, {0x1BEF, 0x1BF3}, {0x1C2C, 0x1C33}, {0x1C36, 0x1C37}, {0x1CD0, 0x1CD2}, {0x1CD4, 0x1CE0},
+	{0x1CE2, 0x1CE8}, {0x1CED, 0x1CED}, {0x1CF4, 0x1CF4}, {0x1CF8, 0x1CF9}, {0x1DC0, 0x1DFF}, {0x200D, 0x200D},
+	{0x20D0, 0x20F0}, {0x2CEF, 0x2CF1}, {0x2D7F, 0x2D7F}, {0x2DE0, 0x2DFF}, {0x302A, 0x302F}, {0x3099, 0x309A},
+	{0xA66F, 0xA672}, {0xA674, 0xA67D}, {0xA69E, 0xA69F}, {0xA6F0, 0xA6F1}, {0xA802, 0xA802}, {0xA806, 0xA806},
+	{0xA80B, 0xA80B}, {0xA825, 0xA826}, {0xA82C, 0xA82C}, {0xA8C4, 0xA8C5}, {0xA8E0, 0xA8F1}, {0xA8FF, 0xA8FF},
+	{0xA926, 0xA92D}, {0xA947, 0xA951}, {0xA953, 0xA953}, {0xA980, 0xA982}, {0xA9B3, 0xA9B3}, {0xA9B6, 0xA9B9},
+	{0xA9BC, 0xA9BD}, {0xA9C0, 0xA9C0}, {0xA9E5, 0xA9E5}, {0xAA29, 0xAA2E}, {0xAA31, 0xAA32}, {0xAA35, 0xAA36},
+	{0xAA43, 0xAA43}, {0xAA4C, 0xAA4C}, {0xAA7C, 0xAA7C}, {0xAAB0, 0xAAB0}, {0xAAB2, 0xAAB4}, {0xAAB7, 0xAAB8},
+	{0xAABE, 0xAABF}, {0xAAC1, 0xAAC1}, {0xAAEC, 0xAAED}, {0xAAF6, 0xAAF6}, {0xABE5, 0xABE5}, {0xABE8, 0xABE8},
+	{0xABED, 0xABED}, {0xFB1E, 0xFB1E}, {0xFE00, 0xFE0F}, {0xFE20, 0xFE2F}, {0xFF9E, 0xFF9F}, {0x101FD, 0x101FD},
+	{0x102E0, 0x102E0}, {0x10376, 0x1037A}, {0x10A01, 0x10A03}, {0x10A05, 0x10A06}, {0x10A0C, 0x10A0F}, {0x10A38, 0x10A3A},
+	{0x10A3F, 0x10A3F}, {0x10AE5, 0x10AE6}, {0x10D24, 0x10D27}, {0x10D69, 0x10D6D}, {0x10EAB, 0x10EAC}, {0x10EFC, 0x10EFF},
+	{0x10F46, 0x10F50}, {0x10F82, 0x10F85}, {0x11001, 0x11001}, {0x11038, 0x11046}, {0x11070, 0x11070}, {0x11073, 0x11074},
+	{0x1107F, 0x11081}, {0x110B3, 0x110B6}, {0x110B9, 0x110BA}, {0x110C2, 0x110C2}, {0x11100, 0x11102}, {0x11127, 0x1112B},
+	{0x1112D, 0x11134}, {0x11173, 0x11173}, {0x11180, 0x11181}, {0x111B6, 0x111BE}, {0x111C0, 0x111C0}, {0x111C9, 0x111CC},
+	{0x111CF, 0x111CF}, {0x1122F, 0x11231}, {0x11234, 0x11237}, {0x1123E, 0x1123E}, {0x11241, 0x11241}, {0x112DF, 0x112DF},
+	{0x112E3, 0x112EA}, {0x11300, 0x11301}, {0x1133B, 0x1133C}, {0x1133E, 0x1133E}, {0x11340, 0x11340}, {0x1134D, 0x1134D},
+	{0x11357, 0x11357}, {0x11366, 0x1136C}, {0x11370, 0x11374}, {0x113B8, 0x113B8}, {0x113BB, 0x113C0}, {0x113C2, 0x113C2},
+	{0x113C5, 0x113C5}, {0x113C7, 0x113C9}, {0x113CE, 0x113D0}, {0x113D2, 0x113D2}, {0x113E1, 0x113E2}, {0x11438, 0x1143F},
+	{0x11442, 0x11444}, {0x11446, 0x11446}, {0x1145E, 0x1145E}, {0x114B0, 0x114B0}, {0x114B3, 0x114B8}, {0x114BA, 0x114BA},
+	{0x114BD, 0x114BD}, {0x114BF, 0x114C0}, {0x114C2, 0x114C3}, {0x115AF, 0x115AF}, {0x115B2, 0x115B5}, {0x115BC, 0x115BD},
+	{0x115BF, 0x115C0}, {0x115DC, 0x115DD}, {0x11633, 0x1163A}, {0x1163D, 0x1163D}, {0x1163F, 0x11640}, {0x116AB, 0x116AB},
+	{0x116AD, 0x116AD}, {0x116B0, 0x116B7}, {0x1171D, 0x1171D}, {0x1171F, 0x1171F}, {0x11722, 0x11725}, {0x11727, 0x1172B},
+	{0x1182F, 0x11837}, {0x11839, 0x1183A}, {0x11930, 0x11930}, {0x1193B, 0x1193E}, {0x11943, 0x11943}, {0x119D4, 0x119D7},
+	{0x119DA, 0x119DB}, {0x119E0, 0x119E0}, {0x11A01, 0x11A0A}, {0x11A33, 0x11A38}, {0x11A3B, 0x11A3E}, {0x11A47, 0x11A47},
+	{0x11A51, 0x11A56}, {0x11A59, 0x11A5B}, {0x11A8A, 0x11A96}, {0x11A98, 0x11A99}, {0x11C30, 0x11C36}, {0x11C38, 0x11C3D},
+	{0x11C3F, 0x11C3F}, {0x11C92, 0x11CA7}, {0x11CAA, 0x11CB0}, {0x11CB2, 0x11CB3}, {0x11CB5, 0x11CB6}, {0x11D31, 0x11D36},
+	{0x11D3A, 0x11D3A}, {0x11D3C, 0x11D3D}, {0x11D3F, 0x11D45}, {0x11D47, 0x11D47}, {0x11D90, 0x11D91}, {0x11D95, 0x11D95},
+	{0x11D97, 0x11D97}, {0x11EF3, 0x11EF4}, {0x11F00, 0x11F01}, {0x11F36, 0x11F3A}, {0x11F40, 0x11F42}, {0x11F5A, 0x11F5A},
+	{0x13440, 0x13440}, {0x13447, 0x13455}, {0x1611E, 0x16129}, {0x1612D, 0x1612F}, {0x16AF0, 0x16AF4}, {0x16B30, 0x16B36},
+	{0x16F4F, 0x16F4F}, {0x16F8F, 0x16F92}, {0x16FE4, 0x16FE4}, {0x16FF0, 0x16FF1}, {0x1BC9D, 0x1BC9E}, {0x1CF00, 0x1CF2D},
+	{0x1CF30, 0x1CF46}, {0x1D165, 0x1D169}, {0x1D16D, 0x1D172}, {0x1D17B, 0x1D182}, {0x1D185, 0x1D18B}, {0x1D1AA, 0x1D1AD},
+	{0x1D242, 0x1D244}, {0x1DA00, 0x1DA36}, {0x1DA3B, 0x1DA6C}, {0x1DA75, 0x1DA75}, {0x1DA84, 0x1DA84}, {0x1DA9B, 0x1DA9F},
+	{0x1DAA1, 0x1DAAF}, {0x1E000, 0x1E006}, {0x1E008, 0x1E018}, {0x1E01B, 0x1E021}, {0x1E023, 0x1E024}, {0x1E026, 0x1E02A},
+	{0x1E08F, 0x1E08F}, {0x1E130, 0x1E136}, {0x1E2AE, 0x1E2AE}, {0x1E2EC, 0x1E2EF}, {0x1E4EC, 0x1E4EF}, {0x1E5EE, 0x1E5EF},
+	{0x1E8D0, 0x1E8D6}, {0x1E944, 0x1E94A}, {0x1F3FB, 0x1F3FF}, {0xE0020, 0xE007F}, {0xE0100, 0xE01EF},
+}
@@ -0,0 +1,73 @@
+package tokenizer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestNextGrapheme 用Unicode 16.0.0的GraphemeBreakTest用例测试扩展字素簇切分
+func TestNextGrapheme(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("tokenizer", "testdata", "grapheme_break_test.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+	count := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var text string
+		var want []string
+		for _, cluster := range strings.Split(strings.Trim(line, "÷ "), "÷") {
+			var sb strings.Builder
+			for _, hex := range strings.Split(cluster, "×") {
+				r, err := strconv.ParseUint(strings.TrimSpace(hex), 16, 32)
+				if err != nil {
+					t.Fatalf("invalid line %q: %v", line, err)
+				}
+				sb.WriteRune(rune(r))
+			}
+			want = append(want, sb.String())
+			text += sb.String()
+		}
+
+		var got []string
+		for s := text; s != ""; {
+			n := nextGrapheme(s)
+			got = append(got, s[:n])
+			s = s[n:]
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+q, want %+q", line, got, want)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Fatal("no test cases")
+	}
+}
+
+// TestNextGraphemeExtended 测试与旧的近似实现不同的情况：印度文字的连写、Prepend、
+// 不在emoji区块中的Extended_Pictographic，以及连续的区域指示符
+func TestNextGraphemeExtended(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"\u0915\u094D\u0937a", "\u0915\u094D\u0937"},                        // GB9c：क्ष，Consonant Linker Consonant
+		{"\u0600\u0661", "\u0600\u0661"},                                     // GB9b：Prepend不与后一字符断开
+		{"\u2194\u200D\u2194x", "\u2194\u200D\u2194"},                        // GB11：↔是Extended_Pictographic
+		{"\U0001F1E8\U0001F1F3\U0001F1FA\U0001F1F8", "\U0001F1E8\U0001F1F3"}, // GB12：区域指示符两两成对
+		{"a\u200D\U0001F600", "a\u200D"},                                     // ZWJ之前不是Extended_Pictographic时断开
+		{"\u1100\uAC00", "\u1100\uAC00"},                                     // GB6：L与LV
+	}
+	for _, tt := range tests {
+		if got := tt.text[:nextGrapheme(tt.text)]; got != tt.want {
+			t.Errorf("nextGrapheme(%+q) = %+q, want %+q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1037 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jinja.go 实现chat template所需的Jinja子集：{{ }}输出、{% %}语句（if/for/set/macro/break/continue/generation）、
+// {# #}注释与空白控制。与HF apply_chat_template一致，默认开启trim_blocks与lstrip_blocks
+
+// jinjaNode 模板语法树节点
+type jinjaNode interface{}
+
+type (
+	textNode   struct{ text string }
+	outputNode struct{ expr jinjaExpr }
+	ifNode     struct {
+		conds    []jinjaExpr
+		bodies   [][]jinjaNode
+		elseBody []jinjaNode
+	}
+	forNode struct {
+		targets  []string
+		iter     jinjaExpr
+		filter   jinjaExpr // for ... in ... if filter
+		body     []jinjaNode
+		elseBody []jinjaNode
+	}
+	setNode struct {
+		target string
+		attr   string    // {% set ns.attr = ... %}
+		expr   jinjaExpr // 为nil时使用body（{% set x %}...{% endset %}）
+		body   []jinjaNode
+	}
+	macroNode struct {
+		name     string
+		params   []string
+		defaults []jinjaExpr
+		body     []jinjaNode
+	}
+	breakNode    struct{}
+	continueNode struct{}
+)
+
+// jinjaExpr 表达式节点
+type jinjaExpr interface{}
+
+type (
+	literalExpr struct{ value interface{} }
+	nameExpr    struct{ name string }
+	listExpr    struct{ items []jinjaExpr }
+	dictExpr    struct{ keys, values []jinjaExpr }
+	attrExpr    struct {
+		obj  jinjaExpr
+		name string
+	}
+	indexExpr struct{ obj, index jinjaExpr }
+	sliceExpr struct{ obj, start, stop, step jinjaExpr }
+	callExpr  struct {
+		fn     jinjaExpr
+		args   []jinjaExpr
+		kwargs []jinjaKwarg
+	}
+	filterExpr struct {
+		expr   jinjaExpr
+		name   string
+		args   []jinjaExpr
+		kwargs []jinjaKwarg
+	}
+	testExpr struct {
+		expr   jinjaExpr
+		name   string
+		args   []jinjaExpr
+		negate bool
+	}
+	unaryExpr struct {
+		op string
+		x  jinjaExpr
+	}
+	binaryExpr struct {
+		op          string
+		left, right jinjaExpr
+	}
+	condExpr struct{ cond, then, otherwise jinjaExpr }
+)
+
+// jinjaKwarg 调用时的关键字参数
+type jinjaKwarg struct {
+	name  string
+	value jinjaExpr
+}
+
+// jinjaTemplate 解析后的模板
+type jinjaTemplate struct {
+	nodes []jinjaNode
+}
+
+// parseJinja 解析模板文本。与Jinja默认的keep_trailing_newline=False一致，去掉末尾的一个换行
+func parseJinja(source string) (*jinjaTemplate, error) {
+	source = strings.TrimSuffix(strings.Replace(source, "\r\n", "\n", -1), "\n")
+	segments, err := lexJinja(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &jinjaParser{segments: segments}
+	nodes, end, err := p.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	if end != nil {
+		return nil, fmt.Errorf("unexpected {%% %s %%}", end.keyword)
+	}
+	return &jinjaTemplate{nodes: nodes}, nil
+}
+
+// jinjaSegment 词法分析结果：文本、输出标签或语句标签
+type jinjaSegment struct {
+	kind    byte // 't' 文本，'o' 输出 {{ }}，'s' 语句 {% %}
+	text    string
+	tokens  []jinjaToken
+	keyword string // 语句的第一个名称
+}
+
+// lexJinja 将模板切分为文本与标签，并处理空白控制
+func lexJinja(source string) ([]jinjaSegment, error) {
+	var segments []jinjaSegment
+	pos := 0
+	lstripNext := false // 上一个标签以 -}} 等结尾
+	trimNewline := false
+	for pos < len(source) {
+		start := indexTagStart(source, pos)
+		text := source[pos:]
+		if start >= 0 {
+			text = source[pos:start]
+		}
+
+		if lstripNext {
+			text = strings.TrimLeftFunc(text, unicode.IsSpace)
+		} else if trimNewline {
+			text = strings.TrimPrefix(strings.TrimPrefix(text, "\r"), "\n")
+		}
+		lstripNext, trimNewline = false, false
+
+		if start < 0 {
+			if text != "" {
+				segments = append(segments, jinjaSegment{kind: 't', text: text})
+			}
+			break
+		}
+
+		open := source[start : start+2]
+		closeTag := map[string]string{"{{": "}}", "{%": "%}", "{#": "#}"}[open]
+		inner := start + 2
+		switch {
+		case inner < len(source) && source[inner] == '-':
+			text = strings.TrimRightFunc(text, unicode.IsSpace)
+			inner++
+		case inner < len(source) && source[inner] == '+':
+			inner++
+		case open != "{{":
+			text = lstripBlock(text, pos == 0)
+		}
+		if text != "" {
+			segments = append(segments, jinjaSegment{kind: 't', text: text})
+		}
+
+		end := findTagEnd(source, inner, closeTag)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed tag %s at %d", open, start)
+		}
+		body := source[inner:end]
+		if strings.HasSuffix(body, "-") {
+			body = body[:len(body)-1]
+			lstripNext = true
+		} else if strings.HasSuffix(body, "+") {
+			body = body[:len(body)-1]
+		} else if open != "{{" {
+			trimNewline = true
+		}
+		pos = end + 2
+
+		switch open {
+		case "{#":
+			continue
+		case "{{":
+			tokens, err := tokenizeJinjaExpr(body)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, jinjaSegment{kind: 'o', tokens: tokens})
+		default:
+			tokens, err := tokenizeJinjaExpr(body)
+			if err != nil {
+				return nil, err
+			}
+			if len(tokens) == 0 || tokens[0].kind != 'n' {
+				return nil, fmt.Errorf("invalid statement: {%%%s%%}", body)
+			}
+			if tokens[0].value == "raw" {
+				// {% raw %} ... {% endraw %} 原样输出
+				endRaw := strings.Index(source[pos:], "endraw")
+				if endRaw < 0 {
+					return nil, fmt.Errorf("unclosed raw block")
+				}
+				tagStart := strings.LastIndex(source[:pos+endRaw], "{%")
+				segments = append(segments, jinjaSegment{kind: 't', text: source[pos:tagStart]})
+				closeRaw := strings.Index(source[pos+endRaw:], "%}")
+				if closeRaw < 0 {
+					return nil, fmt.Errorf("unclosed raw block")
+				}
+				pos = pos + endRaw + closeRaw + 2
+				trimNewline = true
+				continue
+			}
+			segments = append(segments, jinjaSegment{kind: 's', tokens: tokens, keyword: tokens[0].value})
+		}
+	}
+	return segments, nil
+}
+
+// indexTagStart 查找下一个标签的起始位置
+func indexTagStart(source string, pos int) int {
+	for i := pos; i+1 < len(source); i++ {
+		if source[i] == '{' && (source[i+1] == '{' || source[i+1] == '%' || source[i+1] == '#') {
+			return i
+		}
+	}
+	return -1
+}
+
+// findTagEnd 查找标签的结束位置，跳过字符串字面量中的内容
+func findTagEnd(source string, pos int, closeTag string) int {
+	var quote byte
+	for i := pos; i+1 < len(source); i++ {
+		c := source[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		if closeTag != "#}" && (c == '\'' || c == '"') {
+			quote = c
+			continue
+		}
+		if source[i:i+2] == closeTag {
+			return i
+		}
+	}
+	return -1
+}
+
+// lstripBlock lstrip_blocks：块标签之前同一行只有空格或制表符时将其去掉
+func lstripBlock(text string, atTemplateStart bool) string {
+	i := strings.LastIndexByte(text, '\n')
+	if i < 0 && !atTemplateStart {
+		return text
+	}
+	if strings.Trim(text[i+1:], " \t") != "" {
+		return text
+	}
+	return text[:i+1]
+}
+
+// jinjaToken 表达式词法单元：n 名称，s 字符串，i 整数，f 浮点数，o 运算符
+type jinjaToken struct {
+	kind  byte
+	value string
+}
+
+// jinjaOperators 按长度降序排列的运算符
+var jinjaOperators = []string{"**", "//", "==", "!=", "<=", ">=", "+", "-", "*", "/", "%", "~", "<", ">", "=",
+	"(", ")", "[", "]", "{", "}", ".", ",", ":", "|"}
+
+// tokenizeJinjaExpr 对标签内的表达式进行词法分析
+func tokenizeJinjaExpr(s string) ([]jinjaToken, error) {
+	var tokens []jinjaToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+					switch s[j] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					case 'r':
+						sb.WriteByte('\r')
+					default:
+						sb.WriteByte(s[j])
+					}
+					continue
+				}
+				sb.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in %q", s)
+			}
+			tokens = append(tokens, jinjaToken{kind: 's', value: sb.String()})
+			i = j + 1
+		case c >= '0' && c <= '9':
+			j := i
+			kind := byte('i')
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '_' ||
+				s[j] == '.' && kind == 'i' && j+1 < len(s) && s[j+1] >= '0' && s[j+1] <= '9') {
+				if s[j] == '.' {
+					kind = 'f'
+				}
+				j++
+			}
+			tokens = append(tokens, jinjaToken{kind: kind, value: strings.Replace(s[i:j], "_", "", -1)})
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(s) && (s[j] == '_' || unicode.IsLetter(rune(s[j])) || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			tokens = append(tokens, jinjaToken{kind: 'n', value: s[i:j]})
+			i = j
+		default:
+			matched := false
+			for _, op := range jinjaOperators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, jinjaToken{kind: 'o', value: op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q in %q", c, s)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// jinjaParser 语法分析器
+type jinjaParser struct {
+	segments []jinjaSegment
+	pos      int
+}
+
+// blockEnd 结束当前语句块的标签
+type blockEnd struct {
+	keyword string
+	tokens  []jinjaToken
+}
+
+// parseBody 解析语句块，直到遇到end*/elif/else等结束标签或模板末尾
+func (p *jinjaParser) parseBody() ([]jinjaNode, *blockEnd, error) {
+	var nodes []jinjaNode
+	for p.pos < len(p.segments) {
+		seg := p.segments[p.pos]
+		p.pos++
+		switch seg.kind {
+		case 't':
+			nodes = append(nodes, &textNode{text: seg.text})
+		case 'o':
+			expr, err := parseJinjaExpr(seg.tokens)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, &outputNode{expr: expr})
+		default:
+			switch seg.keyword {
+			case "elif", "else", "endif", "endfor", "endset", "endmacro", "endgeneration", "endfilter":
+				return nodes, &blockEnd{keyword: seg.keyword, tokens: seg.tokens[1:]}, nil
+			}
+			node, err := p.parseStatement(seg)
+			if err != nil {
+				return nil, nil, err
+			}
+			if node != nil {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes, nil, nil
+}
+
+// expectEnd 解析语句块并要求以指定标签结束
+func (p *jinjaParser) expectEnd(keywords ...string) ([]jinjaNode, *blockEnd, error) {
+	body, end, err := p.parseBody()
+	if err != nil {
+		return nil, nil, err
+	}
+	if end == nil {
+		return nil, nil, fmt.Errorf("missing {%% %s %%}", keywords[len(keywords)-1])
+	}
+	for _, keyword := range keywords {
+		if end.keyword == keyword {
+			return body, end, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unexpected {%% %s %%}, expected %s", end.keyword, strings.Join(keywords, " or "))
+}
+
+// parseStatement 解析 {% %} 语句
+func (p *jinjaParser) parseStatement(seg jinjaSegment) (jinjaNode, error) {
+	args := seg.tokens[1:]
+	switch seg.keyword {
+	case "if":
+		node := &ifNode{}
+		cond, err := parseJinjaExpr(args)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			body, end, err := p.expectEnd("elif", "else", "endif")
+			if err != nil {
+				return nil, err
+			}
+			node.conds = append(node.conds, cond)
+			node.bodies = append(node.bodies, body)
+			switch end.keyword {
+			case "elif":
+				if cond, err = parseJinjaExpr(end.tokens); err != nil {
+					return nil, err
+				}
+				continue
+			case "else":
+				if node.elseBody, _, err = p.expectEnd("endif"); err != nil {
+					return nil, err
+				}
+			}
+			return node, nil
+		}
+
+	case "for":
+		node := &forNode{}
+		i := 0
+		for ; i < len(args) && !(args[i].kind == 'n' && args[i].value == "in"); i++ {
+			if args[i].kind == 'n' {
+				node.targets = append(node.targets, args[i].value)
+			}
+		}
+		if i == len(args) || len(node.targets) == 0 {
+			return nil, fmt.Errorf("invalid for statement")
+		}
+		rest := args[i+1:]
+		// 可选的 if 过滤条件（不在括号内的顶层if）
+		depth, ifAt := 0, -1
+		for j, tok := range rest {
+			if tok.kind == 'o' && strings.Contains("([{", tok.value) {
+				depth++
+			} else if tok.kind == 'o' && strings.Contains(")]}", tok.value) {
+				depth--
+			} else if depth == 0 && tok.kind == 'n' && tok.value == "if" && !hasElseAfter(rest[j+1:]) {
+				ifAt = j
+				break
+			}
+		}
+		iterTokens := rest
+		if ifAt >= 0 {
+			iterTokens = rest[:ifAt]
+			filter, err := parseJinjaExpr(rest[ifAt+1:])
+			if err != nil {
+				return nil, err
+			}
+			node.filter = filter
+		}
+		if len(iterTokens) > 0 && iterTokens[len(iterTokens)-1].kind == 'n' && iterTokens[len(iterTokens)-1].value == "recursive" {
+			return nil, fmt.Errorf("recursive loops are not supported")
+		}
+		iter, err := parseJinjaExpr(iterTokens)
+		if err != nil {
+			return nil, err
+		}
+		node.iter = iter
+		body, end, err := p.expectEnd("else", "endfor")
+		if err != nil {
+			return nil, err
+		}
+		node.body = body
+		if end.keyword == "else" {
+			if node.elseBody, _, err = p.expectEnd("endfor"); err != nil {
+				return nil, err
+			}
+		}
+		return node, nil
+
+	case "set":
+		if len(args) == 0 || args[0].kind != 'n' {
+			return nil, fmt.Errorf("invalid set statement")
+		}
+		node := &setNode{target: args[0].value}
+		rest := args[1:]
+		if len(rest) >= 2 && rest[0].value == "." && rest[1].kind == 'n' {
+			node.attr = rest[1].value
+			rest = rest[2:]
+		}
+		if len(rest) == 0 {
+			body, _, err := p.expectEnd("endset")
+			if err != nil {
+				return nil, err
+			}
+			node.body = body
+			return node, nil
+		}
+		if rest[0].value != "=" || rest[0].kind != 'o' {
+			return nil, fmt.Errorf("invalid set statement")
+		}
+		expr, err := parseJinjaExpr(rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		node.expr = expr
+		return node, nil
+
+	case "macro":
+		if len(args) < 3 || args[0].kind != 'n' || args[1].value != "(" {
+			return nil, fmt.Errorf("invalid macro statement")
+		}
+		node := &macroNode{name: args[0].value}
+		ep := &exprParser{tokens: args[2:]}
+		for !ep.accept("o", ")") {
+			name := ep.next()
+			if name.kind != 'n' {
+				return nil, fmt.Errorf("invalid macro parameter")
+			}
+			var def jinjaExpr
+			if ep.accept("o", "=") {
+				var err error
+				if def, err = ep.parseExpr(); err != nil {
+					return nil, err
+				}
+			}
+			node.params = append(node.params, name.value)
+			node.defaults = append(node.defaults, def)
+			if !ep.accept("o", ",") && ep.peek().value != ")" {
+				return nil, fmt.Errorf("invalid macro parameters")
+			}
+		}
+		body, _, err := p.expectEnd("endmacro")
+		if err != nil {
+			return nil, err
+		}
+		node.body = body
+		return node, nil
+
+	case "generation":
+		// AssistantTracker扩展：仅用于标记助手回复，渲染时原样输出内容
+		body, _, err := p.expectEnd("endgeneration")
+		if err != nil {
+			return nil, err
+		}
+		return &ifNode{conds: []jinjaExpr{&literalExpr{value: true}}, bodies: [][]jinjaNode{body}}, nil
+
+	case "break":
+		return &breakNode{}, nil
+	case "continue":
+		return &continueNode{}, nil
+	}
+	return nil, fmt.Errorf("unsupported statement: %s", seg.keyword)
+}
+
+// hasElseAfter for语句中 if 后面出现 else 时为条件表达式而非过滤条件
+func hasElseAfter(tokens []jinjaToken) bool {
+	for _, tok := range tokens {
+		if tok.kind == 'n' && tok.value == "else" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJinjaExpr 解析完整的表达式
+func parseJinjaExpr(tokens []jinjaToken) (jinjaExpr, error) {
+	ep := &exprParser{tokens: tokens}
+	expr, err := ep.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	// 顶层的逗号表示元组
+	if ep.peek().value == "," && ep.peek().kind == 'o' {
+		items := []jinjaExpr{expr}
+		for ep.accept("o", ",") {
+			item, err := ep.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		expr = &listExpr{items: items}
+	}
+	if ep.pos < len(ep.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", ep.tokens[ep.pos].value)
+	}
+	return expr, nil
+}
+
+// exprParser 表达式的递归下降分析器
+type exprParser struct {
+	tokens []jinjaToken
+	pos    int
+}
+
+func (ep *exprParser) peek() jinjaToken {
+	if ep.pos < len(ep.tokens) {
+		return ep.tokens[ep.pos]
+	}
+	return jinjaToken{}
+}
+
+func (ep *exprParser) next() jinjaToken {
+	tok := ep.peek()
+	ep.pos++
+	return tok
+}
+
+// accept 下一个token为指定的运算符("o")或名称("n")时消费它
+func (ep *exprParser) accept(kind, value string) bool {
+	tok := ep.peek()
+	if tok.kind == kind[0] && tok.value == value {
+		ep.pos++
+		return true
+	}
+	return false
+}
+
+func (ep *exprParser) expect(kind, value string) error {
+	if !ep.accept(kind, value) {
+		return fmt.Errorf("expected %q, got %q", value, ep.peek().value)
+	}
+	return nil
+}
+
+// parseExpr 条件表达式：a if cond else b
+func (ep *exprParser) parseExpr() (jinjaExpr, error) {
+	expr, err := ep.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for ep.accept("n", "if") {
+		cond, err := ep.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		var otherwise jinjaExpr
+		if ep.accept("n", "else") {
+			if otherwise, err = ep.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		expr = &condExpr{cond: cond, then: expr, otherwise: otherwise}
+	}
+	return expr, nil
+}
+
+func (ep *exprParser) parseOr() (jinjaExpr, error) {
+	left, err := ep.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for ep.accept("n", "or") {
+		right, err := ep.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (ep *exprParser) parseAnd() (jinjaExpr, error) {
+	left, err := ep.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for ep.accept("n", "and") {
+		right, err := ep.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (ep *exprParser) parseNot() (jinjaExpr, error) {
+	if ep.accept("n", "not") {
+		x, err := ep.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", x: x}, nil
+	}
+	return ep.parseCompare()
+}
+
+func (ep *exprParser) parseCompare() (jinjaExpr, error) {
+	left, err := ep.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := ep.peek()
+		var op string
+		switch {
+		case tok.kind == 'o' && (tok.value == "==" || tok.value == "!=" || tok.value == "<" ||
+			tok.value == ">" || tok.value == "<=" || tok.value == ">="):
+			op = tok.value
+			ep.pos++
+		case tok.kind == 'n' && tok.value == "in":
+			op = "in"
+			ep.pos++
+		case tok.kind == 'n' && tok.value == "not" && ep.pos+1 < len(ep.tokens) && ep.tokens[ep.pos+1].value == "in":
+			op = "not in"
+			ep.pos += 2
+		case tok.kind == 'n' && tok.value == "is":
+			ep.pos++
+			test := &testExpr{expr: left, negate: ep.accept("n", "not")}
+			name := ep.next()
+			if name.kind != 'n' {
+				return nil, fmt.Errorf("invalid test name %q", name.value)
+			}
+			test.name = name.value
+			if ep.peek().kind == 'o' && ep.peek().value == "(" {
+				ep.pos++
+				args, _, err := ep.parseArgs()
+				if err != nil {
+					return nil, err
+				}
+				test.args = args
+			} else if next := ep.peek(); next.kind == 's' || next.kind == 'i' || next.kind == 'f' ||
+				next.kind == 'n' && next.value != "and" && next.value != "or" && next.value != "else" && next.value != "if" {
+				// 单个参数可省略括号，如 is divisibleby 3
+				arg, err := ep.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				test.args = []jinjaExpr{arg}
+			}
+			left = test
+			continue
+		default:
+			return left, nil
+		}
+		right, err := ep.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (ep *exprParser) parseConcat() (jinjaExpr, error) {
+	left, err := ep.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+	for ep.accept("o", "~") {
+		right, err := ep.parseAdd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "~", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (ep *exprParser) parseAdd() (jinjaExpr, error) {
+	left, err := ep.parseMul()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := ep.peek()
+		if tok.kind != 'o' || tok.value != "+" && tok.value != "-" {
+			return left, nil
+		}
+		ep.pos++
+		right, err := ep.parseMul()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: tok.value, left: left, right: right}
+	}
+}
+
+func (ep *exprParser) parseMul() (jinjaExpr, error) {
+	left, err := ep.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := ep.peek()
+		if tok.kind != 'o' || tok.value != "*" && tok.value != "/" && tok.value != "//" && tok.value != "%" {
+			return left, nil
+		}
+		ep.pos++
+		right, err := ep.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: tok.value, left: left, right: right}
+	}
+}
+
+func (ep *exprParser) parseUnary() (jinjaExpr, error) {
+	tok := ep.peek()
+	if tok.kind == 'o' && (tok.value == "-" || tok.value == "+") {
+		ep.pos++
+		x, err := ep.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: tok.value, x: x}, nil
+	}
+	return ep.parsePow()
+}
+
+func (ep *exprParser) parsePow() (jinjaExpr, error) {
+	left, err := ep.parseFilter()
+	if err != nil {
+		return nil, err
+	}
+	if ep.accept("o", "**") {
+		right, err := ep.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpr{op: "**", left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+// parseFilter 过滤器：x | name(args)
+func (ep *exprParser) parseFilter() (jinjaExpr, error) {
+	expr, err := ep.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	for ep.accept("o", "|") {
+		name := ep.next()
+		if name.kind != 'n' {
+			return nil, fmt.Errorf("invalid filter name %q", name.value)
+		}
+		filter := &filterExpr{expr: expr, name: name.value}
+		if ep.accept("o", "(") {
+			if filter.args, filter.kwargs, err = ep.parseArgs(); err != nil {
+				return nil, err
+			}
+		}
+		expr = filter
+	}
+	return expr, nil
+}
+
+// parsePostfix 属性访问、下标、切片与调用
+func (ep *exprParser) parsePostfix() (jinjaExpr, error) {
+	expr, err := ep.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case ep.accept("o", "."):
+			name := ep.next()
+			if name.kind != 'n' && name.kind != 'i' {
+				return nil, fmt.Errorf("invalid attribute %q", name.value)
+			}
+			expr = &attrExpr{obj: expr, name: name.value}
+		case ep.accept("o", "["):
+			var parts [3]jinjaExpr
+			colons := 0
+			for !ep.accept("o", "]") {
+				if ep.accept("o", ":") {
+					colons++
+					if colons > 2 {
+						return nil, fmt.Errorf("invalid slice")
+					}
+					continue
+				}
+				part, err := ep.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				parts[colons] = part
+			}
+			if colons == 0 {
+				expr = &indexExpr{obj: expr, index: parts[0]}
+			} else {
+				expr = &sliceExpr{obj: expr, start: parts[0], stop: parts[1], step: parts[2]}
+			}
+		case ep.accept("o", "("):
+			args, kwargs, err := ep.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			expr = &callExpr{fn: expr, args: args, kwargs: kwargs}
+		default:
+			return expr, nil
+		}
+	}
+}
+
+// parseArgs 解析调用参数，左括号已被消费
+func (ep *exprParser) parseArgs() ([]jinjaExpr, []jinjaKwarg, error) {
+	var args []jinjaExpr
+	var kwargs []jinjaKwarg
+	for !ep.accept("o", ")") {
+		if ep.pos >= len(ep.tokens) {
+			return nil, nil, fmt.Errorf("unclosed call")
+		}
+		if tok := ep.peek(); tok.kind == 'n' && ep.pos+1 < len(ep.tokens) && ep.tokens[ep.pos+1].value == "=" && ep.tokens[ep.pos+1].kind == 'o' {
+			ep.pos += 2
+			value, err := ep.parseExpr()
+			if err != nil {
+				return nil, nil, err
+			}
+			kwargs = append(kwargs, jinjaKwarg{name: tok.value, value: value})
+		} else {
+			arg, err := ep.parseExpr()
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, arg)
+		}
+		if !ep.accept("o", ",") && ep.peek().value != ")" {
+			return nil, nil, fmt.Errorf("expected ',' or ')', got %q", ep.peek().value)
+		}
+	}
+	return args, kwargs, nil
+}
+
+// parsePrimary 字面量、名称、括号、列表与字典
+func (ep *exprParser) parsePrimary() (jinjaExpr, error) {
+	tok := ep.next()
+	switch tok.kind {
+	case 's':
+		value := tok.value
+		// 相邻的字符串字面量自动拼接
+		for ep.peek().kind == 's' {
+			value += ep.next().value
+		}
+		return &literalExpr{value: value}, nil
+	case 'i':
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpr{value: n}, nil
+	case 'f':
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpr{value: f}, nil
+	case 'n':
+		switch tok.value {
+		case "true", "True":
+			return &literalExpr{value: true}, nil
+		case "false", "False":
+			return &literalExpr{value: false}, nil
+		case "none", "None":
+			return &literalExpr{value: nil}, nil
+		}
+		return &nameExpr{name: tok.value}, nil
+	case 'o':
+		switch tok.value {
+		case "(":
+			if ep.accept("o", ")") {
+				return &listExpr{}, nil
+			}
+			expr, err := ep.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if ep.peek().value == "," {
+				items := []jinjaExpr{expr}
+				for ep.accept("o", ",") {
+					if ep.peek().value == ")" {
+						break
+					}
+					item, err := ep.parseExpr()
+					if err != nil {
+						return nil, err
+					}
+					items = append(items, item)
+				}
+				expr = &listExpr{items: items}
+			}
+			return expr, ep.expect("o", ")")
+		case "[":
+			list := &listExpr{}
+			for !ep.accept("o", "]") {
+				item, err := ep.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if !ep.accept("o", ",") && ep.peek().value != "]" {
+					return nil, fmt.Errorf("expected ',' or ']'")
+				}
+			}
+			return list, nil
+		case "{":
+			dict := &dictExpr{}
+			for !ep.accept("o", "}") {
+				key, err := ep.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				if err := ep.expect("o", ":"); err != nil {
+					return nil, err
+				}
+				value, err := ep.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				dict.keys = append(dict.keys, key)
+				dict.values = append(dict.values, value)
+				if !ep.accept("o", ",") && ep.peek().value != "}" {
+					return nil, fmt.Errorf("expected ',' or '}'")
+				}
+			}
+			return dict, nil
+		}
+	}
+	if tok.kind == 0 {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected token %q", tok.value)
+}
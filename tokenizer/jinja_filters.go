@@ -0,0 +1,549 @@
+package tokenizer
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jinja_filters.go 内置过滤器与测试，覆盖常见chat template使用的部分
+
+// filterArg 取第i个位置参数或同名关键字参数
+func filterArg(args []interface{}, kwargs map[string]interface{}, i int, name string) (interface{}, bool) {
+	if i < len(args) {
+		return args[i], true
+	}
+	v, ok := kwargs[name]
+	return v, ok
+}
+
+// applyFilter 应用过滤器 value | name(args)
+func applyFilter(name string, value interface{}, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	switch name {
+	case "length", "count":
+		switch v := value.(type) {
+		case string:
+			return utf8.RuneCountInString(v), nil
+		case *jinjaDict:
+			return len(v.keys), nil
+		}
+		items, err := iterate(value)
+		return len(items), err
+
+	case "string":
+		return toString(value), nil
+	case "safe":
+		return value, nil
+	case "e", "escape":
+		return html.EscapeString(toString(value)), nil
+	case "upper":
+		return strings.ToUpper(toString(value)), nil
+	case "lower":
+		return strings.ToLower(toString(value)), nil
+	case "title":
+		return pyTitle(toString(value)), nil
+	case "capitalize":
+		return pyCapitalize(toString(value)), nil
+	case "wordcount":
+		return len(strings.Fields(toString(value))), nil
+
+	case "trim":
+		if chars, ok := filterArg(args, kwargs, 0, "chars"); ok && chars != nil {
+			return strings.Trim(toString(value), toString(chars)), nil
+		}
+		return strings.TrimSpace(toString(value)), nil
+
+	case "replace":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("replace filter requires 2 arguments")
+		}
+		count := -1
+		if v, ok := filterArg(args, kwargs, 2, "count"); ok {
+			if n, ok := v.(int); ok {
+				count = n
+			}
+		}
+		return strings.Replace(toString(value), toString(args[0]), toString(args[1]), count), nil
+
+	case "default", "d":
+		def, _ := filterArg(args, kwargs, 0, "default_value")
+		if def == nil && len(args) == 0 {
+			def = ""
+		}
+		boolean, _ := filterArg(args, kwargs, 1, "boolean")
+		if _, isUndefined := value.(jinjaUndefined); isUndefined || truthy(boolean) && !truthy(value) {
+			return def, nil
+		}
+		return value, nil
+
+	case "int":
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case float64:
+			return int(v), nil
+		case bool:
+			if v {
+				return 1, nil
+			}
+			return 0, nil
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return n, nil
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return int(f), nil
+			}
+		}
+		if def, ok := filterArg(args, kwargs, 0, "default"); ok {
+			return def, nil
+		}
+		return 0, nil
+
+	case "float":
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+		if s, ok := value.(string); ok {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f, nil
+			}
+		}
+		if def, ok := filterArg(args, kwargs, 0, "default"); ok {
+			return def, nil
+		}
+		return 0.0, nil
+
+	case "abs":
+		switch v := value.(type) {
+		case int:
+			if v < 0 {
+				return -v, nil
+			}
+			return v, nil
+		case float64:
+			return math.Abs(v), nil
+		}
+		return nil, fmt.Errorf("bad operand type for abs(): %s", typeName(value))
+
+	case "round":
+		f, ok := toFloat(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot round %s", typeName(value))
+		}
+		precision := 0
+		if v, ok := filterArg(args, kwargs, 0, "precision"); ok {
+			precision, _ = v.(int)
+		}
+		method := "common"
+		if v, ok := filterArg(args, kwargs, 1, "method"); ok {
+			method = toString(v)
+		}
+		scale := math.Pow(10, float64(precision))
+		switch method {
+		case "floor":
+			return math.Floor(f*scale) / scale, nil
+		case "ceil":
+			return math.Ceil(f*scale) / scale, nil
+		}
+		return math.Round(f*scale) / scale, nil
+
+	case "first", "last":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return undefined, nil
+		}
+		if name == "first" {
+			return items[0], nil
+		}
+		return items[len(items)-1], nil
+
+	case "list":
+		items, err := iterate(value)
+		return append([]interface{}{}, items...), err
+
+	case "reverse":
+		if s, ok := value.(string); ok {
+			runes := []rune(s)
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+			return string(runes), nil
+		}
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		reversed := make([]interface{}, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		return reversed, nil
+
+	case "join":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		sep := ""
+		if v, ok := filterArg(args, kwargs, 0, "d"); ok {
+			sep = toString(v)
+		}
+		attribute, hasAttribute := filterArg(args, kwargs, 1, "attribute")
+		parts := make([]string, len(items))
+		for i, item := range items {
+			if hasAttribute {
+				item = getAttr(item, toString(attribute))
+			}
+			parts[i] = toString(item)
+		}
+		return strings.Join(parts, sep), nil
+
+	case "unique":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		var unique []interface{}
+		for _, item := range items {
+			if ok, _ := contains(unique, item); !ok {
+				unique = append(unique, item)
+			}
+		}
+		return unique, nil
+
+	case "sort":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		reverse, _ := filterArg(args, kwargs, 0, "reverse")
+		caseSensitive, _ := filterArg(args, kwargs, 1, "case_sensitive")
+		attribute, hasAttribute := filterArg(args, kwargs, 2, "attribute")
+		sorted := append([]interface{}{}, items...)
+		key := func(v interface{}) interface{} {
+			if hasAttribute {
+				v = getAttr(v, toString(attribute))
+			}
+			if s, ok := v.(string); ok && !truthy(caseSensitive) {
+				return strings.ToLower(s)
+			}
+			return v
+		}
+		var sortErr error
+		sort.SliceStable(sorted, func(i, j int) bool {
+			c, err := compareValues(key(sorted[i]), key(sorted[j]))
+			if err != nil {
+				sortErr = err
+			}
+			if truthy(reverse) {
+				return c > 0
+			}
+			return c < 0
+		})
+		return sorted, sortErr
+
+	case "items":
+		switch v := value.(type) {
+		case *jinjaDict:
+			return v.items(), nil
+		case jinjaUndefined:
+			return []interface{}{}, nil
+		}
+		return nil, fmt.Errorf("items filter requires a mapping, got %s", typeName(value))
+
+	case "dictsort":
+		d, ok := value.(*jinjaDict)
+		if !ok {
+			return nil, fmt.Errorf("dictsort filter requires a mapping, got %s", typeName(value))
+		}
+		items := d.items()
+		sort.SliceStable(items, func(i, j int) bool {
+			return strings.ToLower(items[i].([]interface{})[0].(string)) < strings.ToLower(items[j].([]interface{})[0].(string))
+		})
+		return items, nil
+
+	case "indent":
+		indention := "    "
+		if v, ok := filterArg(args, kwargs, 0, "width"); ok {
+			if n, ok := v.(int); ok {
+				indention = strings.Repeat(" ", n)
+			} else {
+				indention = toString(v)
+			}
+		}
+		first, _ := filterArg(args, kwargs, 1, "first")
+		blank, _ := filterArg(args, kwargs, 2, "blank")
+		return indentText(toString(value), indention, truthy(first), truthy(blank)), nil
+
+	case "tojson":
+		indent := ""
+		if v, ok := filterArg(args, kwargs, 0, "indent"); ok && v != nil {
+			if n, ok := v.(int); ok {
+				indent = strings.Repeat(" ", n)
+			} else {
+				indent = toString(v)
+			}
+		}
+		var sb strings.Builder
+		if err := writeJSON(&sb, value, indent, 0); err != nil {
+			return nil, err
+		}
+		return sb.String(), nil
+
+	case "attr":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("attr filter requires 1 argument")
+		}
+		return getAttr(value, toString(args[0])), nil
+
+	case "map":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		mapped := make([]interface{}, len(items))
+		for i, item := range items {
+			if attribute, ok := kwargs["attribute"]; ok {
+				v := getAttr(item, toString(attribute))
+				if _, isUndefined := v.(jinjaUndefined); isUndefined {
+					if def, ok := kwargs["default"]; ok {
+						v = def
+					}
+				}
+				mapped[i] = v
+				continue
+			}
+			if len(args) == 0 {
+				return nil, fmt.Errorf("map filter requires a filter name or attribute")
+			}
+			v, err := applyFilter(toString(args[0]), item, args[1:], nil)
+			if err != nil {
+				return nil, err
+			}
+			mapped[i] = v
+		}
+		return mapped, nil
+
+	case "select", "reject", "selectattr", "rejectattr":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		byAttr := strings.HasSuffix(name, "attr")
+		keep := strings.HasPrefix(name, "select")
+		testArgs := args
+		var attribute string
+		if byAttr {
+			if len(testArgs) == 0 {
+				return nil, fmt.Errorf("%s filter requires an attribute", name)
+			}
+			attribute, testArgs = toString(testArgs[0]), testArgs[1:]
+		}
+		var selected []interface{}
+		for _, item := range items {
+			v := item
+			if byAttr {
+				v = getAttr(item, attribute)
+			}
+			ok := truthy(v)
+			if len(testArgs) > 0 {
+				if ok, err = applyTest(toString(testArgs[0]), v, testArgs[1:]); err != nil {
+					return nil, err
+				}
+			}
+			if ok == keep {
+				selected = append(selected, item)
+			}
+		}
+		return selected, nil
+
+	case "sum":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		var total interface{} = 0
+		if start, ok := filterArg(args, kwargs, 1, "start"); ok {
+			total = start
+		}
+		attribute, hasAttribute := filterArg(args, kwargs, 0, "attribute")
+		for _, item := range items {
+			if hasAttribute {
+				item = getAttr(item, toString(attribute))
+			}
+			if total, err = binaryOp("+", total, item); err != nil {
+				return nil, err
+			}
+		}
+		return total, nil
+
+	case "min", "max":
+		items, err := iterate(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return undefined, nil
+		}
+		best := items[0]
+		for _, item := range items[1:] {
+			c, err := compareValues(item, best)
+			if err != nil {
+				return nil, err
+			}
+			if name == "min" && c < 0 || name == "max" && c > 0 {
+				best = item
+			}
+		}
+		return best, nil
+	}
+	return nil, fmt.Errorf("unknown filter: %s", name)
+}
+
+// indentText Jinja的indent过滤器：除第一行（first为true时包括第一行）外的非空行添加缩进
+func indentText(s, indention string, first, blank bool) string {
+	lines := strings.Split(s, "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+			if blank || line != "" {
+				sb.WriteString(indention)
+			}
+		}
+		sb.WriteString(line)
+	}
+	if first {
+		return indention + sb.String()
+	}
+	return sb.String()
+}
+
+// applyTest 应用测试 value is name(args)
+func applyTest(name string, value interface{}, args []interface{}) (bool, error) {
+	arg := func() (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("test %s requires an argument", name)
+		}
+		return args[0], nil
+	}
+
+	switch name {
+	case "defined":
+		_, isUndefined := value.(jinjaUndefined)
+		return !isUndefined, nil
+	case "undefined":
+		_, isUndefined := value.(jinjaUndefined)
+		return isUndefined, nil
+	case "none":
+		return value == nil, nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok, nil
+	case "true":
+		return value == true, nil
+	case "false":
+		return value == false, nil
+	case "integer":
+		_, ok := value.(int)
+		return ok, nil
+	case "float":
+		_, ok := value.(float64)
+		return ok, nil
+	case "number":
+		return isNumber(value), nil
+	case "string":
+		_, ok := value.(string)
+		return ok, nil
+	case "mapping":
+		_, ok := value.(*jinjaDict)
+		return ok, nil
+	case "sequence", "iterable":
+		switch value.(type) {
+		case string, []interface{}, *jinjaDict:
+			return true, nil
+		}
+		return false, nil
+	case "callable":
+		return isCallable(value), nil
+	case "lower", "upper":
+		s, ok := value.(string)
+		if !ok {
+			return false, nil
+		}
+		if name == "lower" {
+			return s == strings.ToLower(s), nil
+		}
+		return s == strings.ToUpper(s), nil
+	case "even", "odd":
+		n, ok := value.(int)
+		if !ok {
+			return false, fmt.Errorf("test %s requires an integer", name)
+		}
+		return (n%2 == 0) == (name == "even"), nil
+	case "divisibleby":
+		d, err := arg()
+		if err != nil {
+			return false, err
+		}
+		n, ok1 := value.(int)
+		m, ok2 := d.(int)
+		if !ok1 || !ok2 || m == 0 {
+			return false, fmt.Errorf("divisibleby requires non-zero integers")
+		}
+		return n%m == 0, nil
+	case "eq", "equalto", "==", "sameas":
+		other, err := arg()
+		return err == nil && jinjaEqual(value, other), err
+	case "ne", "!=":
+		other, err := arg()
+		return err == nil && !jinjaEqual(value, other), err
+	case "lt", "<", "le", "<=", "gt", ">", "ge", ">=":
+		other, err := arg()
+		if err != nil {
+			return false, err
+		}
+		c, err := compareValues(value, other)
+		if err != nil {
+			return false, err
+		}
+		switch name {
+		case "lt", "<":
+			return c < 0, nil
+		case "le", "<=":
+			return c <= 0, nil
+		case "gt", ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "in":
+		container, err := arg()
+		if err != nil {
+			return false, err
+		}
+		return contains(container, value)
+	case "space":
+		s, ok := value.(string)
+		return ok && s != "" && strings.TrimFunc(s, unicode.IsSpace) == "", nil
+	}
+	return false, fmt.Errorf("unknown test: %s", name)
+}
+
+// sortedKeys 按键排序，保证关键字参数构造的字典顺序稳定
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
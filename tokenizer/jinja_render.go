@@ -0,0 +1,1650 @@
+package tokenizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jinja_render.go 模板求值：值的表示与Python语义、作用域、循环控制与全局函数。
+// 值使用 nil(None)、bool、int、float64、string、[]interface{}、*jinjaDict 表示
+
+// jinjaUndefined 未定义的值，输出为空字符串，属性访问仍得到未定义值
+type jinjaUndefined struct{}
+
+var undefined = jinjaUndefined{}
+
+// jinjaDict 保持插入顺序的字典，与Python dict一致；namespace同样使用它
+type jinjaDict struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newJinjaDict() *jinjaDict {
+	return &jinjaDict{values: make(map[string]interface{})}
+}
+
+func (d *jinjaDict) get(key string) (interface{}, bool) {
+	v, ok := d.values[key]
+	return v, ok
+}
+
+func (d *jinjaDict) set(key string, value interface{}) {
+	if _, exists := d.values[key]; !exists {
+		d.keys = append(d.keys, key)
+	}
+	d.values[key] = value
+}
+
+// items 返回 [key, value] 对的列表
+func (d *jinjaDict) items() []interface{} {
+	items := make([]interface{}, len(d.keys))
+	for i, key := range d.keys {
+		items[i] = []interface{}{key, d.values[key]}
+	}
+	return items
+}
+
+// jinjaFunc 全局函数与绑定方法
+type jinjaFunc func(args []interface{}, kwargs map[string]interface{}) (interface{}, error)
+
+// jinjaMacro {% macro %} 定义的宏，调用时在定义处的作用域中渲染
+type jinjaMacro struct {
+	node  *macroNode
+	scope *jinjaScope
+}
+
+// jinjaLoop for循环中的loop变量
+type jinjaLoop struct {
+	items []interface{}
+	index int
+}
+
+// jinjaScope 变量作用域，for循环与宏调用创建子作用域
+type jinjaScope struct {
+	vars    map[string]interface{}
+	parent  *jinjaScope
+	tracker *jinjaTracker // 为nil时不记录循环位置
+}
+
+func (s *jinjaScope) child() *jinjaScope {
+	return &jinjaScope{vars: make(map[string]interface{}), parent: s, tracker: s.tracker}
+}
+
+// jinjaTracker 记录for循环遍历到被跟踪元素（如chat的每条消息）时模板输出的位置
+type jinjaTracker struct {
+	out   *strings.Builder    // 模板的最终输出，set块与宏调用的输出不记录
+	items map[interface{}]int // 被跟踪的元素（指针）到其序号
+	marks []jinjaMark
+}
+
+// jinjaMark 从offset字节起的输出属于序号为index的元素，index为-1表示循环结束
+type jinjaMark struct {
+	offset int
+	index  int
+}
+
+func (s *jinjaScope) lookup(name string) (interface{}, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	errLoopBreak    = errors.New("break outside of loop")
+	errLoopContinue = errors.New("continue outside of loop")
+)
+
+// render 使用给定变量渲染模板
+func (t *jinjaTemplate) render(vars map[string]interface{}) (string, error) {
+	out, _, err := t.renderTracked(vars, nil)
+	return out, err
+}
+
+// renderTracked 渲染模板，并返回for循环遍历items中各元素时的输出位置
+func (t *jinjaTemplate) renderTracked(vars map[string]interface{}, items map[interface{}]int) (string, []jinjaMark, error) {
+	var out strings.Builder
+	root := &jinjaScope{vars: jinjaGlobals()}
+	if len(items) > 0 {
+		root.tracker = &jinjaTracker{out: &out, items: items}
+	}
+	scope := root.child()
+	for name, value := range vars {
+		scope.vars[name] = value
+	}
+	if err := renderNodes(t.nodes, scope, &out); err != nil {
+		return "", nil, err
+	}
+	if root.tracker == nil {
+		return out.String(), nil, nil
+	}
+	return out.String(), root.tracker.marks, nil
+}
+
+// mark 在循环遍历到item时记录输出位置，只记录模板最终输出中的位置
+func (tr *jinjaTracker) mark(out *strings.Builder, item interface{}) bool {
+	if tr == nil || out != tr.out {
+		return false
+	}
+	index, ok := tr.items[item]
+	if !ok {
+		return false
+	}
+	tr.marks = append(tr.marks, jinjaMark{offset: out.Len(), index: index})
+	return true
+}
+
+// renderNodes 渲染语句块
+func renderNodes(nodes []jinjaNode, scope *jinjaScope, out *strings.Builder) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *textNode:
+			out.WriteString(n.text)
+
+		case *outputNode:
+			v, err := evalExpr(n.expr, scope)
+			if err != nil {
+				return err
+			}
+			out.WriteString(toString(v))
+
+		case *ifNode:
+			matched := false
+			for i, cond := range n.conds {
+				v, err := evalExpr(cond, scope)
+				if err != nil {
+					return err
+				}
+				if truthy(v) {
+					if err := renderNodes(n.bodies[i], scope, out); err != nil {
+						return err
+					}
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				if err := renderNodes(n.elseBody, scope, out); err != nil {
+					return err
+				}
+			}
+
+		case *forNode:
+			if err := renderFor(n, scope, out); err != nil {
+				return err
+			}
+
+		case *setNode:
+			var value interface{}
+			if n.expr != nil {
+				v, err := evalExpr(n.expr, scope)
+				if err != nil {
+					return err
+				}
+				value = v
+			} else {
+				var body strings.Builder
+				if err := renderNodes(n.body, scope, &body); err != nil {
+					return err
+				}
+				value = body.String()
+			}
+			if n.attr == "" {
+				scope.vars[n.target] = value
+				continue
+			}
+			target, _ := scope.lookup(n.target)
+			ns, ok := target.(*jinjaDict)
+			if !ok {
+				return fmt.Errorf("cannot assign attribute on %s", typeName(target))
+			}
+			ns.set(n.attr, value)
+
+		case *macroNode:
+			scope.vars[n.name] = &jinjaMacro{node: n, scope: scope}
+
+		case *breakNode:
+			return errLoopBreak
+		case *continueNode:
+			return errLoopContinue
+		}
+	}
+	return nil
+}
+
+// renderFor 渲染for循环，过滤条件在计算loop变量之前应用
+func renderFor(n *forNode, scope *jinjaScope, out *strings.Builder) error {
+	iterable, err := evalExpr(n.iter, scope)
+	if err != nil {
+		return err
+	}
+	all, err := iterate(iterable)
+	if err != nil {
+		return err
+	}
+
+	items := all
+	if n.filter != nil {
+		items = nil
+		for _, item := range all {
+			itemScope := scope.child()
+			if err := bindTargets(itemScope, n.targets, item); err != nil {
+				return err
+			}
+			v, err := evalExpr(n.filter, itemScope)
+			if err != nil {
+				return err
+			}
+			if truthy(v) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if len(items) == 0 {
+		return renderNodes(n.elseBody, scope, out)
+	}
+	loop := &jinjaLoop{items: items}
+	tracked := false
+	defer func() {
+		if tracked {
+			scope.tracker.marks = append(scope.tracker.marks, jinjaMark{offset: out.Len(), index: -1})
+		}
+	}()
+	for i, item := range items {
+		loop.index = i
+		if scope.tracker.mark(out, trackKey(item)) {
+			tracked = true
+		}
+		itemScope := scope.child()
+		if err := bindTargets(itemScope, n.targets, item); err != nil {
+			return err
+		}
+		itemScope.vars["loop"] = loop
+		err := renderNodes(n.body, itemScope, out)
+		if err == errLoopBreak {
+			break
+		}
+		if err != nil && err != errLoopContinue {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackKey 只有指针类型的元素（dict）可以被跟踪，其余元素不能作为map的键或不唯一
+func trackKey(item interface{}) interface{} {
+	if dict, ok := item.(*jinjaDict); ok {
+		return dict
+	}
+	return nil
+}
+
+// bindTargets 绑定循环变量，多个变量时解包元素
+func bindTargets(scope *jinjaScope, targets []string, item interface{}) error {
+	if len(targets) == 1 {
+		scope.vars[targets[0]] = item
+		return nil
+	}
+	values, ok := item.([]interface{})
+	if !ok || len(values) != len(targets) {
+		return fmt.Errorf("cannot unpack %s into %d values", typeName(item), len(targets))
+	}
+	for i, target := range targets {
+		scope.vars[target] = values[i]
+	}
+	return nil
+}
+
+// evalExpr 对表达式求值
+func evalExpr(expr jinjaExpr, scope *jinjaScope) (interface{}, error) {
+	switch e := expr.(type) {
+	case *literalExpr:
+		return e.value, nil
+
+	case *nameExpr:
+		if v, ok := scope.lookup(e.name); ok {
+			return v, nil
+		}
+		return undefined, nil
+
+	case *listExpr:
+		return evalList(e.items, scope)
+
+	case *dictExpr:
+		dict := newJinjaDict()
+		for i := range e.keys {
+			key, err := evalExpr(e.keys[i], scope)
+			if err != nil {
+				return nil, err
+			}
+			value, err := evalExpr(e.values[i], scope)
+			if err != nil {
+				return nil, err
+			}
+			dict.set(toString(key), value)
+		}
+		return dict, nil
+
+	case *attrExpr:
+		obj, err := evalExpr(e.obj, scope)
+		if err != nil {
+			return nil, err
+		}
+		return getAttr(obj, e.name), nil
+
+	case *indexExpr:
+		obj, err := evalExpr(e.obj, scope)
+		if err != nil {
+			return nil, err
+		}
+		index, err := evalExpr(e.index, scope)
+		if err != nil {
+			return nil, err
+		}
+		return getItem(obj, index), nil
+
+	case *sliceExpr:
+		return evalSlice(e, scope)
+
+	case *callExpr:
+		args, err := evalList(e.args, scope)
+		if err != nil {
+			return nil, err
+		}
+		kwargs, err := evalKwargs(e.kwargs, scope)
+		if err != nil {
+			return nil, err
+		}
+		if attr, ok := e.fn.(*attrExpr); ok {
+			obj, err := evalExpr(attr.obj, scope)
+			if err != nil {
+				return nil, err
+			}
+			if fn := getAttr(obj, attr.name); isCallable(fn) {
+				return callValue(fn, args, kwargs)
+			}
+			return callMethod(obj, attr.name, args, kwargs)
+		}
+		fn, err := evalExpr(e.fn, scope)
+		if err != nil {
+			return nil, err
+		}
+		return callValue(fn, args, kwargs)
+
+	case *filterExpr:
+		value, err := evalExpr(e.expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		args, err := evalList(e.args, scope)
+		if err != nil {
+			return nil, err
+		}
+		kwargs, err := evalKwargs(e.kwargs, scope)
+		if err != nil {
+			return nil, err
+		}
+		return applyFilter(e.name, value, args, kwargs)
+
+	case *testExpr:
+		value, err := evalExpr(e.expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		args, err := evalList(e.args, scope)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := applyTest(e.name, value, args)
+		if err != nil {
+			return nil, err
+		}
+		return ok != e.negate, nil
+
+	case *unaryExpr:
+		x, err := evalExpr(e.x, scope)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "not":
+			return !truthy(x), nil
+		case "-":
+			return binaryOp("-", 0, x)
+		}
+		return x, nil
+
+	case *binaryExpr:
+		left, err := evalExpr(e.left, scope)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "and":
+			if !truthy(left) {
+				return left, nil
+			}
+			return evalExpr(e.right, scope)
+		case "or":
+			if truthy(left) {
+				return left, nil
+			}
+			return evalExpr(e.right, scope)
+		}
+		right, err := evalExpr(e.right, scope)
+		if err != nil {
+			return nil, err
+		}
+		return binaryOp(e.op, left, right)
+
+	case *condExpr:
+		cond, err := evalExpr(e.cond, scope)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(cond) {
+			return evalExpr(e.then, scope)
+		}
+		if e.otherwise == nil {
+			return undefined, nil
+		}
+		return evalExpr(e.otherwise, scope)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func evalList(exprs []jinjaExpr, scope *jinjaScope) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(exprs))
+	for _, expr := range exprs {
+		v, err := evalExpr(expr, scope)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func evalKwargs(kwargs []jinjaKwarg, scope *jinjaScope) (map[string]interface{}, error) {
+	if len(kwargs) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]interface{}, len(kwargs))
+	for _, kwarg := range kwargs {
+		v, err := evalExpr(kwarg.value, scope)
+		if err != nil {
+			return nil, err
+		}
+		values[kwarg.name] = v
+	}
+	return values, nil
+}
+
+// evalSlice Python切片 obj[start:stop:step]，支持列表与字符串
+func evalSlice(e *sliceExpr, scope *jinjaScope) (interface{}, error) {
+	obj, err := evalExpr(e.obj, scope)
+	if err != nil {
+		return nil, err
+	}
+	bounds := [3]*int{}
+	for i, part := range []jinjaExpr{e.start, e.stop, e.step} {
+		if part == nil {
+			continue
+		}
+		v, err := evalExpr(part, scope)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		n, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("slice indices must be integers, got %s", typeName(v))
+		}
+		bounds[i] = &n
+	}
+
+	var items []interface{}
+	runes, isString := []rune(nil), false
+	switch o := obj.(type) {
+	case []interface{}:
+		items = o
+	case string:
+		runes, isString = []rune(o), true
+		items = make([]interface{}, len(runes))
+	default:
+		return nil, fmt.Errorf("%s object is not subscriptable", typeName(obj))
+	}
+
+	indices, err := sliceIndices(len(items), bounds[0], bounds[1], bounds[2])
+	if err != nil {
+		return nil, err
+	}
+	if isString {
+		out := make([]rune, len(indices))
+		for i, index := range indices {
+			out[i] = runes[index]
+		}
+		return string(out), nil
+	}
+	out := make([]interface{}, len(indices))
+	for i, index := range indices {
+		out[i] = items[index]
+	}
+	return out, nil
+}
+
+// sliceIndices 按Python规则计算切片选中的下标
+func sliceIndices(length int, start, stop, step *int) ([]int, error) {
+	s := 1
+	if step != nil {
+		s = *step
+	}
+	if s == 0 {
+		return nil, fmt.Errorf("slice step cannot be zero")
+	}
+	clamp := func(p *int, def, lower, upper int) int {
+		if p == nil {
+			return def
+		}
+		i := *p
+		if i < 0 {
+			i += length
+		}
+		if i < lower {
+			return lower
+		}
+		if i > upper {
+			return upper
+		}
+		return i
+	}
+
+	var indices []int
+	if s > 0 {
+		for i := clamp(start, 0, 0, length); i < clamp(stop, length, 0, length); i += s {
+			indices = append(indices, i)
+		}
+	} else {
+		for i := clamp(start, length-1, -1, length-1); i > clamp(stop, -1, -1, length-1); i += s {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
+
+// getAttr 属性访问 obj.name
+func getAttr(obj interface{}, name string) interface{} {
+	switch o := obj.(type) {
+	case *jinjaDict:
+		if v, ok := o.get(name); ok {
+			return v
+		}
+	case *jinjaLoop:
+		n := len(o.items)
+		switch name {
+		case "index":
+			return o.index + 1
+		case "index0":
+			return o.index
+		case "revindex":
+			return n - o.index
+		case "revindex0":
+			return n - o.index - 1
+		case "first":
+			return o.index == 0
+		case "last":
+			return o.index == n-1
+		case "length":
+			return n
+		case "depth":
+			return 1
+		case "depth0":
+			return 0
+		case "previtem":
+			if o.index > 0 {
+				return o.items[o.index-1]
+			}
+		case "nextitem":
+			if o.index < n-1 {
+				return o.items[o.index+1]
+			}
+		}
+	case []interface{}:
+		if i, err := strconv.Atoi(name); err == nil {
+			return getItem(o, i)
+		}
+	}
+	return undefined
+}
+
+// getItem 下标访问 obj[index]，列表与字符串支持负数下标
+func getItem(obj, index interface{}) interface{} {
+	switch o := obj.(type) {
+	case *jinjaDict:
+		if key, ok := index.(string); ok {
+			if v, ok := o.get(key); ok {
+				return v
+			}
+		}
+	case []interface{}:
+		if i, ok := index.(int); ok {
+			if i < 0 {
+				i += len(o)
+			}
+			if i >= 0 && i < len(o) {
+				return o[i]
+			}
+		}
+	case string:
+		if i, ok := index.(int); ok {
+			runes := []rune(o)
+			if i < 0 {
+				i += len(runes)
+			}
+			if i >= 0 && i < len(runes) {
+				return string(runes[i])
+			}
+		}
+	case *jinjaLoop:
+		if name, ok := index.(string); ok {
+			return getAttr(o, name)
+		}
+	}
+	return undefined
+}
+
+func isCallable(v interface{}) bool {
+	switch v.(type) {
+	case jinjaFunc, *jinjaMacro:
+		return true
+	}
+	return false
+}
+
+// callValue 调用函数或宏
+func callValue(fn interface{}, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	switch f := fn.(type) {
+	case jinjaFunc:
+		return f(args, kwargs)
+	case *jinjaMacro:
+		return f.call(args, kwargs)
+	}
+	return nil, fmt.Errorf("%s object is not callable", typeName(fn))
+}
+
+// call 渲染宏，未传入且没有默认值的参数为未定义值
+func (m *jinjaMacro) call(args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	if len(args) > len(m.node.params) {
+		return nil, fmt.Errorf("macro %s takes %d arguments, got %d", m.node.name, len(m.node.params), len(args))
+	}
+	scope := m.scope.child()
+	for i, param := range m.node.params {
+		if i < len(args) {
+			scope.vars[param] = args[i]
+		} else if v, ok := kwargs[param]; ok {
+			scope.vars[param] = v
+		} else if m.node.defaults[i] != nil {
+			v, err := evalExpr(m.node.defaults[i], scope)
+			if err != nil {
+				return nil, err
+			}
+			scope.vars[param] = v
+		} else {
+			scope.vars[param] = undefined
+		}
+	}
+	var out strings.Builder
+	if err := renderNodes(m.node.body, scope, &out); err != nil {
+		return nil, err
+	}
+	return out.String(), nil
+}
+
+// jinjaGlobals 模板可用的全局函数，与HF apply_chat_template提供的一致
+func jinjaGlobals() map[string]interface{} {
+	return map[string]interface{}{
+		"range": jinjaFunc(func(args []interface{}, _ map[string]interface{}) (interface{}, error) {
+			bounds := make([]int, len(args))
+			for i, arg := range args {
+				n, ok := arg.(int)
+				if !ok {
+					return nil, fmt.Errorf("range() arguments must be integers")
+				}
+				bounds[i] = n
+			}
+			start, stop, step := 0, 0, 1
+			switch len(bounds) {
+			case 1:
+				stop = bounds[0]
+			case 2:
+				start, stop = bounds[0], bounds[1]
+			case 3:
+				start, stop, step = bounds[0], bounds[1], bounds[2]
+			default:
+				return nil, fmt.Errorf("range() takes 1 to 3 arguments")
+			}
+			indices, err := sliceIndices(stop, &start, &stop, &step)
+			if err != nil || start < 0 {
+				return nil, fmt.Errorf("invalid range(%d, %d, %d)", start, stop, step)
+			}
+			items := make([]interface{}, len(indices))
+			for i, index := range indices {
+				items[i] = index
+			}
+			return items, nil
+		}),
+		"namespace": jinjaFunc(newNamespace),
+		"dict":      jinjaFunc(newNamespace),
+		"raise_exception": jinjaFunc(func(args []interface{}, _ map[string]interface{}) (interface{}, error) {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("template raised an exception")
+			}
+			return nil, fmt.Errorf("%s", toString(args[0]))
+		}),
+		"strftime_now": jinjaFunc(func(args []interface{}, _ map[string]interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("strftime_now() takes 1 argument")
+			}
+			return strftime(time.Now(), toString(args[0])), nil
+		}),
+	}
+}
+
+// newNamespace namespace()/dict()：以位置参数中的字典与关键字参数构造字典
+func newNamespace(args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	ns := newJinjaDict()
+	for _, arg := range args {
+		d, ok := arg.(*jinjaDict)
+		if !ok {
+			return nil, fmt.Errorf("namespace() positional arguments must be mappings")
+		}
+		for _, key := range d.keys {
+			ns.set(key, d.values[key])
+		}
+	}
+	for _, key := range sortedKeys(kwargs) {
+		ns.set(key, kwargs[key])
+	}
+	return ns, nil
+}
+
+// strftime 按C strftime格式化时间，支持chat template中常见的指令
+func strftime(t time.Time, format string) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			sb.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'd':
+			sb.WriteString(t.Format("02"))
+		case 'e':
+			sb.WriteString(t.Format("_2"))
+		case 'm':
+			sb.WriteString(t.Format("01"))
+		case 'y':
+			sb.WriteString(t.Format("06"))
+		case 'Y':
+			sb.WriteString(t.Format("2006"))
+		case 'b':
+			sb.WriteString(t.Format("Jan"))
+		case 'B':
+			sb.WriteString(t.Format("January"))
+		case 'a':
+			sb.WriteString(t.Format("Mon"))
+		case 'A':
+			sb.WriteString(t.Format("Monday"))
+		case 'H':
+			sb.WriteString(t.Format("15"))
+		case 'I':
+			sb.WriteString(t.Format("03"))
+		case 'M':
+			sb.WriteString(t.Format("04"))
+		case 'S':
+			sb.WriteString(t.Format("05"))
+		case 'p':
+			sb.WriteString(t.Format("PM"))
+		case 'j':
+			sb.WriteString(fmt.Sprintf("%03d", t.YearDay()))
+		case '%':
+			sb.WriteByte('%')
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(format[i])
+		}
+	}
+	return sb.String()
+}
+
+// iterate 可迭代对象的元素：列表、字典的键、字符串的字符，未定义值视为空
+func iterate(v interface{}) ([]interface{}, error) {
+	switch o := v.(type) {
+	case []interface{}:
+		return o, nil
+	case *jinjaDict:
+		keys := make([]interface{}, len(o.keys))
+		for i, key := range o.keys {
+			keys[i] = key
+		}
+		return keys, nil
+	case string:
+		var chars []interface{}
+		for _, r := range o {
+			chars = append(chars, string(r))
+		}
+		return chars, nil
+	case jinjaUndefined:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%s object is not iterable", typeName(v))
+}
+
+// truthy Python的真值判断
+func truthy(v interface{}) bool {
+	switch o := v.(type) {
+	case nil, jinjaUndefined:
+		return false
+	case bool:
+		return o
+	case int:
+		return o != 0
+	case float64:
+		return o != 0
+	case string:
+		return o != ""
+	case []interface{}:
+		return len(o) > 0
+	case *jinjaDict:
+		return len(o.keys) > 0
+	}
+	return true
+}
+
+// typeName 错误信息中使用的Python类型名
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "NoneType"
+	case jinjaUndefined:
+		return "Undefined"
+	case bool:
+		return "bool"
+	case int:
+		return "int"
+	case float64:
+		return "float"
+	case string:
+		return "str"
+	case []interface{}:
+		return "list"
+	case *jinjaDict:
+		return "dict"
+	case jinjaFunc, *jinjaMacro:
+		return "function"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// toString Python的str()，输出标签使用
+func toString(v interface{}) string {
+	switch o := v.(type) {
+	case jinjaUndefined:
+		return ""
+	case string:
+		return o
+	}
+	return pyRepr(v)
+}
+
+// pyRepr Python的repr()，用于列表、字典等容器的字符串形式
+func pyRepr(v interface{}) string {
+	switch o := v.(type) {
+	case nil:
+		return "None"
+	case jinjaUndefined:
+		return ""
+	case bool:
+		if o {
+			return "True"
+		}
+		return "False"
+	case int:
+		return strconv.Itoa(o)
+	case float64:
+		return pyFloat(o)
+	case string:
+		return pyQuote(o)
+	case []interface{}:
+		parts := make([]string, len(o))
+		for i, item := range o {
+			parts[i] = pyRepr(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *jinjaDict:
+		parts := make([]string, len(o.keys))
+		for i, key := range o.keys {
+			parts[i] = pyQuote(key) + ": " + pyRepr(o.values[key])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case *jinjaMacro:
+		return "<Macro '" + o.node.name + "'>"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// pyFloat 与Python repr(float)一致的浮点数格式
+func pyFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-4 || abs >= 1e16) {
+		return strconv.FormatFloat(f, 'e', -1, 64)
+	}
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// pyQuote Python风格的字符串字面量
+func pyQuote(s string) string {
+	quote := byte('\'')
+	if strings.Contains(s, "'") && !strings.Contains(s, "\"") {
+		quote = '"'
+	}
+	var sb strings.Builder
+	sb.WriteByte(quote)
+	for _, r := range s {
+		switch {
+		case r == rune(quote) || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\x%02x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte(quote)
+	return sb.String()
+}
+
+// toFloat 数值转换为float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// binaryOp 二元运算，数值运算在两侧都是整数时保持整数
+func binaryOp(op string, left, right interface{}) (interface{}, error) {
+	switch op {
+	case "~":
+		return toString(left) + toString(right), nil
+	case "==":
+		return jinjaEqual(left, right), nil
+	case "!=":
+		return !jinjaEqual(left, right), nil
+	case "in", "not in":
+		ok, err := contains(right, left)
+		if err != nil {
+			return nil, err
+		}
+		return ok == (op == "in"), nil
+	case "<", ">", "<=", ">=":
+		c, err := compareValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "<":
+			return c < 0, nil
+		case ">":
+			return c > 0, nil
+		case "<=":
+			return c <= 0, nil
+		}
+		return c >= 0, nil
+	}
+
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok && op == "+" {
+			return l + r, nil
+		}
+		if r, ok := right.(int); ok && op == "*" {
+			if r < 0 {
+				r = 0
+			}
+			return strings.Repeat(l, r), nil
+		}
+	case []interface{}:
+		if r, ok := right.([]interface{}); ok && op == "+" {
+			return append(append([]interface{}{}, l...), r...), nil
+		}
+	}
+
+	li, lInt := left.(int)
+	ri, rInt := right.(int)
+	lf, lNum := toFloat(left)
+	rf, rNum := toFloat(right)
+	if !lNum || !rNum {
+		return nil, fmt.Errorf("unsupported operand types for %s: %s and %s", op, typeName(left), typeName(right))
+	}
+	bothInt := lInt && rInt
+	switch op {
+	case "+":
+		if bothInt {
+			return li + ri, nil
+		}
+		return lf + rf, nil
+	case "-":
+		if bothInt {
+			return li - ri, nil
+		}
+		return lf - rf, nil
+	case "*":
+		if bothInt {
+			return li * ri, nil
+		}
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return lf / rf, nil
+	case "//":
+		if rf == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		if bothInt {
+			q := li / ri
+			if (li%ri != 0) && ((li < 0) != (ri < 0)) {
+				q--
+			}
+			return q, nil
+		}
+		return math.Floor(lf / rf), nil
+	case "%":
+		if rf == 0 {
+			return nil, fmt.Errorf("modulo by zero")
+		}
+		if bothInt {
+			m := li % ri
+			if m != 0 && (m < 0) != (ri < 0) {
+				m += ri
+			}
+			return m, nil
+		}
+		m := math.Mod(lf, rf)
+		if m != 0 && (m < 0) != (rf < 0) {
+			m += rf
+		}
+		return m, nil
+	case "**":
+		if bothInt && ri >= 0 {
+			result := 1
+			for i := 0; i < ri; i++ {
+				result *= li
+			}
+			return result, nil
+		}
+		return math.Pow(lf, rf), nil
+	}
+	return nil, fmt.Errorf("unsupported operator %s", op)
+}
+
+// jinjaEqual Python的 == 比较
+func jinjaEqual(a, b interface{}) bool {
+	if af, ok := a.(float64); ok {
+		bf, ok := toFloat(b)
+		return ok && isNumber(b) && af == bf
+	}
+	if bf, ok := b.(float64); ok {
+		af, ok := toFloat(a)
+		return ok && isNumber(a) && af == bf
+	}
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jinjaEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case *jinjaDict:
+		y, ok := b.(*jinjaDict)
+		if !ok || len(x.keys) != len(y.keys) {
+			return false
+		}
+		for _, key := range x.keys {
+			v, ok := y.get(key)
+			if !ok || !jinjaEqual(x.values[key], v) {
+				return false
+			}
+		}
+		return true
+	case jinjaFunc:
+		return false
+	}
+	if _, ok := b.(jinjaFunc); ok {
+		return false
+	}
+	return a == b
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, float64:
+		return true
+	}
+	return false
+}
+
+// compareValues 比较数值或字符串的大小
+func compareValues(a, b interface{}) (int, error) {
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			return strings.Compare(as, bs), nil
+		}
+	} else if isNumber(a) && isNumber(b) {
+		af, _ := toFloat(a)
+		bf, _ := toFloat(b)
+		switch {
+		case af < bf:
+			return -1, nil
+		case af > bf:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", typeName(a), typeName(b))
+}
+
+// contains Python的 item in container
+func contains(container, item interface{}) (bool, error) {
+	switch c := container.(type) {
+	case string:
+		s, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("'in <string>' requires string as left operand, not %s", typeName(item))
+		}
+		return strings.Contains(c, s), nil
+	case []interface{}:
+		for _, v := range c {
+			if jinjaEqual(v, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *jinjaDict:
+		key, ok := item.(string)
+		if !ok {
+			return false, nil
+		}
+		_, exists := c.get(key)
+		return exists, nil
+	case jinjaUndefined:
+		return false, nil
+	}
+	return false, fmt.Errorf("argument of type %s is not iterable", typeName(container))
+}
+
+// callMethod 字符串、字典与loop对象上的方法调用
+func callMethod(obj interface{}, name string, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	switch o := obj.(type) {
+	case string:
+		return callStringMethod(o, name, args)
+	case *jinjaDict:
+		switch name {
+		case "items":
+			return o.items(), nil
+		case "keys":
+			return iterate(o)
+		case "values":
+			values := make([]interface{}, len(o.keys))
+			for i, key := range o.keys {
+				values[i] = o.values[key]
+			}
+			return values, nil
+		case "get":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("get() takes at least 1 argument")
+			}
+			if key, ok := args[0].(string); ok {
+				if v, ok := o.get(key); ok {
+					return v, nil
+				}
+			}
+			if len(args) > 1 {
+				return args[1], nil
+			}
+			return nil, nil
+		case "update":
+			for _, arg := range args {
+				if d, ok := arg.(*jinjaDict); ok {
+					for _, key := range d.keys {
+						o.set(key, d.values[key])
+					}
+				}
+			}
+			for _, key := range sortedKeys(kwargs) {
+				o.set(key, kwargs[key])
+			}
+			return nil, nil
+		}
+	case *jinjaLoop:
+		if name == "cycle" && len(args) > 0 {
+			return args[o.index%len(args)], nil
+		}
+	case []interface{}:
+		switch name {
+		case "index":
+			for i, v := range o {
+				if len(args) > 0 && jinjaEqual(v, args[0]) {
+					return i, nil
+				}
+			}
+			return nil, fmt.Errorf("value is not in list")
+		case "count":
+			count := 0
+			for _, v := range o {
+				if len(args) > 0 && jinjaEqual(v, args[0]) {
+					count++
+				}
+			}
+			return count, nil
+		}
+	}
+	return nil, fmt.Errorf("%s object has no method %q", typeName(obj), name)
+}
+
+// callStringMethod Python字符串方法
+func callStringMethod(s, name string, args []interface{}) (interface{}, error) {
+	strArg := func(i int) (string, bool) {
+		if i >= len(args) {
+			return "", false
+		}
+		v, ok := args[i].(string)
+		return v, ok
+	}
+
+	switch name {
+	case "strip", "lstrip", "rstrip":
+		chars, custom := strArg(0)
+		trim := func(s string) string {
+			switch {
+			case custom && name == "strip":
+				return strings.Trim(s, chars)
+			case custom && name == "lstrip":
+				return strings.TrimLeft(s, chars)
+			case custom:
+				return strings.TrimRight(s, chars)
+			case name == "strip":
+				return strings.TrimSpace(s)
+			case name == "lstrip":
+				return strings.TrimLeftFunc(s, unicode.IsSpace)
+			}
+			return strings.TrimRightFunc(s, unicode.IsSpace)
+		}
+		return trim(s), nil
+	case "split", "rsplit":
+		sep, hasSep := strArg(0)
+		maxSplit := -1
+		if len(args) > 1 {
+			if n, ok := args[1].(int); ok {
+				maxSplit = n
+			}
+		}
+		var parts []string
+		switch {
+		case !hasSep:
+			parts = strings.Fields(s)
+		case maxSplit < 0:
+			parts = strings.Split(s, sep)
+		case name == "split":
+			parts = strings.SplitN(s, sep, maxSplit+1)
+		default:
+			parts = rsplitN(s, sep, maxSplit+1)
+		}
+		items := make([]interface{}, len(parts))
+		for i, part := range parts {
+			items[i] = part
+		}
+		return items, nil
+	case "splitlines":
+		var items []interface{}
+		for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+			items = append(items, strings.TrimSuffix(line, "\r"))
+		}
+		if s == "" {
+			items = []interface{}{}
+		}
+		return items, nil
+	case "startswith", "endswith":
+		var candidates []interface{}
+		if len(args) > 0 {
+			if list, ok := args[0].([]interface{}); ok {
+				candidates = list
+			} else {
+				candidates = args[:1]
+			}
+		}
+		for _, c := range candidates {
+			prefix, ok := c.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s() argument must be str", name)
+			}
+			if name == "startswith" && strings.HasPrefix(s, prefix) || name == "endswith" && strings.HasSuffix(s, prefix) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "upper":
+		return strings.ToUpper(s), nil
+	case "lower":
+		return strings.ToLower(s), nil
+	case "title":
+		return pyTitle(s), nil
+	case "capitalize":
+		return pyCapitalize(s), nil
+	case "replace":
+		old, ok1 := strArg(0)
+		replacement, ok2 := strArg(1)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("replace() arguments must be str")
+		}
+		count := -1
+		if len(args) > 2 {
+			if n, ok := args[2].(int); ok {
+				count = n
+			}
+		}
+		return strings.Replace(s, old, replacement, count), nil
+	case "find", "rfind", "count":
+		sub, ok := strArg(0)
+		if !ok {
+			return nil, fmt.Errorf("%s() argument must be str", name)
+		}
+		var i int
+		switch name {
+		case "count":
+			return strings.Count(s, sub), nil
+		case "find":
+			i = strings.Index(s, sub)
+		default:
+			i = strings.LastIndex(s, sub)
+		}
+		if i < 0 {
+			return -1, nil
+		}
+		return utf8.RuneCountInString(s[:i]), nil
+	case "join":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("join() takes 1 argument")
+		}
+		items, err := iterate(args[0])
+		if err != nil {
+			return nil, err
+		}
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = toString(item)
+		}
+		return strings.Join(parts, s), nil
+	case "isdigit", "isspace", "isalpha", "isalnum":
+		if s == "" {
+			return false, nil
+		}
+		for _, r := range s {
+			if name == "isdigit" && !unicode.IsDigit(r) || name == "isspace" && !unicode.IsSpace(r) ||
+				name == "isalpha" && !unicode.IsLetter(r) || name == "isalnum" && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return nil, fmt.Errorf("str object has no method %q", name)
+}
+
+// rsplitN 从右侧开始最多切分为n段
+func rsplitN(s, sep string, n int) []string {
+	var parts []string
+	for len(parts) < n-1 {
+		i := strings.LastIndex(s, sep)
+		if i < 0 {
+			break
+		}
+		parts = append([]string{s[i+len(sep):]}, parts...)
+		s = s[:i]
+	}
+	return append([]string{s}, parts...)
+}
+
+// pyTitle Python的str.title()：每个字母序列首字母大写，其余小写
+func pyTitle(s string) string {
+	var sb strings.Builder
+	prevLetter := false
+	for _, r := range s {
+		if prevLetter {
+			sb.WriteRune(unicode.ToLower(r))
+		} else {
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+		prevLetter = unicode.IsLetter(r)
+	}
+	return sb.String()
+}
+
+// pyCapitalize Python的str.capitalize()
+func pyCapitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + strings.ToLower(s[size:])
+}
+
+// decodeJinjaValue 将JSON解码为模板值，对象保持键的顺序，整数保持为int
+func decodeJinjaValue(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeJinjaToken(dec)
+	if err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func decodeJinjaToken(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			dict := newJinjaDict()
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeJinjaToken(dec)
+				if err != nil {
+					return nil, err
+				}
+				dict.set(keyTok.(string), value)
+			}
+			_, err := dec.Token()
+			return dict, err
+		case '[':
+			list := []interface{}{}
+			for dec.More() {
+				value, err := decodeJinjaToken(dec)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			_, err := dec.Token()
+			return list, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	case json.Number:
+		if i, err := strconv.Atoi(t.String()); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+	return tok, nil
+}
+
+// writeJSON 与Python json.dumps(ensure_ascii=False)一致的序列化，indent为空时使用单行格式
+func writeJSON(sb *strings.Builder, v interface{}, indent string, depth int) error {
+	newline := func(d int) {
+		if indent != "" {
+			sb.WriteByte('\n')
+			sb.WriteString(strings.Repeat(indent, d))
+		}
+	}
+	separator := ", "
+	if indent != "" {
+		separator = ","
+	}
+
+	switch o := v.(type) {
+	case nil, jinjaUndefined:
+		sb.WriteString("null")
+	case bool:
+		sb.WriteString(strconv.FormatBool(o))
+	case int:
+		sb.WriteString(strconv.Itoa(o))
+	case float64:
+		switch {
+		case math.IsNaN(o):
+			sb.WriteString("NaN")
+		case math.IsInf(o, 1):
+			sb.WriteString("Infinity")
+		case math.IsInf(o, -1):
+			sb.WriteString("-Infinity")
+		default:
+			sb.WriteString(pyFloat(o))
+		}
+	case string:
+		writeJSONString(sb, o)
+	case []interface{}:
+		if len(o) == 0 {
+			sb.WriteString("[]")
+			return nil
+		}
+		sb.WriteByte('[')
+		for i, item := range o {
+			if i > 0 {
+				sb.WriteString(separator)
+			}
+			newline(depth + 1)
+			if err := writeJSON(sb, item, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		sb.WriteByte(']')
+	case *jinjaDict:
+		if len(o.keys) == 0 {
+			sb.WriteString("{}")
+			return nil
+		}
+		sb.WriteByte('{')
+		for i, key := range o.keys {
+			if i > 0 {
+				sb.WriteString(separator)
+			}
+			newline(depth + 1)
+			writeJSONString(sb, key)
+			sb.WriteString(": ")
+			if err := writeJSON(sb, o.values[key], indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		sb.WriteByte('}')
+	default:
+		return fmt.Errorf("object of type %s is not JSON serializable", typeName(v))
+	}
+	return nil
+}
+
+// writeJSONString 写入JSON字符串，只转义引号、反斜杠与控制字符
+func writeJSONString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
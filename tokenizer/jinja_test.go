@@ -0,0 +1,162 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestJinjaRender 测试chat template常用的Jinja语法
+func TestJinjaRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		vars     string
+		want     string
+	}{
+		{"output", `{{ name }}-{{ 1 + 2 * 3 }}-{{ 7 // 2 }}-{{ 7 / 2 }}-{{ -7 % 3 }}`, `{"name": "x"}`, "x-7-3-3.5-2"},
+		{"python str", `{{ [1, 'a', none, true] }}|{{ {'k': 1.0} }}|{{ missing }}`, `{}`, "[1, 'a', None, True]|{'k': 1.0}|"},
+		{"trim blocks", "{% if true %}\n  yes\n{% endif %}\nend", `{}`, "  yes\nend"},
+		{"lstrip blocks", "a\n    {% if true %}\nb\n    {% endif %}\nc", `{}`, "a\nb\nc"},
+		{"whitespace control", "a  {{- ' b ' -}}  c", `{}`, "a b c"},
+		{"comment and raw", "{# note #}{% raw %}{{ x }}{% endraw %}", `{}`, "{{ x }}"},
+		{"elif", `{% for n in [1, 2, 3] %}{% if n == 1 %}one{% elif n == 2 %}two{% else %}many{% endif %} {% endfor %}`, `{}`, "one two many "},
+		{"loop vars", `{% for x in items %}{{ loop.index }}{{ x }}{% if not loop.last %},{% endif %}{% endfor %}`, `{"items": ["a", "b"]}`, "1a,2b"},
+		{"loop filter and else", `{% for x in items if x > 1 %}{{ loop.length }}{{ x }}{% else %}empty{% endfor %}|{% for x in [] %}x{% else %}empty{% endfor %}`, `{"items": [1, 2, 3]}`, "2223|empty"},
+		{"break continue", `{% for x in range(10) %}{% if x == 1 %}{% continue %}{% endif %}{% if x > 3 %}{% break %}{% endif %}{{ x }}{% endfor %}`, `{}`, "023"},
+		{"scoping", `{% set x = 1 %}{% for i in [1] %}{% set x = 2 %}{% endfor %}{{ x }}`, `{}`, "1"},
+		{"namespace", `{% set ns = namespace(found=false) %}{% for i in [1, 2] %}{% if i == 2 %}{% set ns.found = true %}{% endif %}{% endfor %}{{ ns.found }}`, `{}`, "True"},
+		{"set block", `{% set body %}[{{ 1 }}]{% endset %}{{ body }}`, `{}`, "[1]"},
+		{"macro", `{% macro greet(name, punct='!') %}Hi {{ name }}{{ punct }}{% endmacro %}{{ greet('a') }} {{ greet('b', punct='?') }}`, `{}`, "Hi a! Hi b?"},
+		{"tests", `{{ x is defined }} {{ y is defined }} {{ x is string }} {{ n is none }} {{ 4 is divisibleby 2 }} {{ x is not mapping }}`, `{"x": "s", "n": null}`, "True False True True True True"},
+		{"in", `{{ 'a' in 'cat' }} {{ 2 not in [1, 2] }} {{ 'k' in d }}`, `{"d": {"k": 1}}`, "True False True"},
+		{"attr and index", `{{ m.role }} {{ m['content'] }} {{ items[-1] }} {{ items[1:] }} {{ items[::-1] }} {{ 'abc'[1] }}`, `{"m": {"role": "user", "content": "hi"}, "items": [1, 2, 3]}`, "user hi 3 [2, 3] [3, 2, 1] b"},
+		{"string methods", `{{ s.strip() }}|{{ s.split('</think>')[-1].lstrip('\n') }}|{{ 'a,b'.split(',') }}|{{ s.startswith(' x') }}|{{ 'hello world'.title() }}`, `{"s": " x</think>\n\ny "}`, "x</think>\n\ny|y |['a', 'b']|True|Hello World"},
+		{"dict methods", `{% for k, v in d.items() %}{{ k }}={{ v }};{% endfor %}{{ d.get('z', 0) }}`, `{"d": {"b": 1, "a": 2}}`, "b=1;a=2;0"},
+		{"filters", `{{ '  x ' | trim }}|{{ items | length }}|{{ items | join(', ') }}|{{ missing | default('d') }}|{{ 'ab' | upper }}|{{ items | first }}|{{ items | last }}|{{ 3.7 | int }}`, `{"items": ["a", "b"]}`, "x|2|a, b|d|AB|a|b|3"},
+		{"select filters", `{{ msgs | selectattr('role', 'equalto', 'user') | map(attribute='content') | join }}|{{ msgs | rejectattr('role', 'eq', 'user') | list | length }}`, `{"msgs": [{"role": "user", "content": "a"}, {"role": "tool", "content": "b"}, {"role": "user", "content": "c"}]}`, "ac|1"},
+		{"tojson", `{{ tool | tojson }}|{{ {'a': [1, 2]} | tojson(indent=2) }}`, `{"tool": {"name": "get", "description": "查询\"天气\"", "parameters": {"type": "object", "required": []}}}`,
+			"{\"name\": \"get\", \"description\": \"查询\\\"天气\\\"\", \"parameters\": {\"type\": \"object\", \"required\": []}}|{\n  \"a\": [\n    1,\n    2\n  ]\n}"},
+		{"indent", `{{ 'a\nb\n\nc' | indent(2) }}|{{ 'a\nb' | indent(2, true) }}`, `{}`, "a\n  b\n\n  c|  a\n  b"},
+		{"conditional expr", `{{ 'y' if flag else 'n' }}{{ 'z' if false }}`, `{"flag": true}`, "y"},
+		{"string concat", `{{ 'a' ~ 1 ~ none }}{{ 'b' + 'c' }}`, `{}`, "a1Nonebc"},
+		{"trailing newline", "x\n", `{}`, "x"},
+	}
+
+	for _, tt := range tests {
+		template, err := parseJinja(tt.template)
+		if err != nil {
+			t.Errorf("%s: parse failed: %v", tt.name, err)
+			continue
+		}
+		vars, err := decodeJinjaValue([]byte(tt.vars))
+		if err != nil {
+			t.Fatalf("%s: invalid vars: %v", tt.name, err)
+		}
+		values := make(map[string]interface{})
+		for _, key := range vars.(*jinjaDict).keys {
+			values[key] = vars.(*jinjaDict).values[key]
+		}
+		got, err := template.render(values)
+		if err != nil {
+			t.Errorf("%s: render failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestJinjaErrors 测试语法错误与raise_exception
+func TestJinjaErrors(t *testing.T) {
+	for _, source := range []string{"{% if x %}", "{{ x ", "{% for %}{% endfor %}", "{% endif %}", "{% unknown %}"} {
+		if _, err := parseJinja(source); err == nil {
+			t.Errorf("expected parse error for %q", source)
+		}
+	}
+
+	template, err := parseJinja(`{% if messages[0].role != 'user' %}{{ raise_exception('Conversation must start with user') }}{% endif %}`)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	messages, _ := decodeJinjaValue([]byte(`[{"role": "assistant"}]`))
+	_, err = template.render(map[string]interface{}{"messages": messages})
+	if err == nil || !strings.Contains(err.Error(), "must start with user") {
+		t.Errorf("expected raised exception, got %v", err)
+	}
+}
+
+// chatTemplateCase testdata/chat_templates 中的一个用例，expected与error二选一
+type chatTemplateCase struct {
+	Name                string          `json:"name"`
+	Variables           json.RawMessage `json:"variables"`
+	Messages            json.RawMessage `json:"messages"`
+	Tools               json.RawMessage `json:"tools"`
+	AddGenerationPrompt bool            `json:"add_generation_prompt"`
+	Expected            string          `json:"expected"`
+	Error               string          `json:"error"`
+}
+
+// TestJinjaChatTemplates 用真实模型的chat template渲染对话，与transformers渲染的提示词对比
+func TestJinjaChatTemplates(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("tokenizer", "testdata", "chat_templates", "*", "chat_template.jinja"))
+	if err != nil || len(dirs) == 0 {
+		t.Fatalf("no chat template fixtures: %v", err)
+	}
+	for _, path := range dirs {
+		dir := filepath.Dir(path)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			source, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read template: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, "cases.json"))
+			if err != nil {
+				t.Fatalf("Failed to read cases: %v", err)
+			}
+			var cases []chatTemplateCase
+			if err := json.Unmarshal(data, &cases); err != nil {
+				t.Fatalf("Invalid cases: %v", err)
+			}
+			template, err := parseJinja(string(source))
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+
+			for _, tc := range cases {
+				vars := map[string]interface{}{"add_generation_prompt": tc.AddGenerationPrompt}
+				variables, err := decodeJinjaValue(tc.Variables)
+				if err != nil {
+					t.Fatalf("%s: invalid variables: %v", tc.Name, err)
+				}
+				for _, key := range variables.(*jinjaDict).keys {
+					vars[key] = variables.(*jinjaDict).values[key]
+				}
+				if vars["messages"], err = decodeJinjaValue(tc.Messages); err != nil {
+					t.Fatalf("%s: invalid messages: %v", tc.Name, err)
+				}
+				if len(tc.Tools) > 0 {
+					if vars["tools"], err = decodeJinjaValue(tc.Tools); err != nil {
+						t.Fatalf("%s: invalid tools: %v", tc.Name, err)
+					}
+				}
+
+				got, err := template.render(vars)
+				if tc.Error != "" {
+					if err == nil || err.Error() != tc.Error {
+						t.Errorf("%s: error = %v, want %q", tc.Name, err, tc.Error)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s: render failed: %v", tc.Name, err)
+				} else if got != tc.Expected {
+					t.Errorf("%s: got %q, want %q", tc.Name, got, tc.Expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// normalizedString 经过变换（规范化、字节映射等）后的文本片段，
+// alignments记录text中每个字节对应的原始文本字节区间
+type normalizedString struct {
+	text       string
+	alignments [][2]int
+}
+
+// newNormalizedString 由原始文本创建，每个字节对齐到其所在字符的区间
+func newNormalizedString(text string) *normalizedString {
+	alignments := make([][2]int, len(text))
+	for i := 0; i < len(text); {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		for j := i; j < i+size; j++ {
+			alignments[j] = [2]int{i, i + size}
+		}
+		i += size
+	}
+	return &normalizedString{text: text, alignments: alignments}
+}
+
+// offsets 返回text中[start, end)字节区间对应的原始文本字节区间
+func (n *normalizedString) offsets(start, end int) [2]int {
+	if len(n.alignments) == 0 {
+		return [2]int{0, 0}
+	}
+	if start >= end {
+		if start < len(n.alignments) {
+			pos := n.alignments[start][0]
+			return [2]int{pos, pos}
+		}
+		pos := n.alignments[len(n.alignments)-1][1]
+		return [2]int{pos, pos}
+	}
+	return [2]int{n.alignments[start][0], n.alignments[end-1][1]}
+}
+
+// originalOffsets 返回整个片段在原始文本中的字节区间
+func (n *normalizedString) originalOffsets() [2]int {
+	return n.offsets(0, len(n.text))
+}
+
+// slice 截取text中[start, end)字节区间，保留对齐信息
+func (n *normalizedString) slice(start, end int) *normalizedString {
+	return &normalizedString{text: n.text[start:end], alignments: n.alignments[start:end]}
+}
+
+// span 返回text中[start, end)字节区间整体对应的原始区间
+func (n *normalizedString) span(start, end int) [2]int {
+	return [2]int{n.alignments[start][0], n.alignments[end-1][1]}
+}
+
+// mapRunes 将每个字符替换为f返回的字符串，新字节对齐到被替换字符的原始区间
+func (n *normalizedString) mapRunes(f func(r rune) string) *normalizedString {
+	var sb strings.Builder
+	sb.Grow(len(n.text))
+	alignments := make([][2]int, 0, len(n.text))
+	for i := 0; i < len(n.text); {
+		r, size := utf8.DecodeRuneInString(n.text[i:])
+		replacement := f(r)
+		sb.WriteString(replacement)
+		span := n.span(i, i+size)
+		for j := 0; j < len(replacement); j++ {
+			alignments = append(alignments, span)
+		}
+		i += size
+	}
+	return &normalizedString{text: sb.String(), alignments: alignments}
+}
+
+// mapBytes 将每个字节替换为f返回的字符串，新字节对齐到被替换字节的原始区间
+func (n *normalizedString) mapBytes(f func(b byte) string) *normalizedString {
+	var sb strings.Builder
+	sb.Grow(len(n.text) * 2)
+	alignments := make([][2]int, 0, len(n.text)*2)
+	for i := 0; i < len(n.text); i++ {
+		replacement := f(n.text[i])
+		sb.WriteString(replacement)
+		for j := 0; j < len(replacement); j++ {
+			alignments = append(alignments, n.alignments[i])
+		}
+	}
+	return &normalizedString{text: sb.String(), alignments: alignments}
+}
+
+// prepend 在开头插入文本，新字节对齐到第一个字符的原始区间
+func (n *normalizedString) prepend(s string) *normalizedString {
+	if n.text == "" {
+		return n
+	}
+	span := n.span(0, 1)
+	alignments := make([][2]int, 0, len(s)+len(n.alignments))
+	for i := 0; i < len(s); i++ {
+		alignments = append(alignments, span)
+	}
+	alignments = append(alignments, n.alignments...)
+	return &normalizedString{text: s + n.text, alignments: alignments}
+}
+
+// replace 将匹配区间替换为content，替换内容对齐到被替换区间的原始位置；
+// matches必须有序且互不重叠
+func (n *normalizedString) replace(matches [][2]int, content string) *normalizedString {
+	if len(matches) == 0 {
+		return n
+	}
+
+	var sb strings.Builder
+	alignments := make([][2]int, 0, len(n.alignments))
+	prev := 0
+	for _, m := range matches {
+		if m[0] == m[1] {
+			continue
+		}
+		sb.WriteString(n.text[prev:m[0]])
+		alignments = append(alignments, n.alignments[prev:m[0]]...)
+		sb.WriteString(content)
+		span := n.span(m[0], m[1])
+		for i := 0; i < len(content); i++ {
+			alignments = append(alignments, span)
+		}
+		prev = m[1]
+	}
+	sb.WriteString(n.text[prev:])
+	alignments = append(alignments, n.alignments[prev:]...)
+	return &normalizedString{text: sb.String(), alignments: alignments}
+}
+
+// splitBehavior 切分时对匹配部分（分隔符）的处理方式
+type splitBehavior string
+
+const (
+	splitRemoved            splitBehavior = "Removed"
+	splitIsolated           splitBehavior = "Isolated"
+	splitMergedWithPrevious splitBehavior = "MergedWithPrevious"
+	splitMergedWithNext     splitBehavior = "MergedWithNext"
+	splitContiguous         splitBehavior = "Contiguous"
+)
+
+// segment 切分得到的区间，isMatch表示是否为匹配部分
+type segment struct {
+	start, end int
+	isMatch    bool
+}
+
+// split 根据匹配区间切分文本，matches必须有序且互不重叠；
+// invert为true时匹配部分与非匹配部分互换角色
+func (n *normalizedString) split(matches [][2]int, behavior splitBehavior, invert bool) []*normalizedString {
+	var segments []segment
+	prev := 0
+	for _, m := range matches {
+		if prev != m[0] {
+			segments = append(segments, segment{prev, m[0], invert})
+		}
+		segments = append(segments, segment{m[0], m[1], !invert})
+		prev = m[1]
+	}
+	if prev != len(n.text) {
+		segments = append(segments, segment{prev, len(n.text), invert})
+	}
+
+	var merged []segment
+	switch behavior {
+	case splitRemoved:
+		for _, s := range segments {
+			if !s.isMatch {
+				merged = append(merged, s)
+			}
+		}
+	case splitMergedWithPrevious:
+		previousMatch := false
+		for _, s := range segments {
+			if s.isMatch && !previousMatch && len(merged) > 0 {
+				merged[len(merged)-1].end = s.end
+			} else {
+				merged = append(merged, s)
+			}
+			previousMatch = s.isMatch
+		}
+	case splitMergedWithNext:
+		previousMatch := false
+		for i := len(segments) - 1; i >= 0; i-- {
+			s := segments[i]
+			if s.isMatch && !previousMatch && len(merged) > 0 {
+				merged[len(merged)-1].start = s.start
+			} else {
+				merged = append(merged, s)
+			}
+			previousMatch = s.isMatch
+		}
+		for i, j := 0, len(merged)-1; i < j; i, j = i+1, j-1 {
+			merged[i], merged[j] = merged[j], merged[i]
+		}
+	case splitContiguous:
+		for i, s := range segments {
+			if i > 0 && s.isMatch && segments[i-1].isMatch {
+				merged[len(merged)-1].end = s.end
+			} else {
+				merged = append(merged, s)
+			}
+		}
+	default: // splitIsolated
+		merged = segments
+	}
+
+	pieces := make([]*normalizedString, 0, len(merged))
+	for _, s := range merged {
+		if s.start < s.end {
+			pieces = append(pieces, n.slice(s.start, s.end))
+		}
+	}
+	return pieces
+}
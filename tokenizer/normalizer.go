@@ -0,0 +1,276 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dlclark/regexp2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizer 规范化器，在预分词之前对文本进行变换（对应HF的normalizer）
+type normalizer interface {
+	normalize(n *normalizedString) *normalizedString
+}
+
+// newNormalizer 根据HF配置创建规范化器
+func newNormalizer(config map[string]interface{}) (normalizer, error) {
+	typ, _ := config["type"].(string)
+	switch typ {
+	case "Sequence":
+		items, _ := config["normalizers"].([]interface{})
+		var sequence sequenceNormalizer
+		for _, item := range items {
+			child, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid normalizer in sequence: %v", item)
+			}
+			n, err := newNormalizer(child)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, n)
+		}
+		return sequence, nil
+
+	case "NFC":
+		return unicodeNormalizer{form: norm.NFC}, nil
+	case "NFD":
+		return unicodeNormalizer{form: norm.NFD}, nil
+	case "NFKC":
+		return unicodeNormalizer{form: norm.NFKC}, nil
+	case "NFKD":
+		return unicodeNormalizer{form: norm.NFKD}, nil
+	case "Precompiled":
+		p, err := newPrecompiledNormalizer(config)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+
+	case "Lowercase":
+		return lowercaseNormalizer{}, nil
+
+	case "StripAccents":
+		return stripAccentsNormalizer{}, nil
+
+	case "Strip":
+		return stripNormalizer{
+			left:  boolOption(config, "strip_left", true),
+			right: boolOption(config, "strip_right", true),
+		}, nil
+
+	case "Replace":
+		pattern, _ := config["pattern"].(map[string]interface{})
+		replace := &replaceNormalizer{content: stringOption(config, "content", "")}
+		if re, ok := pattern["Regex"].(string); ok {
+			compiled, err := regexp2.Compile(re, regexp2.None)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Replace pattern %q: %v", re, err)
+			}
+			replace.regexp = compiled
+		} else if literal, ok := pattern["String"].(string); ok {
+			replace.literal = literal
+		} else {
+			return nil, fmt.Errorf("invalid Replace pattern: %v", config["pattern"])
+		}
+		return replace, nil
+
+	case "Prepend":
+		return prependNormalizer{prepend: stringOption(config, "prepend", "")}, nil
+
+	case "Nmt":
+		return nmtNormalizer{}, nil
+
+	case "BertNormalizer":
+		lowercase := boolOption(config, "lowercase", true)
+		return bertNormalizer{
+			cleanText:          boolOption(config, "clean_text", true),
+			handleChineseChars: boolOption(config, "handle_chinese_chars", true),
+			stripAccents:       boolOption(config, "strip_accents", lowercase),
+			lowercase:          lowercase,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported normalizer type: %q", typ)
+	}
+}
+
+// sequenceNormalizer 依次应用多个规范化器
+type sequenceNormalizer []normalizer
+
+func (s sequenceNormalizer) normalize(n *normalizedString) *normalizedString {
+	for _, child := range s {
+		n = child.normalize(n)
+	}
+	return n
+}
+
+// unicodeNormalizer unicode规范化（NFC/NFD/NFKC/NFKD），
+// 按规范化片段处理，片段内的输出字节对齐到整个片段的原始区间
+type unicodeNormalizer struct {
+	form norm.Form
+}
+
+func (u unicodeNormalizer) normalize(n *normalizedString) *normalizedString {
+	if u.form.IsNormalString(n.text) {
+		return n
+	}
+
+	var sb strings.Builder
+	alignments := make([][2]int, 0, len(n.text))
+	for i := 0; i < len(n.text); {
+		j := len(n.text)
+		if boundary := u.form.NextBoundaryInString(n.text[i:], true); boundary > 0 {
+			j = i + boundary
+		}
+		normalized := u.form.String(n.text[i:j])
+		sb.WriteString(normalized)
+		span := n.span(i, j)
+		for k := 0; k < len(normalized); k++ {
+			alignments = append(alignments, span)
+		}
+		i = j
+	}
+	return &normalizedString{text: sb.String(), alignments: alignments}
+}
+
+// lowercaseNormalizer 转换为小写
+type lowercaseNormalizer struct{}
+
+func (lowercaseNormalizer) normalize(n *normalizedString) *normalizedString {
+	return n.mapRunes(func(r rune) string {
+		return strings.ToLower(string(r))
+	})
+}
+
+// stripAccentsNormalizer 去除组合附加符号（需配合NFD使用）
+type stripAccentsNormalizer struct{}
+
+func (stripAccentsNormalizer) normalize(n *normalizedString) *normalizedString {
+	return n.mapRunes(func(r rune) string {
+		if unicode.Is(unicode.Mn, r) {
+			return ""
+		}
+		return string(r)
+	})
+}
+
+// stripNormalizer 去除首尾空白
+type stripNormalizer struct {
+	left, right bool
+}
+
+func (s stripNormalizer) normalize(n *normalizedString) *normalizedString {
+	start, end := 0, len(n.text)
+	if s.left {
+		start = len(n.text) - len(strings.TrimLeftFunc(n.text, unicode.IsSpace))
+	}
+	if s.right {
+		end = len(strings.TrimRightFunc(n.text, unicode.IsSpace))
+	}
+	if start >= end {
+		return n.slice(0, 0)
+	}
+	return n.slice(start, end)
+}
+
+// replaceNormalizer 将匹配的正则或字符串替换为指定内容
+type replaceNormalizer struct {
+	regexp  *regexp2.Regexp
+	literal string
+	content string
+}
+
+func (r *replaceNormalizer) normalize(n *normalizedString) *normalizedString {
+	if r.regexp != nil {
+		return n.replace(regexpMatches(r.regexp, n.text), r.content)
+	}
+	return n.replace(literalMatches(r.literal, n.text), r.content)
+}
+
+// prependNormalizer 在非空文本开头插入指定内容
+type prependNormalizer struct {
+	prepend string
+}
+
+func (p prependNormalizer) normalize(n *normalizedString) *normalizedString {
+	return n.prepend(p.prepend)
+}
+
+// nmtNormalizer 去除控制字符，并将特殊空白替换为普通空格
+type nmtNormalizer struct{}
+
+func (nmtNormalizer) normalize(n *normalizedString) *normalizedString {
+	return n.mapRunes(func(r rune) string {
+		switch {
+		case r <= 0x08 || r == 0x0B || (r >= 0x0E && r <= 0x1F) || r == 0x7F || r == 0x8F || r == 0x9F:
+			return ""
+		case r == 0x0009 || r == 0x000A || r == 0x000C || r == 0x000D || r == 0x1680 ||
+			(r >= 0x200B && r <= 0x200F) || r == 0x2028 || r == 0x2029 || r == 0x2581 ||
+			r == 0xFEFF || r == 0xFFFD:
+			return " "
+		}
+		return string(r)
+	})
+}
+
+// bertNormalizer BERT风格规范化：清理控制字符、中文字符两侧加空格、去除重音、转小写
+type bertNormalizer struct {
+	cleanText          bool
+	handleChineseChars bool
+	stripAccents       bool
+	lowercase          bool
+}
+
+func (b bertNormalizer) normalize(n *normalizedString) *normalizedString {
+	if b.cleanText {
+		n = n.mapRunes(func(r rune) string {
+			switch {
+			case r == 0 || r == utf8.RuneError || isControl(r):
+				return ""
+			case unicode.IsSpace(r):
+				return " "
+			}
+			return string(r)
+		})
+	}
+	if b.handleChineseChars {
+		n = n.mapRunes(func(r rune) string {
+			if isChineseChar(r) {
+				return " " + string(r) + " "
+			}
+			return string(r)
+		})
+	}
+	if b.stripAccents {
+		n = unicodeNormalizer{form: norm.NFD}.normalize(n)
+		n = stripAccentsNormalizer{}.normalize(n)
+	}
+	if b.lowercase {
+		n = lowercaseNormalizer{}.normalize(n)
+	}
+	return n
+}
+
+// isControl 判断是否为控制字符（制表符和换行视为空白而非控制字符）
+func isControl(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	return unicode.In(r, unicode.Cc, unicode.Cf, unicode.Co)
+}
+
+// isChineseChar 判断是否为CJK统一表意文字
+func isChineseChar(r rune) bool {
+	return (r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x20000 && r <= 0x2A6DF) ||
+		(r >= 0x2A700 && r <= 0x2B73F) ||
+		(r >= 0x2B740 && r <= 0x2B81F) ||
+		(r >= 0x2B920 && r <= 0x2CEAF) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x2F800 && r <= 0x2FA1F)
+}
@@ -0,0 +1,78 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TestNormalizers 测试由配置驱动的规范化器
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		text   string
+		want   string
+	}{
+		{"nfc", `{"type": "NFC"}`, "e\u0301te\u0301", "\u00e9t\u00e9"},
+		{"nfd", `{"type": "NFD"}`, "\u00e9", "e\u0301"},
+		{"nfkc", `{"type": "NFKC"}`, "ﬁne ①", "fine 1"},
+		{"lowercase", `{"type": "Lowercase"}`, "HeLLo ÉCOLE", "hello école"},
+		{"strip", `{"type": "Strip", "strip_left": true, "strip_right": false}`, "  hi  ", "hi  "},
+		{"replace string", `{"type": "Replace", "pattern": {"String": " "}, "content": "▁"}`, "a b", "a▁b"},
+		{"replace regex", `{"type": "Replace", "pattern": {"Regex": " {2,}"}, "content": " "}`, "a   b", "a b"},
+		{"prepend", `{"type": "Prepend", "prepend": "▁"}`, "ab", "▁ab"},
+		{"prepend empty", `{"type": "Prepend", "prepend": "▁"}`, "", ""},
+		{"sequence", `{"type": "Sequence", "normalizers": [
+			{"type": "Prepend", "prepend": "▁"},
+			{"type": "Replace", "pattern": {"String": " "}, "content": "▁"}
+		]}`, "Hi there", "▁Hi▁there"},
+		{"bert", `{"type": "BertNormalizer", "clean_text": true, "handle_chinese_chars": true, "strip_accents": null, "lowercase": true}`,
+			"Héllo 中文\tX", "hello  中  文  x"},
+	}
+
+	for _, tt := range tests {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.config), &config); err != nil {
+			t.Fatalf("%s: invalid config: %v", tt.name, err)
+		}
+		n, err := newNormalizer(config)
+		if err != nil {
+			t.Fatalf("%s: failed to build normalizer: %v", tt.name, err)
+		}
+
+		got := n.normalize(newNormalizedString(tt.text))
+		if got.text != tt.want {
+			t.Errorf("%s: normalize(%q) = %q, want %q", tt.name, tt.text, got.text, tt.want)
+		}
+		if len(got.alignments) != len(got.text) {
+			t.Errorf("%s: %d alignments for %d bytes", tt.name, len(got.alignments), len(got.text))
+		}
+	}
+}
+
+// TestNormalizerOffsets 测试规范化后的区间能对齐回原始文本
+func TestNormalizerOffsets(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer normalizer
+		text       string
+		start, end int
+		want       [2]int
+	}{
+		// "e" + U+0301 合成为 "é"，对齐到原始的3个字节
+		{"nfc", unicodeNormalizer{form: norm.NFC}, "xe\u0301y", 1, 3, [2]int{1, 4}},
+		{"strip", stripNormalizer{left: true, right: true}, "  hi  ", 0, 2, [2]int{2, 4}},
+		// 3字节的 "▁" 对齐到被替换的1字节空格
+		{"replace", &replaceNormalizer{literal: " ", content: "▁"}, "a b", 1, 4, [2]int{1, 2}},
+		{"lowercase", lowercaseNormalizer{}, "ÀB", 2, 3, [2]int{2, 3}},
+	}
+
+	for _, tt := range tests {
+		got := tt.normalizer.normalize(newNormalizedString(tt.text)).offsets(tt.start, tt.end)
+		if got != tt.want {
+			t.Errorf("%s: offsets(%d, %d) = %v, want %v", tt.name, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
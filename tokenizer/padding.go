@@ -0,0 +1,136 @@
+package tokenizer
+
+import (
+	"fmt"
+)
+
+// PaddingOptions 填充选项
+type PaddingOptions struct {
+	Length          int    `json:"length,omitempty"`             // 固定长度，为0时填充到批次中最长的序列
+	Direction       string `json:"direction,omitempty"`          // 在右侧（right，默认）或左侧（left）填充
+	PadToMultipleOf int    `json:"pad_to_multiple_of,omitempty"` // 将目标长度向上取整为该值的倍数
+	PadToken        string `json:"pad_token,omitempty"`          // 为空时使用模型的pad token
+	PadID           int    `json:"pad_id,omitempty"`
+	PadTypeID       int    `json:"pad_type_id,omitempty"`
+}
+
+// hfPadding tokenizer.json中的padding，strategy为 "BatchLongest" 或 {"Fixed": n}
+type hfPadding struct {
+	Strategy        interface{} `json:"strategy"`
+	Direction       string      `json:"direction"`
+	PadToMultipleOf *int        `json:"pad_to_multiple_of"`
+	PadID           int         `json:"pad_id"`
+	PadTypeID       int         `json:"pad_type_id"`
+	PadToken        string      `json:"pad_token"`
+}
+
+// options 转换为填充选项
+func (p *hfPadding) options() *PaddingOptions {
+	opts := &PaddingOptions{
+		Direction: normalizeOption(p.Direction),
+		PadToken:  p.PadToken,
+		PadID:     p.PadID,
+		PadTypeID: p.PadTypeID,
+	}
+	if p.PadToMultipleOf != nil {
+		opts.PadToMultipleOf = *p.PadToMultipleOf
+	}
+	if strategy, ok := p.Strategy.(map[string]interface{}); ok {
+		if length, ok := strategy["Fixed"].(float64); ok {
+			opts.Length = int(length)
+		}
+	}
+	return opts
+}
+
+// paddingOptions 补全填充选项：未指定的字段依次取tokenizer.json的padding、
+// tokenizer_config.json的padding_side与pad_token
+func (t *Tokenizer) paddingOptions(opts PaddingOptions) (PaddingOptions, error) {
+	defaults := PaddingOptions{Direction: t.config.PaddingSide}
+	if t.config.Padding != nil {
+		defaults = *t.config.Padding
+		if defaults.Direction == "" {
+			defaults.Direction = t.config.PaddingSide
+		}
+	}
+	if opts.Length == 0 {
+		opts.Length = defaults.Length
+	}
+	if opts.Direction == "" {
+		opts.Direction = defaults.Direction
+	}
+	if opts.PadToMultipleOf == 0 {
+		opts.PadToMultipleOf = defaults.PadToMultipleOf
+	}
+	if opts.PadTypeID == 0 {
+		opts.PadTypeID = defaults.PadTypeID
+	}
+	if opts.PadToken == "" {
+		opts.PadToken, opts.PadID = defaults.PadToken, defaults.PadID
+	}
+	if opts.PadToken == "" {
+		opts.PadToken = t.config.SpecialTokens["pad"]
+	}
+	if opts.PadToken == "" {
+		return opts, fmt.Errorf("padding requires a pad token")
+	}
+	id, exists := t.config.Vocabulary[opts.PadToken]
+	if !exists {
+		return opts, fmt.Errorf("pad token %q is not in the vocabulary", opts.PadToken)
+	}
+	opts.PadID = id
+
+	opts.Direction = normalizeOption(opts.Direction)
+	if opts.Direction == "" {
+		opts.Direction = "right"
+	}
+	switch {
+	case opts.Direction != "right" && opts.Direction != "left":
+		return opts, fmt.Errorf("unsupported padding direction: %q", opts.Direction)
+	case opts.Length < 0 || opts.PadToMultipleOf < 0:
+		return opts, fmt.Errorf("invalid padding length")
+	}
+	return opts, nil
+}
+
+// padEncodings 将编码结果填充到相同长度：固定长度或其中最长的序列，并按pad_to_multiple_of向上取整。
+// 溢出片段填充到同一长度
+func padEncodings(encodings []*Encoding, opts PaddingOptions) {
+	target := opts.Length
+	if target == 0 {
+		for _, encoding := range encodings {
+			target = maxInt(target, encoding.Len())
+		}
+	}
+	if m := opts.PadToMultipleOf; m > 0 && target%m != 0 {
+		target += m - target%m
+	}
+	for _, encoding := range encodings {
+		encoding.pad(target, opts)
+	}
+}
+
+// pad 用pad token填充到target个token，填充的token注意力掩码为0
+func (e *Encoding) pad(target int, opts PaddingOptions) {
+	for _, overflow := range e.Overflowing {
+		overflow.pad(target, opts)
+	}
+	n := target - e.Len()
+	if n <= 0 {
+		return
+	}
+
+	padding := &Encoding{}
+	for i := 0; i < n; i++ {
+		padding.appendToken(opts.PadID, opts.PadToken, opts.PadTypeID, true, [2]int{}, -1)
+		padding.AttentionMask[i] = 0
+	}
+	if opts.Direction == "left" {
+		overflowing := e.Overflowing
+		padding.appendEncoding(e)
+		*e = *padding
+		e.Overflowing = overflowing
+		return
+	}
+	e.appendEncoding(padding)
+}
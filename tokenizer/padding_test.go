@@ -0,0 +1,61 @@
+package tokenizer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestTruncationAndPadding 测试编码时截断（为特殊token预留位置）、溢出片段与填充
+func TestTruncationAndPadding(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+
+	encoding, err := tk.EncodeWithOptions("Unaffable is good.", tokenizer.EncodeOptions{
+		AddSpecialTokens: true,
+		Truncation:       &tokenizer.TruncationOptions{MaxLength: 5},
+		Padding:          &tokenizer.PaddingOptions{Length: 8, Direction: "left", PadToken: "[UNK]"},
+	})
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	wantTokens := []string{"[UNK]", "[UNK]", "[UNK]", "[CLS]", "un", "##aff", "##able", "[SEP]"}
+	if !reflect.DeepEqual(encoding.Tokens, wantTokens) {
+		t.Errorf("tokens = %q, want %q", encoding.Tokens, wantTokens)
+	}
+	if want := []int{0, 0, 0, 1, 1, 1, 1, 1}; !reflect.DeepEqual(encoding.AttentionMask, want) {
+		t.Errorf("attention mask = %v, want %v", encoding.AttentionMask, want)
+	}
+	if len(encoding.Overflowing) != 1 {
+		t.Fatalf("overflowing = %d parts, want 1", len(encoding.Overflowing))
+	}
+	wantOverflow := []string{"[UNK]", "[UNK]", "[UNK]", "[CLS]", "is", "good", ".", "[SEP]"}
+	if got := encoding.Overflowing[0].Tokens; !reflect.DeepEqual(got, wantOverflow) {
+		t.Errorf("overflowing tokens = %q, want %q", got, wantOverflow)
+	}
+
+	// 填充到pad_to_multiple_of的倍数
+	encoding, err = tk.EncodeWithOptions("is good", tokenizer.EncodeOptions{
+		Padding: &tokenizer.PaddingOptions{PadToMultipleOf: 4, PadToken: "[UNK]"},
+	})
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	if want := []int{6, 7, 0, 0}; !reflect.DeepEqual(encoding.IDs, want) {
+		t.Errorf("ids = %v, want %v", encoding.IDs, want)
+	}
+
+	// 没有pad token、pad token不在词汇表中或参数无效时报错
+	invalid := []tokenizer.EncodeOptions{
+		{Padding: &tokenizer.PaddingOptions{Length: 8}},
+		{Padding: &tokenizer.PaddingOptions{Length: 8, PadToken: "[NOPE]", PadID: 3}},
+		{Truncation: &tokenizer.TruncationOptions{MaxLength: 2, Strategy: "unknown"}},
+		{Truncation: &tokenizer.TruncationOptions{MaxLength: 2, Stride: 2}},
+		{Truncation: &tokenizer.TruncationOptions{MaxLength: 2, Direction: "up"}},
+	}
+	for _, opts := range invalid {
+		if _, err := tk.EncodeWithOptions("Unaffable is good.", opts); err == nil {
+			t.Errorf("expected error for options %+v", opts)
+		}
+	}
+}
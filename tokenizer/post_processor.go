@@ -0,0 +1,360 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// postProcessor 后处理器，在模型编码后添加特殊token并设置类型ID（对应HF的post_processor）。
+// encodings依次为单句或句对的编码结果
+type postProcessor interface {
+	process(encodings []*Encoding, addSpecialTokens bool) ([]*Encoding, error)
+}
+
+// newPostProcessor 根据HF配置创建后处理器
+func newPostProcessor(config map[string]interface{}) (postProcessor, error) {
+	typ, _ := config["type"].(string)
+	switch typ {
+	case "Sequence":
+		items, _ := config["processors"].([]interface{})
+		var sequence sequenceProcessor
+		for _, item := range items {
+			child, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid post_processor in sequence: %v", item)
+			}
+			p, err := newPostProcessor(child)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, p)
+		}
+		return sequence, nil
+
+	case "TemplateProcessing":
+		return newTemplateProcessor(config)
+
+	case "BertProcessing":
+		cls, sep, err := clsSepOption(config)
+		if err != nil {
+			return nil, err
+		}
+		return &templateProcessor{
+			single: []templatePiece{cls.piece(0), {sequence: "A"}, sep.piece(0)},
+			pair:   []templatePiece{cls.piece(0), {sequence: "A"}, sep.piece(0), {sequence: "B", typeID: 1}, sep.piece(1)},
+			specialTokens: map[string]templateSpecialToken{
+				cls.token: {ids: []int{cls.id}, tokens: []string{cls.token}},
+				sep.token: {ids: []int{sep.id}, tokens: []string{sep.token}},
+			},
+		}, nil
+
+	case "RobertaProcessing":
+		cls, sep, err := clsSepOption(config)
+		if err != nil {
+			return nil, err
+		}
+		template := &templateProcessor{
+			single: []templatePiece{cls.piece(0), {sequence: "A"}, sep.piece(0)},
+			pair:   []templatePiece{cls.piece(0), {sequence: "A"}, sep.piece(0), sep.piece(0), {sequence: "B"}, sep.piece(0)},
+			specialTokens: map[string]templateSpecialToken{
+				cls.token: {ids: []int{cls.id}, tokens: []string{cls.token}},
+				sep.token: {ids: []int{sep.id}, tokens: []string{sep.token}},
+			},
+		}
+		// RobertaProcessing先按ByteLevel规则裁剪偏移中的空格，再添加特殊token
+		return sequenceProcessor{newByteLevelProcessor(config), template}, nil
+
+	case "ByteLevel":
+		return newByteLevelProcessor(config), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported post_processor type: %q", typ)
+	}
+}
+
+// sequenceProcessor 依次应用多个后处理器
+type sequenceProcessor []postProcessor
+
+func (s sequenceProcessor) process(encodings []*Encoding, addSpecialTokens bool) ([]*Encoding, error) {
+	var err error
+	for _, p := range s {
+		encodings, err = p.process(encodings, addSpecialTokens)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encodings, nil
+}
+
+// byteLevelProcessor ByteLevel后处理器，不添加特殊token；
+// trimOffsets为true时从偏移中去掉token首尾的空格
+type byteLevelProcessor struct {
+	trimOffsets    bool
+	addPrefixSpace bool
+}
+
+// newByteLevelProcessor 解析ByteLevel后处理器配置
+func newByteLevelProcessor(config map[string]interface{}) byteLevelProcessor {
+	return byteLevelProcessor{
+		trimOffsets:    boolOption(config, "trim_offsets", true),
+		addPrefixSpace: boolOption(config, "add_prefix_space", true),
+	}
+}
+
+func (b byteLevelProcessor) process(encodings []*Encoding, addSpecialTokens bool) ([]*Encoding, error) {
+	if !b.trimOffsets {
+		return encodings, nil
+	}
+
+	space := byteEncoder[' ']
+	isSpace := func(r rune) bool {
+		return r == space || unicode.IsSpace(r)
+	}
+	for _, encoding := range encodings {
+		for i, token := range encoding.Tokens {
+			leading := len([]rune(token)) - len([]rune(strings.TrimLeftFunc(token, isSpace)))
+			trailing := len([]rune(token)) - len([]rune(strings.TrimRightFunc(token, isSpace)))
+			if leading == 0 && trailing == 0 {
+				continue
+			}
+
+			byteOffsets, offsets := &encoding.ByteOffsets[i], &encoding.Offsets[i]
+			// 第一个token开头的单个空格是add_prefix_space添加的，不在原始文本中
+			if leading == 1 && b.addPrefixSpace && (i == 0 || byteOffsets[0] == 0) {
+				leading = 0
+			}
+			for _, o := range []*[2]int{byteOffsets, offsets} {
+				if leading > 0 {
+					o[0] = minInt(o[0]+leading, o[1])
+				}
+				if trailing > 0 && o[1] >= trailing {
+					o[1] = maxInt(o[1]-trailing, o[0])
+				}
+			}
+		}
+	}
+	return encodings, nil
+}
+
+// minInt 返回较小值
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt 返回较大值
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// templatePiece 模板中的一项：序列（A/B）或特殊token
+type templatePiece struct {
+	sequence     string // "A"或"B"，为空表示特殊token
+	specialToken string
+	typeID       int
+}
+
+// templateSpecialToken 模板中特殊token对应的IDs和tokens
+type templateSpecialToken struct {
+	ids    []int
+	tokens []string
+}
+
+// templateProcessor 按模板拼接序列与特殊token（TemplateProcessing），
+// BertProcessing与RobertaProcessing也转换为模板实现
+type templateProcessor struct {
+	single        []templatePiece
+	pair          []templatePiece
+	specialTokens map[string]templateSpecialToken
+}
+
+// newTemplateProcessor 解析TemplateProcessing配置
+func newTemplateProcessor(config map[string]interface{}) (*templateProcessor, error) {
+	tp := &templateProcessor{specialTokens: make(map[string]templateSpecialToken)}
+
+	var err error
+	if tp.single, err = parseTemplate(config["single"]); err != nil {
+		return nil, err
+	}
+	if config["pair"] != nil {
+		if tp.pair, err = parseTemplate(config["pair"]); err != nil {
+			return nil, err
+		}
+	}
+
+	specialTokens, _ := config["special_tokens"].(map[string]interface{})
+	for name, value := range specialTokens {
+		item, _ := value.(map[string]interface{})
+		var st templateSpecialToken
+		ids, _ := item["ids"].([]interface{})
+		for _, id := range ids {
+			if f, ok := id.(float64); ok {
+				st.ids = append(st.ids, int(f))
+			}
+		}
+		tokens, _ := item["tokens"].([]interface{})
+		for _, token := range tokens {
+			if s, ok := token.(string); ok {
+				st.tokens = append(st.tokens, s)
+			}
+		}
+		if len(st.ids) != len(st.tokens) {
+			return nil, fmt.Errorf("special token %q has %d ids but %d tokens", name, len(st.ids), len(st.tokens))
+		}
+		tp.specialTokens[name] = st
+	}
+
+	for _, piece := range append(append([]templatePiece{}, tp.single...), tp.pair...) {
+		if piece.sequence == "" {
+			if _, exists := tp.specialTokens[piece.specialToken]; !exists {
+				return nil, fmt.Errorf("missing special token %q in template", piece.specialToken)
+			}
+		}
+	}
+	return tp, nil
+}
+
+// parseTemplate 解析模板，支持对象数组和 "[CLS] $A [SEP] $B:1 [SEP]:1" 字符串两种格式
+func parseTemplate(value interface{}) ([]templatePiece, error) {
+	switch template := value.(type) {
+	case string:
+		var pieces []templatePiece
+		for _, field := range strings.Fields(template) {
+			piece, err := parseTemplateString(field)
+			if err != nil {
+				return nil, err
+			}
+			pieces = append(pieces, piece)
+		}
+		return pieces, nil
+
+	case []interface{}:
+		var pieces []templatePiece
+		for _, item := range template {
+			if s, ok := item.(string); ok {
+				piece, err := parseTemplateString(s)
+				if err != nil {
+					return nil, err
+				}
+				pieces = append(pieces, piece)
+				continue
+			}
+			obj, _ := item.(map[string]interface{})
+			if seq, ok := obj["Sequence"].(map[string]interface{}); ok {
+				pieces = append(pieces, templatePiece{
+					sequence: stringOption(seq, "id", "A"),
+					typeID:   intOption(seq, "type_id", 0),
+				})
+			} else if st, ok := obj["SpecialToken"].(map[string]interface{}); ok {
+				pieces = append(pieces, templatePiece{
+					specialToken: stringOption(st, "id", ""),
+					typeID:       intOption(st, "type_id", 0),
+				})
+			} else {
+				return nil, fmt.Errorf("invalid template piece: %v", item)
+			}
+		}
+		return pieces, nil
+
+	default:
+		return nil, fmt.Errorf("invalid template: %v", value)
+	}
+}
+
+// parseTemplateString 解析字符串格式的模板项，如 $A、$B:1、[SEP]:1
+func parseTemplateString(s string) (templatePiece, error) {
+	name, typeID := s, 0
+	if i := strings.LastIndex(s, ":"); i > 0 {
+		id, err := strconv.Atoi(s[i+1:])
+		if err == nil {
+			name, typeID = s[:i], id
+		}
+	}
+
+	if strings.HasPrefix(name, "$") {
+		switch name[1:] {
+		case "", "A", "a":
+			return templatePiece{sequence: "A", typeID: typeID}, nil
+		case "B", "b":
+			return templatePiece{sequence: "B", typeID: typeID}, nil
+		default:
+			// $0、$1 形式只指定类型ID
+			id, err := strconv.Atoi(name[1:])
+			if err != nil {
+				return templatePiece{}, fmt.Errorf("invalid template sequence: %q", s)
+			}
+			return templatePiece{sequence: "A", typeID: id}, nil
+		}
+	}
+	return templatePiece{specialToken: name, typeID: typeID}, nil
+}
+
+func (tp *templateProcessor) process(encodings []*Encoding, addSpecialTokens bool) ([]*Encoding, error) {
+	if !addSpecialTokens {
+		return encodings, nil
+	}
+
+	template := tp.single
+	if len(encodings) > 1 {
+		if tp.pair == nil {
+			return nil, fmt.Errorf("post_processor has no template for sequence pairs")
+		}
+		template = tp.pair
+	}
+
+	result := &Encoding{}
+	for _, piece := range template {
+		switch piece.sequence {
+		case "A":
+			encodings[0].setTypeID(piece.typeID)
+			result.appendEncoding(encodings[0])
+		case "B":
+			if len(encodings) > 1 {
+				encodings[1].setTypeID(piece.typeID)
+				result.appendEncoding(encodings[1])
+			}
+		default:
+			st := tp.specialTokens[piece.specialToken]
+			for i, id := range st.ids {
+				result.appendSpecialToken(id, st.tokens[i], piece.typeID)
+			}
+		}
+	}
+	return []*Encoding{result}, nil
+}
+
+// specialTokenOption BertProcessing/RobertaProcessing中的 [token, id] 配置
+type specialTokenOption struct {
+	token string
+	id    int
+}
+
+// piece 生成对应的模板项
+func (s specialTokenOption) piece(typeID int) templatePiece {
+	return templatePiece{specialToken: s.token, typeID: typeID}
+}
+
+// clsSepOption 读取cls与sep配置
+func clsSepOption(config map[string]interface{}) (cls, sep specialTokenOption, err error) {
+	parse := func(key string) (specialTokenOption, error) {
+		value, _ := config[key].([]interface{})
+		if len(value) != 2 {
+			return specialTokenOption{}, fmt.Errorf("invalid %s token: %v", key, config[key])
+		}
+		token, _ := value[0].(string)
+		id, _ := value[1].(float64)
+		return specialTokenOption{token: token, id: int(id)}, nil
+	}
+	if cls, err = parse("cls"); err != nil {
+		return
+	}
+	sep, err = parse("sep")
+	return
+}
@@ -0,0 +1,163 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestEncoding 构造不含特殊token的编码结果
+func newTestEncoding(typeID int, tokens ...string) *Encoding {
+	encoding := &Encoding{}
+	for i, token := range tokens {
+		encoding.appendToken(i+typeID*10, token, typeID, false, [2]int{i, i + len(token)}, i)
+	}
+	return encoding
+}
+
+// TestPostProcessors 测试由配置驱动的后处理器
+func TestPostProcessors(t *testing.T) {
+	glm := `{"type": "TemplateProcessing",
+		"single": [{"SpecialToken": {"id": "[gMASK]", "type_id": 0}}, {"SpecialToken": {"id": "<sop>", "type_id": 0}}, {"Sequence": {"id": "A", "type_id": 0}}],
+		"pair": [{"SpecialToken": {"id": "[gMASK]", "type_id": 0}}, {"SpecialToken": {"id": "<sop>", "type_id": 0}}, {"Sequence": {"id": "A", "type_id": 0}}, {"Sequence": {"id": "B", "type_id": 1}}],
+		"special_tokens": {
+			"[gMASK]": {"id": "[gMASK]", "ids": [151331], "tokens": ["[gMASK]"]},
+			"<sop>": {"id": "<sop>", "ids": [151333], "tokens": ["<sop>"]}
+		}}`
+
+	tests := []struct {
+		name    string
+		config  string
+		pair    bool
+		add     bool
+		tokens  []string
+		typeIDs []int
+		mask    []int
+	}{
+		{"template single", glm, false, true,
+			[]string{"[gMASK]", "<sop>", "a", "b"}, []int{0, 0, 0, 0}, []int{1, 1, 0, 0}},
+		{"template pair", glm, true, true,
+			[]string{"[gMASK]", "<sop>", "a", "b", "c"}, []int{0, 0, 0, 0, 1}, []int{1, 1, 0, 0, 0}},
+		{"template disabled", glm, false, false,
+			[]string{"a", "b"}, []int{0, 0}, []int{0, 0}},
+		{"template string", `{"type": "TemplateProcessing", "single": "[CLS] $A [SEP]", "pair": "[CLS] $A [SEP] $B:1 [SEP]:1",
+			"special_tokens": {"[CLS]": {"id": "[CLS]", "ids": [101], "tokens": ["[CLS]"]}, "[SEP]": {"id": "[SEP]", "ids": [102], "tokens": ["[SEP]"]}}}`,
+			true, true, []string{"[CLS]", "a", "b", "[SEP]", "c", "[SEP]"}, []int{0, 0, 0, 0, 1, 1}, []int{1, 0, 0, 1, 0, 1}},
+		{"bert", `{"type": "BertProcessing", "sep": ["[SEP]", 102], "cls": ["[CLS]", 101]}`, true, true,
+			[]string{"[CLS]", "a", "b", "[SEP]", "c", "[SEP]"}, []int{0, 0, 0, 0, 1, 1}, []int{1, 0, 0, 1, 0, 1}},
+		{"roberta", `{"type": "RobertaProcessing", "sep": ["</s>", 2], "cls": ["<s>", 0], "trim_offsets": true, "add_prefix_space": true}`, true, true,
+			[]string{"<s>", "a", "b", "</s>", "</s>", "c", "</s>"}, []int{0, 0, 0, 0, 0, 0, 0}, []int{1, 0, 0, 1, 1, 0, 1}},
+		{"sequence", `{"type": "Sequence", "processors": [{"type": "ByteLevel", "trim_offsets": false}, {"type": "BertProcessing", "sep": ["[SEP]", 102], "cls": ["[CLS]", 101]}]}`, false, true,
+			[]string{"[CLS]", "a", "b", "[SEP]"}, []int{0, 0, 0, 0}, []int{1, 0, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.config), &config); err != nil {
+			t.Fatalf("%s: invalid config: %v", tt.name, err)
+		}
+		p, err := newPostProcessor(config)
+		if err != nil {
+			t.Fatalf("%s: failed to build post_processor: %v", tt.name, err)
+		}
+
+		encodings := []*Encoding{newTestEncoding(0, "a", "b")}
+		if tt.pair {
+			encodings = append(encodings, newTestEncoding(1, "c"))
+		}
+		processed, err := p.process(encodings, tt.add)
+		if err != nil {
+			t.Fatalf("%s: process failed: %v", tt.name, err)
+		}
+		got := mergeEncodings(processed)
+
+		if !reflect.DeepEqual(got.Tokens, tt.tokens) {
+			t.Errorf("%s: tokens = %q, want %q", tt.name, got.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(got.TypeIDs, tt.typeIDs) {
+			t.Errorf("%s: type ids = %v, want %v", tt.name, got.TypeIDs, tt.typeIDs)
+		}
+		if !reflect.DeepEqual(got.SpecialTokensMask, tt.mask) {
+			t.Errorf("%s: special tokens mask = %v, want %v", tt.name, got.SpecialTokensMask, tt.mask)
+		}
+	}
+}
+
+// TestTemplateMissingPair 测试模板未定义句对时返回错误
+func TestTemplateMissingPair(t *testing.T) {
+	p, err := newPostProcessor(map[string]interface{}{
+		"type":           "TemplateProcessing",
+		"single":         "$A",
+		"special_tokens": map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("failed to build post_processor: %v", err)
+	}
+	if _, err := p.process([]*Encoding{newTestEncoding(0, "a"), newTestEncoding(1, "b")}, true); err == nil {
+		t.Error("expected error for missing pair template")
+	}
+}
+
+// TestEncodeAddSpecialTokens 测试Encode按post_processor添加BOS/EOS
+func TestEncodeAddSpecialTokens(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "tokenizer.json")
+	config := `{
+		"added_tokens": [
+			{"id": 2, "content": "<s>", "special": true},
+			{"id": 3, "content": "</s>", "special": true}
+		],
+		"pre_tokenizer": {"type": "Whitespace"},
+		"post_processor": {"type": "TemplateProcessing", "single": "<s> $A </s>", "pair": "<s> $A </s> $B:1 </s>:1",
+			"special_tokens": {"<s>": {"id": "<s>", "ids": [2], "tokens": ["<s>"]}, "</s>": {"id": "</s>", "ids": [3], "tokens": ["</s>"]}}},
+		"model": {"type": "BPE", "vocab": {"a": 0, "b": 1, "<s>": 2, "</s>": 3}, "merges": []}
+	}`
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	tk, err := NewTokenizer(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	encoding, err := tk.EncodeWithOptions("a b", EncodeOptions{AddSpecialTokens: true, Pair: "b"})
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	if want := []int{2, 0, 1, 3, 1, 3}; !reflect.DeepEqual(encoding.IDs, want) {
+		t.Errorf("ids = %v, want %v", encoding.IDs, want)
+	}
+	if want := []int{0, 0, 0, 0, 1, 1}; !reflect.DeepEqual(encoding.TypeIDs, want) {
+		t.Errorf("type ids = %v, want %v", encoding.TypeIDs, want)
+	}
+
+	ids, err := tk.Encode("a b")
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	if want := []int{0, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Encode without special tokens = %v, want %v", ids, want)
+	}
+}
+
+// TestByteLevelTrimOffsets 测试ByteLevel后处理器从偏移中去掉token首尾的空格
+func TestByteLevelTrimOffsets(t *testing.T) {
+	// "Ġhello" 的前缀空格由add_prefix_space添加，"ĠĠworld" 中的空格来自原始文本
+	encoding := &Encoding{}
+	encoding.appendToken(0, "Ġhello", 0, false, [2]int{0, 5}, 0)
+	encoding.appendToken(1, "ĠĠworld", 0, false, [2]int{5, 12}, 1)
+	encoding.appendToken(2, "!Ġ", 0, false, [2]int{12, 14}, 2)
+
+	processed, err := newByteLevelProcessor(map[string]interface{}{"trim_offsets": true}).process([]*Encoding{encoding}, false)
+	if err != nil {
+		t.Fatalf("process failed: %v", err)
+	}
+	want := [][2]int{{0, 5}, {7, 12}, {12, 13}}
+	if got := processed[0].ByteOffsets; !reflect.DeepEqual(got, want) {
+		t.Errorf("byte offsets = %v, want %v", got, want)
+	}
+	if got := processed[0].Offsets; !reflect.DeepEqual(got, want) {
+		t.Errorf("offsets = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,412 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dlclark/regexp2"
+)
+
+// gpt2SplitPattern GPT-2 ByteLevel预分词使用的正则表达式
+const gpt2SplitPattern = `'s|'t|'re|'ve|'m|'ll|'d| ?\p{L}+| ?\p{N}+| ?[^\s\p{L}\p{N}]+|\s+(?!\S)|\s+`
+
+// whitespacePattern Whitespace预分词使用的正则表达式
+const whitespacePattern = `\w+|[^\w\s]+`
+
+var (
+	gpt2SplitRegexp  = regexp2.MustCompile(gpt2SplitPattern, regexp2.None) // gpt2Matches的参照实现
+	whitespaceRegexp = regexp2.MustCompile(whitespacePattern, regexp2.None)
+)
+
+// preTokenizer 预分词器，将文本片段切分为更小的片段（对应HF的pre_tokenizer）
+type preTokenizer interface {
+	preTokenize(pieces []*normalizedString) []*normalizedString
+}
+
+// newPreTokenizer 根据HF配置创建预分词器
+func newPreTokenizer(config map[string]interface{}) (preTokenizer, error) {
+	typ, _ := config["type"].(string)
+	switch typ {
+	case "Sequence":
+		items, _ := config["pretokenizers"].([]interface{})
+		var sequence sequencePreTokenizer
+		for _, item := range items {
+			child, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid pre_tokenizer in sequence: %v", item)
+			}
+			pt, err := newPreTokenizer(child)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, pt)
+		}
+		return sequence, nil
+
+	case "Split":
+		pattern, _ := config["pattern"].(map[string]interface{})
+		split := &splitPreTokenizer{
+			behavior: splitBehavior(stringOption(config, "behavior", string(splitIsolated))),
+		}
+		split.invert, _ = config["invert"].(bool)
+		if re, ok := pattern["Regex"].(string); ok {
+			compiled, err := regexp2.Compile(re, regexp2.None)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Split pattern %q: %v", re, err)
+			}
+			split.regexp = compiled
+		} else if literal, ok := pattern["String"].(string); ok {
+			split.literal = literal
+		} else {
+			return nil, fmt.Errorf("invalid Split pattern: %v", config["pattern"])
+		}
+		return split, nil
+
+	case "ByteLevel":
+		return &byteLevelPreTokenizer{
+			addPrefixSpace: boolOption(config, "add_prefix_space", true),
+			useRegex:       boolOption(config, "use_regex", true),
+		}, nil
+
+	case "Whitespace":
+		return whitespacePreTokenizer{}, nil
+
+	case "WhitespaceSplit":
+		return whitespaceSplitPreTokenizer{}, nil
+
+	case "BertPreTokenizer":
+		return bertPreTokenizer{}, nil
+
+	case "Digits":
+		return digitsPreTokenizer{individualDigits: boolOption(config, "individual_digits", false)}, nil
+
+	case "Punctuation":
+		return punctuationPreTokenizer{
+			behavior: splitBehavior(stringOption(config, "behavior", string(splitIsolated))),
+		}, nil
+
+	case "CharDelimiterSplit":
+		delimiter, _ := utf8.DecodeRuneInString(stringOption(config, "delimiter", " "))
+		return charDelimiterPreTokenizer{delimiter: delimiter}, nil
+
+	case "Metaspace":
+		return newMetaspacePreTokenizer(config), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported pre_tokenizer type: %q", typ)
+	}
+}
+
+// stringOption 读取字符串配置项
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok {
+		return value
+	}
+	return fallback
+}
+
+// boolOption 读取布尔配置项
+func boolOption(config map[string]interface{}, key string, fallback bool) bool {
+	if value, ok := config[key].(bool); ok {
+		return value
+	}
+	return fallback
+}
+
+// splitEach 对每个片段应用切分函数
+func splitEach(pieces []*normalizedString, f func(*normalizedString) []*normalizedString) []*normalizedString {
+	result := make([]*normalizedString, 0, len(pieces))
+	for _, piece := range pieces {
+		result = append(result, f(piece)...)
+	}
+	return result
+}
+
+// regexpMatches 返回正则在文本中全部匹配的字节区间
+func regexpMatches(re *regexp2.Regexp, text string) [][2]int {
+	// regexp2按字符计算位置，需要转换为字节偏移
+	runeOffsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		runeOffsets = append(runeOffsets, i)
+	}
+	runeOffsets = append(runeOffsets, len(text))
+
+	var matches [][2]int
+	m, err := re.FindStringMatch(text)
+	for err == nil && m != nil {
+		matches = append(matches, [2]int{runeOffsets[m.Index], runeOffsets[m.Index+m.Length]})
+		m, err = re.FindNextMatch(m)
+	}
+	return matches
+}
+
+// gpt2Matches 按gpt2SplitPattern切分文本，返回各匹配的字节区间。
+// 与正则表达式的结果一致，但不需要回溯，是字节级预分词的主要开销所在
+func gpt2Matches(text string) [][2]int {
+	var matches [][2]int
+	for i := 0; i < len(text); {
+		end := gpt2MatchAt(text, i)
+		matches = append(matches, [2]int{i, end})
+		i = end
+	}
+	return matches
+}
+
+// gpt2MatchAt 返回从start开始的匹配的结束位置，按正则中各分支的顺序尝试
+func gpt2MatchAt(text string, start int) int {
+	// 's|'t|'re|'ve|'m|'ll|'d
+	if text[start] == '\'' {
+		for _, suffix := range []string{"s", "t", "re", "ve", "m", "ll", "d"} {
+			if strings.HasPrefix(text[start+1:], suffix) {
+				return start + 1 + len(suffix)
+			}
+		}
+	}
+
+	// ` ?\p{L}+`、` ?\p{N}+`、` ?[^\s\p{L}\p{N}]+`
+	i := start
+	if text[i] == ' ' && i+1 < len(text) {
+		i++
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	var class func(rune) bool
+	switch {
+	case unicode.IsLetter(r):
+		class = unicode.IsLetter
+	case unicode.IsNumber(r):
+		class = unicode.IsNumber
+	case !unicode.IsSpace(r):
+		class = func(r rune) bool { return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r) }
+	}
+	if class != nil {
+		for i < len(text) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if !class(r) {
+				break
+			}
+			i += size
+		}
+		return i
+	}
+
+	// `\s+(?!\S)|\s+`：空白后接非空白字符时，最后一个空白留给下一个匹配
+	end, last := start, start
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		last = end
+		end += size
+	}
+	if end < len(text) && last > start {
+		return last
+	}
+	return end
+}
+
+// literalMatches 返回字符串在文本中全部出现位置的字节区间
+func literalMatches(literal, text string) [][2]int {
+	var matches [][2]int
+	if literal == "" {
+		return matches
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], literal)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		matches = append(matches, [2]int{start, start + len(literal)})
+		offset = start + len(literal)
+	}
+	return matches
+}
+
+// charMatches 返回满足条件的每个字符的字节区间
+func charMatches(text string, pred func(rune) bool) [][2]int {
+	var matches [][2]int
+	for i, r := range text {
+		if pred(r) {
+			matches = append(matches, [2]int{i, i + utf8.RuneLen(r)})
+		}
+	}
+	return matches
+}
+
+// isPunctuation 判断是否为标点（ASCII标点及unicode标点）
+func isPunctuation(r rune) bool {
+	return (r < utf8.RuneSelf && unicode.IsPrint(r) && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ') ||
+		unicode.IsPunct(r)
+}
+
+// sequencePreTokenizer 依次应用多个预分词器
+type sequencePreTokenizer []preTokenizer
+
+func (s sequencePreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	for _, pt := range s {
+		pieces = pt.preTokenize(pieces)
+	}
+	return pieces
+}
+
+// splitPreTokenizer 按正则或字符串切分
+type splitPreTokenizer struct {
+	regexp   *regexp2.Regexp
+	literal  string
+	behavior splitBehavior
+	invert   bool
+}
+
+func (s *splitPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		if s.regexp != nil {
+			return piece.split(regexpMatches(s.regexp, piece.text), s.behavior, s.invert)
+		}
+		return piece.split(literalMatches(s.literal, piece.text), s.behavior, s.invert)
+	})
+}
+
+// byteLevelPreTokenizer 字节级预分词：可选GPT-2正则切分，再将每个字节映射为可见字符
+type byteLevelPreTokenizer struct {
+	addPrefixSpace bool
+	useRegex       bool
+}
+
+func (b *byteLevelPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	if b.addPrefixSpace {
+		pieces = splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+			if !strings.HasPrefix(piece.text, " ") {
+				piece = piece.prepend(" ")
+			}
+			return []*normalizedString{piece}
+		})
+	}
+	if b.useRegex {
+		pieces = splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+			return piece.split(gpt2Matches(piece.text), splitIsolated, false)
+		})
+	}
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return []*normalizedString{piece.mapBytes(func(c byte) string {
+			return string(byteEncoder[c])
+		})}
+	})
+}
+
+// whitespacePreTokenizer 按 \w+|[^\w\s]+ 切分，丢弃空白
+type whitespacePreTokenizer struct{}
+
+func (whitespacePreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(regexpMatches(whitespaceRegexp, piece.text), splitRemoved, true)
+	})
+}
+
+// whitespaceSplitPreTokenizer 仅按空白字符切分
+type whitespaceSplitPreTokenizer struct{}
+
+func (whitespaceSplitPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(charMatches(piece.text, unicode.IsSpace), splitRemoved, false)
+	})
+}
+
+// bertPreTokenizer 按空白切分后再将每个标点独立出来
+type bertPreTokenizer struct{}
+
+func (bertPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	pieces = whitespaceSplitPreTokenizer{}.preTokenize(pieces)
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(charMatches(piece.text, isPunctuation), splitIsolated, false)
+	})
+}
+
+// digitsPreTokenizer 将数字与其他字符分开，individualDigits时每个数字独立
+type digitsPreTokenizer struct {
+	individualDigits bool
+}
+
+func (d digitsPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	behavior := splitContiguous
+	if d.individualDigits {
+		behavior = splitIsolated
+	}
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(charMatches(piece.text, unicode.IsNumber), behavior, false)
+	})
+}
+
+// punctuationPreTokenizer 按标点切分
+type punctuationPreTokenizer struct {
+	behavior splitBehavior
+}
+
+func (p punctuationPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(charMatches(piece.text, isPunctuation), p.behavior, false)
+	})
+}
+
+// charDelimiterPreTokenizer 按指定字符切分并丢弃该字符
+type charDelimiterPreTokenizer struct {
+	delimiter rune
+}
+
+func (c charDelimiterPreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		return piece.split(charMatches(piece.text, func(r rune) bool { return r == c.delimiter }), splitRemoved, false)
+	})
+}
+
+// metaspacePreTokenizer SentencePiece风格：空格替换为▁，可选在开头补▁并在▁前切分
+type metaspacePreTokenizer struct {
+	replacement   string
+	prependScheme string // always, first, never
+	split         bool
+}
+
+// newMetaspacePreTokenizer 根据配置创建Metaspace预分词器，兼容旧版add_prefix_space配置
+func newMetaspacePreTokenizer(config map[string]interface{}) *metaspacePreTokenizer {
+	m := &metaspacePreTokenizer{
+		replacement:   stringOption(config, "replacement", "▁"),
+		prependScheme: stringOption(config, "prepend_scheme", ""),
+		split:         boolOption(config, "split", true),
+	}
+	if m.prependScheme == "" {
+		m.prependScheme = "never"
+		if boolOption(config, "add_prefix_space", true) {
+			m.prependScheme = "always"
+		}
+	}
+	return m
+}
+
+func (m *metaspacePreTokenizer) preTokenize(pieces []*normalizedString) []*normalizedString {
+	return splitEach(pieces, func(piece *normalizedString) []*normalizedString {
+		piece = piece.mapRunes(func(r rune) string {
+			if r == ' ' {
+				return m.replacement
+			}
+			return string(r)
+		})
+
+		if !strings.HasPrefix(piece.text, m.replacement) {
+			switch m.prependScheme {
+			case "always":
+				piece = piece.prepend(m.replacement)
+			case "first":
+				if piece.originalOffsets()[0] == 0 {
+					piece = piece.prepend(m.replacement)
+				}
+			}
+		}
+
+		if !m.split {
+			return []*normalizedString{piece}
+		}
+		return piece.split(literalMatches(m.replacement, piece.text), splitMergedWithNext, false)
+	})
+}
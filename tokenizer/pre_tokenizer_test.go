@@ -0,0 +1,104 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestPreTokenizers 测试由配置驱动的预分词器
+func TestPreTokenizers(t *testing.T) {
+	glmSplit := `{"type": "Sequence", "pretokenizers": [
+		{"type": "Split", "pattern": {"Regex": "(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\\r\\n\\p{L}\\p{N}]?\\p{L}+|\\p{N}{1,3}| ?[^\\s\\p{L}\\p{N}]+[\\r\\n]*|\\s*[\\r\\n]+|\\s+(?!\\S)|\\s+"}, "behavior": "Isolated", "invert": false},
+		{"type": "ByteLevel", "add_prefix_space": false, "trim_offsets": true, "use_regex": false}
+	]}`
+
+	tests := []struct {
+		name   string
+		config string
+		text   string
+		want   []string
+	}{
+		{"split+bytelevel", glmSplit, "Hello world  123456\n\nfoo", []string{"Hello", "Ġworld", "Ġ", "Ġ", "123", "456", "ĊĊ", "foo"}},
+		{"bytelevel", `{"type": "ByteLevel", "add_prefix_space": true}`, "it's 2 much", []string{"Ġit", "'s", "Ġ2", "Ġmuch"}},
+		{"whitespace", `{"type": "Whitespace"}`, "Hey friend!  How are you?!?", []string{"Hey", "friend", "!", "How", "are", "you", "?!?"}},
+		{"whitespace split", `{"type": "WhitespaceSplit"}`, "Hey friend!  How", []string{"Hey", "friend!", "How"}},
+		{"bert", `{"type": "BertPreTokenizer"}`, "Hey, you!", []string{"Hey", ",", "you", "!"}},
+		{"digits", `{"type": "Digits", "individual_digits": true}`, "Call 123", []string{"Call ", "1", "2", "3"}},
+		{"digits contiguous", `{"type": "Digits", "individual_digits": false}`, "Call 123", []string{"Call ", "123"}},
+		{"metaspace", `{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always", "split": true}`, "Hello  world", []string{"▁Hello", "▁", "▁world"}},
+		{"metaspace legacy", `{"type": "Metaspace", "replacement": "▁", "add_prefix_space": false}`, "Hello world", []string{"Hello", "▁world"}},
+		{"punctuation", `{"type": "Punctuation", "behavior": "Isolated"}`, "Hey, you!", []string{"Hey", ",", " you", "!"}},
+		{"split removed", `{"type": "Split", "pattern": {"String": " "}, "behavior": "Removed", "invert": false}`, "a b  c", []string{"a", "b", "c"}},
+		{"split merged with previous", `{"type": "Split", "pattern": {"String": "-"}, "behavior": "MergedWithPrevious", "invert": false}`, "a-b-c", []string{"a-", "b-", "c"}},
+	}
+
+	for _, tt := range tests {
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.config), &config); err != nil {
+			t.Fatalf("%s: invalid config: %v", tt.name, err)
+		}
+		pt, err := newPreTokenizer(config)
+		if err != nil {
+			t.Fatalf("%s: failed to build pre_tokenizer: %v", tt.name, err)
+		}
+
+		var got []string
+		for _, piece := range pt.preTokenize([]*normalizedString{newNormalizedString(tt.text)}) {
+			got = append(got, piece.text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: preTokenize(%q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestPreTokenizerOffsets 测试预分词片段对齐回原始文本
+func TestPreTokenizerOffsets(t *testing.T) {
+	pt := &byteLevelPreTokenizer{useRegex: true}
+	text := "héllo wörld"
+	pieces := pt.preTokenize([]*normalizedString{newNormalizedString(text)})
+
+	want := [][2]int{{0, 6}, {6, 13}}
+	if len(pieces) != len(want) {
+		t.Fatalf("got %d pieces, want %d", len(pieces), len(want))
+	}
+	for i, piece := range pieces {
+		if got := piece.originalOffsets(); got != want[i] {
+			t.Errorf("piece %q offsets = %v, want %v", piece.text, got, want[i])
+		}
+	}
+}
+
+// TestGPT2Matches 测试手写的GPT-2切分与正则表达式的结果一致
+func TestGPT2Matches(t *testing.T) {
+	texts := []string{
+		"", " ", "  ", "a", " a", "  a", "\n\nHello", "Hello  world  ", "I'm here, you'll see 'em 'S",
+		"x\t\t y", "3.14 apples 12abc", "你好，世界！ 中文 123", "héllo\u00a0wörld\u3000！", "a\r\n  \n b",
+		"'", "''ve", "  ?!", "e\u0301 ", "\xff\xfe bad",
+	}
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []rune("ab1 \t\n'.,你\u00a0\u0301٣svelmdrt")
+	for i := 0; i < 500; i++ {
+		runes := make([]rune, rng.Intn(12))
+		for j := range runes {
+			runes[j] = alphabet[rng.Intn(len(alphabet))]
+		}
+		texts = append(texts, string(runes))
+	}
+
+	for _, text := range texts {
+		got, want := gpt2Matches(text), regexpMatches(gpt2SplitRegexp, text)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("gpt2Matches(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+// TestUnsupportedPreTokenizer 测试未知类型返回错误
+func TestUnsupportedPreTokenizer(t *testing.T) {
+	if _, err := newPreTokenizer(map[string]interface{}{"type": "Unknown"}); err == nil {
+		t.Error("expected error for unsupported pre_tokenizer type")
+	}
+}
@@ -0,0 +1,137 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// precompiledNormalizer SentencePiece的预编译字符映射表（precompiled_charsmap）。
+// 映射表的前4字节为trie的字节数（小端），之后是darts-clone双数组trie，其余为以\0分隔的替换串，
+// trie中的值为替换串在其中的起始位置。替换规则与HF的Precompiled一致：
+// 按字素簇查找，簇不足6字节且有前缀命中时整体替换，否则逐字符查找
+type precompiledNormalizer struct {
+	trie       []uint32
+	normalized []byte
+}
+
+// newPrecompiledNormalizer 由base64编码的映射表创建，映射表为空时不做任何替换
+func newPrecompiledNormalizer(config map[string]interface{}) (*precompiledNormalizer, error) {
+	encoded, _ := config["precompiled_charsmap"].(string)
+	charsmap, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid precompiled_charsmap: %v", err)
+	}
+	if len(charsmap) == 0 {
+		return &precompiledNormalizer{}, nil
+	}
+	if len(charsmap) < 4 {
+		return nil, fmt.Errorf("invalid precompiled_charsmap: truncated header")
+	}
+	trieSize := int(binary.LittleEndian.Uint32(charsmap))
+	if trieSize%4 != 0 || trieSize > len(charsmap)-4 {
+		return nil, fmt.Errorf("invalid precompiled_charsmap: trie size %d", trieSize)
+	}
+
+	p := &precompiledNormalizer{trie: make([]uint32, trieSize/4), normalized: charsmap[4+trieSize:]}
+	for i := range p.trie {
+		p.trie[i] = binary.LittleEndian.Uint32(charsmap[4+4*i:])
+	}
+	return p, nil
+}
+
+// darts-clone双数组单元的各字段
+func dartsHasLeaf(unit uint32) bool  { return (unit>>8)&1 == 1 }
+func dartsValue(unit uint32) int     { return int(unit & (1<<31 - 1)) }
+func dartsLabel(unit uint32) uint32  { return unit & (1<<31 | 0xFF) }
+func dartsOffset(unit uint32) uint32 { return (unit >> 10) << ((unit & (1 << 9)) >> 6) }
+
+// commonPrefixSearch 返回key的所有在trie中的前缀对应的值，按前缀由短到长排列
+func (p *precompiledNormalizer) commonPrefixSearch(key string) []int {
+	if len(p.trie) == 0 {
+		return nil
+	}
+	var results []int
+	pos := dartsOffset(p.trie[0])
+	for i := 0; i < len(key); i++ {
+		c := uint32(key[i])
+		if c == 0 {
+			break
+		}
+		pos ^= c
+		if int(pos) >= len(p.trie) {
+			break
+		}
+		unit := p.trie[pos]
+		if dartsLabel(unit) != c {
+			break
+		}
+		pos ^= dartsOffset(unit)
+		if int(pos) >= len(p.trie) {
+			break
+		}
+		if dartsHasLeaf(unit) {
+			results = append(results, dartsValue(p.trie[pos]))
+		}
+	}
+	return results
+}
+
+// transform 返回chunk的替换串：与HF一致取最短的命中前缀
+func (p *precompiledNormalizer) transform(chunk string) (string, bool) {
+	results := p.commonPrefixSearch(chunk)
+	if len(results) == 0 || results[0] > len(p.normalized) {
+		return "", false
+	}
+	start := results[0]
+	end := start
+	for end < len(p.normalized) && p.normalized[end] != 0 {
+		end++
+	}
+	return string(p.normalized[start:end]), true
+}
+
+func (p *precompiledNormalizer) normalize(n *normalizedString) *normalizedString {
+	if len(p.trie) == 0 {
+		return n
+	}
+
+	var sb strings.Builder
+	alignments := make([][2]int, 0, len(n.text))
+	modified := false
+	emit := func(start, end int, replacement string) {
+		span := n.span(start, end)
+		sb.WriteString(replacement)
+		for k := 0; k < len(replacement); k++ {
+			alignments = append(alignments, span)
+		}
+	}
+	for i := 0; i < len(n.text); {
+		end := i + nextGrapheme(n.text[i:])
+		if end-i < 6 {
+			if replacement, ok := p.transform(n.text[i:end]); ok {
+				emit(i, end, replacement)
+				modified = true
+				i = end
+				continue
+			}
+		}
+		for i < end {
+			_, size := utf8.DecodeRuneInString(n.text[i:])
+			if replacement, ok := p.transform(n.text[i : i+size]); ok {
+				emit(i, i+size, replacement)
+				modified = true
+			} else {
+				sb.WriteString(n.text[i : i+size])
+				alignments = append(alignments, n.alignments[i:i+size]...)
+			}
+			i += size
+		}
+	}
+	if !modified {
+		return n
+	}
+	return &normalizedString{text: sb.String(), alignments: alignments}
+}
@@ -0,0 +1,160 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// buildCharsmap 按darts-clone的单元格式构建precompiled_charsmap，用于测试
+func buildCharsmap(mapping map[string]string) []byte {
+	type node struct {
+		children map[byte]*node
+		value    int
+	}
+	newNode := func() *node { return &node{children: map[byte]*node{}, value: -1} }
+
+	var normalized []byte
+	root := newNode()
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cur := root
+		for i := 0; i < len(key); i++ {
+			next, ok := cur.children[key[i]]
+			if !ok {
+				next = newNode()
+				cur.children[key[i]] = next
+			}
+			cur = next
+		}
+		cur.value = len(normalized)
+		normalized = append(append(normalized, mapping[key]...), 0)
+	}
+
+	units := []uint32{0}
+	used := map[uint32]bool{0: true}
+	usedBase := map[uint32]bool{}
+	free := func(pos uint32) bool { return !used[pos] }
+	type item struct {
+		n   *node
+		pos uint32
+	}
+	queue := []item{{root, 0}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		labels := make([]int, 0, len(cur.n.children))
+		for c := range cur.n.children {
+			labels = append(labels, int(c))
+		}
+		sort.Ints(labels)
+
+		// 为节点选择未用过的base：所有子节点位置与值的位置都未被占用，
+		// base不重复才能保证按标签校验时不会走到其他节点的子节点
+		base := uint32(1)
+		for ; ; base++ {
+			ok := !usedBase[base] && (cur.n.value < 0 || free(base))
+			for _, c := range labels {
+				ok = ok && free(base^uint32(c))
+			}
+			if ok {
+				break
+			}
+		}
+		for int(base|0xFF) >= len(units) {
+			units = append(units, 0)
+		}
+		usedBase[base] = true
+		units[cur.pos] |= (cur.pos ^ base) << 10
+		if cur.n.value >= 0 {
+			units[cur.pos] |= 1 << 8
+			units[base] = 1<<31 | uint32(cur.n.value)
+			used[base] = true
+		}
+		for _, c := range labels {
+			pos := base ^ uint32(c)
+			units[pos] = uint32(c)
+			used[pos] = true
+			queue = append(queue, item{cur.n.children[byte(c)], pos})
+		}
+	}
+
+	blob := make([]byte, 4, 4+4*len(units)+len(normalized))
+	binary.LittleEndian.PutUint32(blob, uint32(4*len(units)))
+	for _, unit := range units {
+		blob = append(blob, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(blob[len(blob)-4:], unit)
+	}
+	return append(blob, normalized...)
+}
+
+// TestPrecompiledNormalizer 测试按预编译字符映射表替换字素簇与字符
+func TestPrecompiledNormalizer(t *testing.T) {
+	charsmap := buildCharsmap(map[string]string{
+		"ｈ":       "h",
+		"ｉ":       "i",
+		"①":       "1",
+		"\u00a0":  " ",
+		"e\u0301": "é",
+		"ﬁ":       "fi",
+		"\u200b":  "",
+	})
+	n, err := newNormalizer(map[string]interface{}{
+		"type":                 "Precompiled",
+		"precompiled_charsmap": base64.StdEncoding.EncodeToString(charsmap),
+	})
+	if err != nil {
+		t.Fatalf("newNormalizer failed: %v", err)
+	}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"ｈｉ①\u00a0e\u0301ﬁ\u200bx", "hi1 éfix"},
+		// 不在映射表中的字符保持不变，不再做NFKC
+		{"Ａ ｈ", "Ａ h"},
+		// 超过6字节的字素簇逐字符查找
+		{"ｈ\u0301\u0301", "h\u0301\u0301"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		got := n.normalize(newNormalizedString(tt.text))
+		if got.text != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.text, got.text, tt.want)
+		}
+		if len(got.alignments) != len(got.text) {
+			t.Errorf("normalize(%q): %d alignments for %d bytes", tt.text, len(got.alignments), len(got.text))
+		}
+	}
+
+	got := n.normalize(newNormalizedString("①e\u0301x"))
+	want := [][2]int{{0, 3}, {3, 6}, {3, 6}, {6, 7}}
+	if !reflect.DeepEqual(got.alignments, want) {
+		t.Errorf("alignments = %v, want %v", got.alignments, want)
+	}
+}
+
+// TestPrecompiledNormalizerCharsmap 测试空映射表与损坏的映射表
+func TestPrecompiledNormalizerCharsmap(t *testing.T) {
+	n, err := newNormalizer(map[string]interface{}{"type": "Precompiled", "precompiled_charsmap": ""})
+	if err != nil {
+		t.Fatalf("empty charsmap: %v", err)
+	}
+	if got := n.normalize(newNormalizedString("ｈ①")); got.text != "ｈ①" {
+		t.Errorf("empty charsmap changed text to %q", got.text)
+	}
+
+	for _, encoded := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte{1, 2}),
+		base64.StdEncoding.EncodeToString([]byte{0xFF, 0, 0, 0, 1, 2})} {
+		if _, err := newNormalizer(map[string]interface{}{"type": "Precompiled", "precompiled_charsmap": encoded}); err == nil {
+			t.Errorf("charsmap %q: expected error", encoded)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// PretrainedTokenizerConfig transformers的 tokenizer_config.json，
+// 与 tokenizer.json 放在同一个模型目录中
+type PretrainedTokenizerConfig struct {
+	ModelMaxLength            float64               `json:"model_max_length"` // 无限制时为1e30
+	PaddingSide               string                `json:"padding_side"`
+	TruncationSide            string                `json:"truncation_side"`
+	BosToken                  SpecialTokenValue     `json:"bos_token"`
+	EosToken                  SpecialTokenValue     `json:"eos_token"`
+	UnkToken                  SpecialTokenValue     `json:"unk_token"`
+	PadToken                  SpecialTokenValue     `json:"pad_token"`
+	SepToken                  SpecialTokenValue     `json:"sep_token"`
+	ClsToken                  SpecialTokenValue     `json:"cls_token"`
+	MaskToken                 SpecialTokenValue     `json:"mask_token"`
+	AdditionalSpecialTokens   []SpecialTokenValue   `json:"additional_special_tokens"`
+	CleanUpTokenizationSpaces *bool                 `json:"clean_up_tokenization_spaces"`
+	AddedTokensDecoder        map[string]AddedToken `json:"added_tokens_decoder"` // 键为token ID
+	ChatTemplate              json.RawMessage       `json:"chat_template"`
+}
+
+// SpecialTokenValue 特殊token，兼容字符串与 {"content": ...} 两种写法
+type SpecialTokenValue string
+
+// UnmarshalJSON 解析特殊token
+func (v *SpecialTokenValue) UnmarshalJSON(data []byte) error {
+	var content string
+	if err := json.Unmarshal(data, &content); err == nil {
+		*v = SpecialTokenValue(content)
+		return nil
+	}
+	var token struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return fmt.Errorf("invalid special token: %s", data)
+	}
+	*v = SpecialTokenValue(token.Content)
+	return nil
+}
+
+// maxModelLength model_max_length 不小于该值时视为没有长度限制
+const maxModelLength = 1e15
+
+// resolveModelPath 将模型路径解析为 tokenizer.json 路径与可选的 tokenizer_config.json 路径：
+// 模型目录及其中的 tokenizer.json 读取同目录下的 tokenizer_config.json，<name>.json 只读取文件本身。
+// 目录中没有 tokenizer.json 时使用SentencePiece的 tokenizer.model
+func resolveModelPath(path string) (configPath, pretrainedPath string, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", "", err
+	}
+	configPath = path
+	if info.IsDir() {
+		configPath = filepath.Join(path, "tokenizer.json")
+		if _, err := os.Stat(configPath); err != nil {
+			// 只有SentencePiece模型文件的目录
+			if model := filepath.Join(path, "tokenizer.model"); fileExists(model) {
+				configPath = model
+			}
+		}
+	} else if base := filepath.Base(path); base != "tokenizer.json" && base != "tokenizer.model" {
+		return configPath, "", nil
+	}
+
+	pretrainedPath = filepath.Join(filepath.Dir(configPath), "tokenizer_config.json")
+	if !fileExists(pretrainedPath) {
+		pretrainedPath = ""
+	}
+	return configPath, pretrainedPath, nil
+}
+
+// fileExists 判断路径是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// loadPretrainedConfig 读取 tokenizer_config.json
+func loadPretrainedConfig(path string) (*PretrainedTokenizerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pretrained PretrainedTokenizerConfig
+	if err := json.Unmarshal(data, &pretrained); err != nil {
+		return nil, fmt.Errorf("无法解析tokenizer_config.json: %v", err)
+	}
+	return &pretrained, nil
+}
+
+// mergePretrainedConfig 将 tokenizer_config.json 合并到配置中：最大长度、填充与截断方向、
+// 特殊token角色、解码时的空格清理、chat template，以及 tokenizer.json 中缺少的added token
+func mergePretrainedConfig(config *TokenizerConfig, pretrained *PretrainedTokenizerConfig) error {
+	switch {
+	case pretrained.ModelMaxLength >= maxModelLength:
+		config.MaxTokens = 0
+	case pretrained.ModelMaxLength > 0:
+		config.MaxTokens = int(pretrained.ModelMaxLength)
+	}
+	if pretrained.PaddingSide != "" {
+		config.PaddingSide = pretrained.PaddingSide
+	}
+	if pretrained.TruncationSide != "" {
+		config.TruncationSide = pretrained.TruncationSide
+	}
+	if pretrained.CleanUpTokenizationSpaces != nil {
+		config.CleanUpTokenizationSpaces = *pretrained.CleanUpTokenizationSpaces
+	}
+
+	// tokenizer.json 中的added token优先，只补充缺少的ID
+	ids := make([]int, 0, len(pretrained.AddedTokensDecoder))
+	for key := range pretrained.AddedTokensDecoder {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		if _, exists := config.ReverseVocab[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		token := pretrained.AddedTokensDecoder[strconv.Itoa(id)]
+		token.ID = id
+		config.AddedTokens = append(config.AddedTokens, token)
+		config.Vocabulary[token.Content] = id
+		config.ReverseVocab[id] = token.Content
+	}
+
+	roles := map[string]SpecialTokenValue{
+		"bos":  pretrained.BosToken,
+		"eos":  pretrained.EosToken,
+		"pad":  pretrained.PadToken,
+		"sep":  pretrained.SepToken,
+		"cls":  pretrained.ClsToken,
+		"mask": pretrained.MaskToken,
+	}
+	for role, token := range roles {
+		if token != "" {
+			config.SpecialTokens[role] = string(token)
+		}
+	}
+	// unk_token不覆盖模型的unknown token：与HF一致，编码时的回退只由tokenizer.json中的模型决定
+
+	for _, token := range pretrained.AdditionalSpecialTokens {
+		config.AdditionalSpecialTokens = append(config.AdditionalSpecialTokens, string(token))
+	}
+
+	templates, err := parseChatTemplates(pretrained.ChatTemplate)
+	if err != nil {
+		return err
+	}
+	if templates != nil {
+		config.ChatTemplates = templates
+	}
+	return nil
+}
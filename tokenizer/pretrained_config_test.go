@@ -0,0 +1,112 @@
+package tokenizer_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// writeModelDir 在临时目录中写入 tokenizer.json 与 tokenizer_config.json，返回模型目录
+func writeModelDir(t *testing.T, pretrainedConfig string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "gpt2")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	fixture, err := os.ReadFile(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	files := map[string]string{"tokenizer.json": string(fixture), "tokenizer_config.json": pretrainedConfig}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// TestPretrainedConfig 测试从模型目录加载并合并 tokenizer_config.json
+func TestPretrainedConfig(t *testing.T) {
+	dir := writeModelDir(t, `{
+		"model_max_length": 128000,
+		"padding_side": "left",
+		"eos_token": "<|endoftext|>",
+		"pad_token": {"content": "<|endoftext|>", "special": true},
+		"additional_special_tokens": ["<|user|>"],
+		"clean_up_tokenization_spaces": true,
+		"added_tokens_decoder": {
+			"312": {"content": "<|endoftext|>", "special": true},
+			"400": {"content": "<|user|>", "special": true}
+		}
+	}`)
+
+	tk, err := tokenizer.NewTokenizer(dir)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	info := tk.Info()
+	if info.Name != "gpt2" || info.MaxLength != 128000 || info.VocabSize != 314 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	// added_tokens_decoder 中新增的token参与分词，并作为特殊token在解码时跳过
+	ids, err := tk.Encode("<|user|>Hello , world .")
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if ids[0] != 400 {
+		t.Errorf("ids = %v, want <|user|> (400) first", ids)
+	}
+	text, err := tk.DecodeWithOptions(ids, tokenizer.DecodeOptions{SkipSpecialTokens: true})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if text != "Hello, world." {
+		t.Errorf("decoded %q, want cleaned up %q", text, "Hello, world.")
+	}
+
+	// 通过 tokenizer.json 路径加载时同样读取同目录的 tokenizer_config.json
+	tk, err = tokenizer.NewTokenizer(filepath.Join(dir, "tokenizer.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	if tk.Info().MaxLength != 128000 {
+		t.Errorf("max length = %d, want 128000", tk.Info().MaxLength)
+	}
+
+	// 没有 tokenizer_config.json 时最大长度未知
+	tk, err = tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	if tk.Info().MaxLength != 0 {
+		t.Errorf("max length = %d, want 0 without tokenizer_config.json", tk.Info().MaxLength)
+	}
+	if _, err := tk.EncodeWithOptions("Hello", tokenizer.EncodeOptions{Truncation: &tokenizer.TruncationOptions{}}); err == nil {
+		t.Error("expected error for truncation without max_length")
+	}
+}
+
+// TestPretrainedConfigUnlimitedLength 测试 model_max_length 为1e30时视为没有长度限制
+func TestPretrainedConfigUnlimitedLength(t *testing.T) {
+	dir := writeModelDir(t, `{"model_max_length": 1000000000000000019884624838656}`)
+	registry, err := tokenizer.LoadRegistry(filepath.Dir(dir))
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if want := []string{"gpt2"}; !reflect.DeepEqual(registry.Names(), want) {
+		t.Fatalf("names = %q, want %q", registry.Names(), want)
+	}
+	if models := registry.Models(); models[0].MaxLength != 0 {
+		t.Errorf("max length = %d, want 0", models[0].MaxLength)
+	}
+
+	if _, err := tokenizer.NewTokenizer(writeModelDir(t, `{"eos_token": 1}`)); err == nil {
+		t.Error("expected error for invalid tokenizer_config.json")
+	}
+}
@@ -0,0 +1,169 @@
+package tokenizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ModelInfo 已加载tokenizer的基本信息
+type ModelInfo struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	VocabSize int    `json:"vocab_size"`
+	MaxLength int    `json:"max_length"`
+
+	Approximate bool `json:"approximate,omitempty"` // 内置的后备tokenizer
+}
+
+// Registry 按模型名称管理多个tokenizer。读取与替换tokenizer由读写锁保护，
+// 替换时已取得旧tokenizer的请求不受影响
+type Registry struct {
+	mu          sync.RWMutex
+	tokenizers  map[string]*Tokenizer
+	defaultName string
+
+	dir      string          // 模型目录，为空表示不从目录加载
+	fromDir  map[string]bool // 从模型目录加载的模型，Reload时按目录内容替换
+	reloadMu sync.Mutex      // 串行化Reload与Install
+}
+
+// NewRegistry 创建空的tokenizer注册表
+func NewRegistry() *Registry {
+	return &Registry{tokenizers: make(map[string]*Tokenizer), fromDir: make(map[string]bool)}
+}
+
+// registryEntry 模型目录中的一个模型
+type registryEntry struct {
+	name string
+	path string
+}
+
+// scanRegistryDir 列出目录中的模型：模型目录 <name>/（含tokenizer.json或SentencePiece的tokenizer.model）
+// 与文件 <name>.json、<name>.model、tiktoken的 <name>.tiktoken，以 . 开头的条目（如上传中的临时目录）被忽略
+func scanRegistryDir(dir string) ([]registryEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []registryEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			if !fileExists(filepath.Join(path, "tokenizer.json")) && !fileExists(filepath.Join(path, "tokenizer.model")) {
+				continue
+			}
+		} else if ext := filepath.Ext(name); ext == ".json" || ext == ".tiktoken" || ext == ".model" {
+			name = strings.TrimSuffix(name, ext)
+		} else {
+			continue
+		}
+		models = append(models, registryEntry{name: name, path: path})
+	}
+	return models, nil
+}
+
+// LoadRegistry 加载目录中的全部tokenizer：模型目录 <name>/（可选tokenizer_config.json）与各模型文件均以name注册，
+// 目录不存在时返回空注册表。单个文件加载失败不影响其余文件，失败信息通过error返回
+func LoadRegistry(dir string) (*Registry, error) {
+	r := NewRegistry()
+	r.dir = dir
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return r, nil
+	}
+	if err := r.Reload(); err != nil {
+		if _, failed := err.(*LoadError); !failed {
+			return nil, err
+		}
+		return r, err
+	}
+	return r, nil
+}
+
+// Register 以name注册tokenizer，name同时作为其结果中的模型名称。
+// 第一个注册的tokenizer为默认tokenizer
+func (r *Registry) Register(name string, tk *Tokenizer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tk.config.ModelName = name
+	r.tokenizers[name] = tk
+	if r.defaultName == "" {
+		r.defaultName = name
+	}
+}
+
+// SetDefault 设置未指定模型时使用的tokenizer
+func (r *Registry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tokenizers[name]; !exists {
+		return fmt.Errorf("unknown model: %q", name)
+	}
+	r.defaultName = name
+	return nil
+}
+
+// Get 获取指定名称的tokenizer，name为空时返回默认tokenizer
+func (r *Registry) Get(name string) (*Tokenizer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		name = r.defaultName
+	}
+	tk, exists := r.tokenizers[name]
+	return tk, exists
+}
+
+// Len 返回已注册的tokenizer数量
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.tokenizers)
+}
+
+// Names 返回按名称排序的模型列表
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.tokenizers))
+	for name := range r.tokenizers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Models 返回按名称排序的模型信息
+func (r *Registry) Models() []ModelInfo {
+	var models []ModelInfo
+	for _, name := range r.Names() {
+		if tk, exists := r.Get(name); exists {
+			models = append(models, tk.Info())
+		}
+	}
+	return models
+}
+
+// Info 返回tokenizer的基本信息
+func (t *Tokenizer) Info() ModelInfo {
+	return ModelInfo{
+		Name:      t.config.ModelName,
+		Type:      t.GetModelType(),
+		VocabSize: t.GetVocabSize(),
+		MaxLength: t.config.MaxTokens,
+
+		Approximate: t.config.Approximate,
+	}
+}
@@ -0,0 +1,197 @@
+package tokenizer_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestLoadRegistry 测试从目录加载多个tokenizer并按名称选择
+func TestLoadRegistry(t *testing.T) {
+	dir := t.TempDir()
+	fixture, err := os.ReadFile(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "gpt2"), 0755); err != nil {
+		t.Fatalf("Failed to create model dir: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join("gpt2", "tokenizer.json"): string(fixture),
+		"bert.json":                             bertConfig,
+		"broken.json":                           `{"model": `,
+		"notes.txt":                             "not a tokenizer",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	registry, err := tokenizer.LoadRegistry(dir)
+	if err == nil {
+		t.Error("expected error for broken tokenizer")
+	}
+	if registry == nil {
+		t.Fatal("registry should keep the tokenizers that loaded")
+	}
+	if want := []string{"bert", "gpt2"}; !reflect.DeepEqual(registry.Names(), want) {
+		t.Errorf("names = %q, want %q", registry.Names(), want)
+	}
+
+	models := registry.Models()
+	if len(models) != 2 || models[0].Type != "WordPiece" || models[1].Type != "BPE" || models[1].VocabSize != 313 {
+		t.Errorf("unexpected models: %+v", models)
+	}
+
+	if err := registry.SetDefault("gpt2"); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	tk, ok := registry.Get("")
+	if !ok || tk.GetModelName() != "gpt2" {
+		t.Errorf("default tokenizer = %v, %v, want gpt2", tk, ok)
+	}
+	if _, ok := registry.Get("llama"); ok {
+		t.Error("expected unknown model to be missing")
+	}
+	if err := registry.SetDefault("llama"); err == nil {
+		t.Error("expected error for unknown default model")
+	}
+}
+
+// TestRegistryInstall 测试上传的tokenizer校验后替换同名模型，校验失败时保留原模型
+func TestRegistryInstall(t *testing.T) {
+	dir := t.TempDir()
+	registry, err := tokenizer.LoadRegistry(dir)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	fixture, err := os.ReadFile(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	tk, err := registry.Install("model", map[string][]byte{"tokenizer.json": fixture})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if info := tk.Info(); info.Name != "model" || info.Type != "BPE" {
+		t.Errorf("unexpected model info: %+v", info)
+	}
+	old, _ := registry.Get("")
+
+	tk, err = registry.Install("model", map[string][]byte{
+		"tokenizer.json":        []byte(bertConfig),
+		"tokenizer_config.json": []byte(`{"model_max_length": 128}`),
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	current, _ := registry.Get("model")
+	if current != tk || current.GetModelType() != "WordPiece" || current.Info().MaxLength != 128 {
+		t.Errorf("model was not replaced: %+v", current.Info())
+	}
+	if old.GetModelType() != "BPE" {
+		t.Error("replacing a model should not change the tokenizer already in use")
+	}
+
+	for name, files := range map[string]map[string][]byte{
+		"model":   {"tokenizer.json": []byte(`{"model": `)},
+		"../evil": {"tokenizer.json": fixture},
+		"other":   {"tokenizer_config.json": []byte(`{}`)},
+		"extra":   {"tokenizer.json": fixture, "vocab.txt": []byte("a")},
+	} {
+		if _, err := registry.Install(name, files); err == nil {
+			t.Errorf("expected Install(%q) to fail", name)
+		}
+	}
+	if current, _ := registry.Get("model"); current != tk {
+		t.Error("failed install should keep the previous tokenizer")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "model" {
+		t.Errorf("unexpected files left in registry dir: %v", entries)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "model", "tokenizer.json"))
+	if err != nil || string(data) != bertConfig {
+		t.Errorf("installed tokenizer.json = %q, %v", data, err)
+	}
+}
+
+// TestRegistryReload 测试重新加载时按目录内容替换、删除模型，并保留加载失败与手动注册的模型
+func TestRegistryReload(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	write("a.json", bertConfig)
+	write("b.json", bertConfig)
+
+	registry, err := tokenizer.LoadRegistry(dir)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	registry.Register("manual", writeConfig(t, bertConfig))
+	a, _ := registry.Get("a")
+
+	write("a.json", `{"model": `)
+	if err := os.Remove(filepath.Join(dir, "b.json")); err != nil {
+		t.Fatalf("Failed to remove b.json: %v", err)
+	}
+	write("c.json", t5Config)
+
+	err = registry.Reload()
+	if _, ok := err.(*tokenizer.LoadError); !ok {
+		t.Errorf("Reload error = %v, want *LoadError", err)
+	}
+	if want := []string{"a", "c", "manual"}; !reflect.DeepEqual(registry.Names(), want) {
+		t.Errorf("names = %q, want %q", registry.Names(), want)
+	}
+	if current, _ := registry.Get("a"); current != a {
+		t.Error("failed reload should keep the previous tokenizer")
+	}
+	if tk, _ := registry.Get("c"); tk.GetModelName() != "c" || tk.GetModelType() != "Unigram" {
+		t.Errorf("unexpected model c: %+v", tk.Info())
+	}
+	if tk, _ := registry.Get(""); tk != a {
+		t.Errorf("default tokenizer = %s, want a", tk.GetModelName())
+	}
+}
+
+// TestRegistryWatch 测试模型目录变化后自动重新加载
+func TestRegistryWatch(t *testing.T) {
+	dir := t.TempDir()
+	registry, err := tokenizer.LoadRegistry(dir)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+
+	reloaded := make(chan error, 1)
+	stop := registry.Watch(10*time.Millisecond, func(err error) { reloaded <- err })
+	defer stop()
+
+	if err := os.WriteFile(filepath.Join(dir, "bert.json"), []byte(bertConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	select {
+	case err := <-reloaded:
+		if err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("registry was not reloaded")
+	}
+	if _, ok := registry.Get("bert"); !ok {
+		t.Errorf("names = %q, want bert", registry.Names())
+	}
+}
@@ -0,0 +1,248 @@
+package tokenizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LoadError 部分模型加载失败，其余模型已正常加载
+type LoadError struct {
+	Failures []string // 每项为 "<name>: <错误信息>"
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("failed to load %d tokenizer(s): %s", len(e.Failures), strings.Join(e.Failures, "; "))
+}
+
+// Reload 重新加载模型目录中的全部tokenizer，一次性替换注册表中从目录加载的模型：
+// 加载失败的模型保留原有的tokenizer，目录中已删除的模型被移除，通过Register注册的模型不受影响
+func (r *Registry) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	if r.dir == "" {
+		return fmt.Errorf("registry has no tokenizer directory")
+	}
+	entries, err := scanRegistryDir(r.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read tokenizer directory: %v", err)
+	}
+
+	loaded := make(map[string]*Tokenizer, len(entries))
+	var names, failures []string
+	for _, entry := range entries {
+		tk, err := NewTokenizer(entry.path)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", entry.name, err))
+			if old, exists := r.Get(entry.name); exists && r.isFromDir(entry.name) {
+				loaded[entry.name] = old
+				names = append(names, entry.name)
+			}
+			continue
+		}
+		tk.config.ModelName = entry.name
+		loaded[entry.name] = tk
+		names = append(names, entry.name)
+	}
+
+	r.mu.Lock()
+	tokenizers := make(map[string]*Tokenizer, len(r.tokenizers)+len(loaded))
+	for name, tk := range r.tokenizers {
+		if !r.fromDir[name] {
+			tokenizers[name] = tk
+		}
+	}
+	fromDir := make(map[string]bool, len(loaded))
+	for name, tk := range loaded {
+		tokenizers[name] = tk
+		fromDir[name] = true
+	}
+	r.tokenizers, r.fromDir = tokenizers, fromDir
+	if _, exists := r.tokenizers[r.defaultName]; !exists {
+		r.defaultName = ""
+		if len(names) > 0 {
+			r.defaultName = names[0]
+		} else {
+			for name := range r.tokenizers {
+				if r.defaultName == "" || name < r.defaultName {
+					r.defaultName = name
+				}
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	if len(failures) > 0 {
+		return &LoadError{Failures: failures}
+	}
+	return nil
+}
+
+// isFromDir 判断模型是否从模型目录加载
+func (r *Registry) isFromDir(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.fromDir[name]
+}
+
+// modelNamePattern 可安装的模型名称
+var modelNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// installFiles 上传模型时允许的文件，tokenizer.json为必需
+var installFiles = map[string]bool{"tokenizer.json": true, "tokenizer_config.json": true}
+
+// Install 校验上传的模型文件并安装为模型目录下的 <name>/：文件先写入临时目录并加载校验，
+// 通过后替换原有的模型目录并注册。校验失败时不影响已加载的模型；
+// 安装成功但原目录未能删除时同时返回tokenizer与error
+func (r *Registry) Install(name string, files map[string][]byte) (*Tokenizer, error) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	if r.dir == "" {
+		return nil, fmt.Errorf("registry has no tokenizer directory")
+	}
+	if !modelNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid model name: %q", name)
+	}
+	if _, exists := files["tokenizer.json"]; !exists {
+		return nil, fmt.Errorf("tokenizer.json is required")
+	}
+	for file := range files {
+		if !installFiles[file] {
+			return nil, fmt.Errorf("unsupported file: %q", file)
+		}
+	}
+	target := filepath.Join(r.dir, name)
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		return nil, fmt.Errorf("model %q is not a model directory", name)
+	}
+	for _, ext := range []string{".json", ".tiktoken", ".model"} {
+		if fileExists(target + ext) {
+			return nil, fmt.Errorf("model %q is loaded from %s%s and cannot be replaced", name, name, ext)
+		}
+	}
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(r.dir, ".upload-"+name+"-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+	for file, data := range files {
+		if err := os.WriteFile(filepath.Join(staging, file), data, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	tk, err := NewTokenizer(staging)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTokenizer(tk); err != nil {
+		return nil, err
+	}
+
+	// 先将原目录移开再换入新目录，失败时恢复原目录
+	backup := staging + ".old"
+	replaced := false
+	if fileExists(target) {
+		if err := os.Rename(target, backup); err != nil {
+			return nil, err
+		}
+		replaced = true
+	}
+	if err := os.Rename(staging, target); err != nil {
+		if replaced {
+			if restoreErr := os.Rename(backup, target); restoreErr != nil {
+				return nil, fmt.Errorf("%v; failed to restore the previous model from %s: %v", err, backup, restoreErr)
+			}
+		}
+		return nil, err
+	}
+
+	r.Register(name, tk)
+	r.mu.Lock()
+	r.fromDir[name] = true
+	r.mu.Unlock()
+
+	// 新模型已生效，原目录删除失败时仍返回tokenizer，并通过error说明残留的目录
+	if replaced {
+		if err := os.RemoveAll(backup); err != nil {
+			return tk, fmt.Errorf("model %q installed, but failed to remove the previous model at %s: %v", name, backup, err)
+		}
+	}
+	return tk, nil
+}
+
+// validateTokenizer 检查tokenizer可以正常编码与解码
+func validateTokenizer(tk *Tokenizer) error {
+	if tk.GetVocabSize() == 0 {
+		return fmt.Errorf("tokenizer has an empty vocabulary")
+	}
+	ids, err := tk.Encode("Hello, world! 你好，世界。")
+	if err != nil {
+		return fmt.Errorf("tokenizer failed to encode: %v", err)
+	}
+	if _, err := tk.Decode(ids); err != nil {
+		return fmt.Errorf("tokenizer failed to decode: %v", err)
+	}
+	return nil
+}
+
+// dirSignature 模型目录中全部模型文件的名称、大小与修改时间，用于检测变化
+func dirSignature(dir string) string {
+	entries, err := scanRegistryDir(dir)
+	if err != nil {
+		return ""
+	}
+	var parts []string
+	stat := func(path string) {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			parts = append(parts, fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+	for _, entry := range entries {
+		stat(entry.path)
+		for _, file := range []string{"tokenizer.json", "tokenizer.model", "tokenizer_config.json", "chat_template.jinja"} {
+			stat(filepath.Join(entry.path, file))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "\n")
+}
+
+// Watch 每隔interval检查一次模型目录，文件有变化时调用Reload，并将结果传给onReload（可以为nil）。
+// 返回的函数用于停止检查
+func (r *Registry) Watch(interval time.Duration, onReload func(error)) (stop func()) {
+	done := make(chan struct{})
+	signature := dirSignature(r.dir)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			current := dirSignature(r.dir)
+			if current == signature {
+				continue
+			}
+			signature = current
+			err := r.Reload()
+			if onReload != nil {
+				onReload(err)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
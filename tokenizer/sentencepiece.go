@@ -0,0 +1,330 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// SentencePiece的piece类型，对应ModelProto.SentencePiece.Type
+const (
+	spNormal      = 1
+	spUnknown     = 2
+	spControl     = 3
+	spUserDefined = 4
+	spUnused      = 5
+	spByte        = 6
+)
+
+// SentencePiece的模型类型，对应TrainerSpec.ModelType
+const (
+	spUnigram = 1
+	spBPE     = 2
+)
+
+// spPiece ModelProto中的一个piece
+type spPiece struct {
+	piece string
+	score float32
+	typ   int
+}
+
+// spModel 从ModelProto中读取的字段，未出现的字段取proto中的默认值
+type spModel struct {
+	pieces []spPiece
+
+	// TrainerSpec
+	modelType                 int
+	byteFallback              bool
+	splitByWhitespace         bool
+	allowWhitespaceOnlyPieces bool
+	treatWhitespaceAsSuffix   bool
+	bosID, eosID, padID       int
+
+	// NormalizerSpec
+	precompiledCharsmap    []byte
+	addDummyPrefix         bool
+	removeExtraWhitespaces bool
+	escapeWhitespaces      bool
+}
+
+// protoField protobuf的一个字段：varint与定长字段的值在value中，长度前缀字段的内容在data中
+type protoField struct {
+	number int
+	wire   int
+	value  uint64
+	data   []byte
+}
+
+// readProtoFields 按wire格式依次读取消息中的字段
+func readProtoFields(msg []byte, fn func(f protoField) error) error {
+	for len(msg) > 0 {
+		key, n := protoVarint(msg)
+		if n == 0 {
+			return fmt.Errorf("invalid protobuf field key")
+		}
+		msg = msg[n:]
+		f := protoField{number: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case 0: // varint
+			f.value, n = protoVarint(msg)
+			if n == 0 {
+				return fmt.Errorf("invalid varint in field %d", f.number)
+			}
+		case 1: // fixed64
+			if len(msg) < 8 {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			for i := 7; i >= 0; i-- {
+				f.value = f.value<<8 | uint64(msg[i])
+			}
+			n = 8
+		case 2: // 长度前缀
+			length, m := protoVarint(msg)
+			if m == 0 || uint64(len(msg)-m) < length {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			f.data = msg[m : m+int(length)]
+			n = m + int(length)
+		case 5: // fixed32
+			if len(msg) < 4 {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			f.value = uint64(msg[0]) | uint64(msg[1])<<8 | uint64(msg[2])<<16 | uint64(msg[3])<<24
+			n = 4
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", f.wire, f.number)
+		}
+		msg = msg[n:]
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoVarint 读取一个varint，返回值与占用的字节数，格式错误时字节数为0
+func protoVarint(data []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < len(data) && i < 10; i++ {
+		value |= uint64(data[i]&0x7f) << (7 * uint(i))
+		if data[i] < 0x80 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// parseSentencePieceModel 解析SentencePiece的ModelProto，只读取分词需要的字段
+func parseSentencePieceModel(data []byte) (*spModel, error) {
+	m := &spModel{
+		modelType:              spUnigram,
+		splitByWhitespace:      true,
+		bosID:                  1,
+		eosID:                  2,
+		padID:                  -1,
+		addDummyPrefix:         true,
+		removeExtraWhitespaces: true,
+		escapeWhitespaces:      true,
+	}
+
+	err := readProtoFields(data, func(f protoField) error {
+		switch f.number {
+		case 1: // pieces
+			piece := spPiece{typ: spNormal}
+			err := readProtoFields(f.data, func(f protoField) error {
+				switch f.number {
+				case 1:
+					piece.piece = string(f.data)
+				case 2:
+					piece.score = math.Float32frombits(uint32(f.value))
+				case 3:
+					piece.typ = int(f.value)
+				}
+				return nil
+			})
+			m.pieces = append(m.pieces, piece)
+			return err
+		case 2: // trainer_spec
+			return readProtoFields(f.data, func(f protoField) error {
+				switch f.number {
+				case 3:
+					m.modelType = int(f.value)
+				case 22:
+					m.splitByWhitespace = f.value != 0
+				case 24:
+					m.treatWhitespaceAsSuffix = f.value != 0
+				case 26:
+					m.allowWhitespaceOnlyPieces = f.value != 0
+				case 35:
+					m.byteFallback = f.value != 0
+				case 41:
+					m.bosID = int(int32(f.value))
+				case 42:
+					m.eosID = int(int32(f.value))
+				case 43:
+					m.padID = int(int32(f.value))
+				}
+				return nil
+			})
+		case 3: // normalizer_spec
+			return readProtoFields(f.data, func(f protoField) error {
+				switch f.number {
+				case 2:
+					m.precompiledCharsmap = f.data
+				case 3:
+					m.addDummyPrefix = f.value != 0
+				case 4:
+					m.removeExtraWhitespaces = f.value != 0
+				case 5:
+					m.escapeWhitespaces = f.value != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid SentencePiece model: %v", err)
+	}
+	if len(m.pieces) == 0 {
+		return nil, fmt.Errorf("invalid SentencePiece model: no pieces")
+	}
+	return m, nil
+}
+
+// loadSentencePieceConfig 加载SentencePiece的tokenizer.model，转换为与HF转换结果一致的配置：
+// unigram模型使用piece得分，BPE模型按得分由高到低推导merges；control与unknown piece为特殊token，
+// user_defined piece为普通added token；规范化、空白转义与dummy prefix按NormalizerSpec设置
+func loadSentencePieceConfig(path string) (*TokenizerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseSentencePieceModel(data)
+	if err != nil {
+		return nil, err
+	}
+	if m.treatWhitespaceAsSuffix {
+		return nil, fmt.Errorf("SentencePiece treat_whitespace_as_suffix is not supported")
+	}
+
+	config := &TokenizerConfig{
+		Vocabulary:    make(map[string]int),
+		ReverseVocab:  make(map[int]string),
+		SpecialTokens: make(map[string]string),
+		Merges:        make(MergeRanks),
+		ModelName:     modelNameFromPath(path),
+		ByteFallback:  m.byteFallback,
+		FuseUnk:       true,
+	}
+	switch m.modelType {
+	case spUnigram:
+		config.ModelType = "Unigram"
+		config.Scores = make([]float64, len(m.pieces))
+	case spBPE:
+		config.ModelType = "BPE"
+		config.IsBPE = true
+	default:
+		return nil, fmt.Errorf("unsupported SentencePiece model type: %d", m.modelType)
+	}
+
+	for id, piece := range m.pieces {
+		config.ReverseVocab[id] = piece.piece
+		if piece.typ == spUnused {
+			continue // 不参与分词，只用于解码
+		}
+		config.Vocabulary[piece.piece] = id
+		if config.Scores != nil {
+			config.Scores[id] = float64(piece.score)
+		}
+
+		switch piece.typ {
+		case spUnknown:
+			config.SpecialTokens["unk"] = piece.piece
+			config.AddedTokens = append(config.AddedTokens, AddedToken{ID: id, Content: piece.piece, Special: true})
+		case spControl:
+			config.AddedTokens = append(config.AddedTokens, AddedToken{ID: id, Content: piece.piece, Special: true})
+		case spUserDefined:
+			config.AddedTokens = append(config.AddedTokens, AddedToken{ID: id, Content: piece.piece})
+		}
+	}
+	for role, id := range map[string]int{"bos": m.bosID, "eos": m.eosID, "pad": m.padID} {
+		if id >= 0 && id < len(m.pieces) && m.pieces[id].typ == spControl {
+			config.SpecialTokens[role] = m.pieces[id].piece
+		}
+	}
+	if config.IsBPE {
+		addSentencePieceMerges(config, m.pieces)
+	}
+
+	var normalizers []interface{}
+	if len(m.precompiledCharsmap) > 0 {
+		normalizers = append(normalizers, map[string]interface{}{
+			"type": "Precompiled", "precompiled_charsmap": base64.StdEncoding.EncodeToString(m.precompiledCharsmap),
+		})
+	}
+	if m.removeExtraWhitespaces {
+		normalizers = append(normalizers,
+			map[string]interface{}{"type": "Strip", "strip_left": true, "strip_right": true},
+			map[string]interface{}{"type": "Replace", "pattern": map[string]interface{}{"Regex": " {2,}"}, "content": " "},
+		)
+	}
+	if len(normalizers) > 0 {
+		config.Normalizer = map[string]interface{}{"type": "Sequence", "normalizers": normalizers}
+	}
+
+	// piece只在开头含▁时，按▁切分不影响结果，否则整段文本交给模型
+	config.PreTokenizer = map[string]interface{}{"type": "Sequence", "pretokenizers": []interface{}{}}
+	decoders := []interface{}{}
+	if m.escapeWhitespaces {
+		prependScheme := "never"
+		if m.addDummyPrefix {
+			prependScheme = "always"
+		}
+		config.PreTokenizer = map[string]interface{}{
+			"type": "Metaspace", "replacement": "▁", "prepend_scheme": prependScheme,
+			"split": m.splitByWhitespace && !m.allowWhitespaceOnlyPieces,
+		}
+		decoders = append(decoders, map[string]interface{}{"type": "Replace", "pattern": map[string]interface{}{"String": "▁"}, "content": " "})
+	}
+	if m.byteFallback {
+		decoders = append(decoders, map[string]interface{}{"type": "ByteFallback"})
+	}
+	decoders = append(decoders, map[string]interface{}{"type": "Fuse"})
+	if m.addDummyPrefix {
+		decoders = append(decoders, map[string]interface{}{"type": "Strip", "content": " ", "start": 1.0, "stop": 0.0})
+	}
+	config.Decoder = map[string]interface{}{"type": "Sequence", "decoders": decoders}
+	return config, nil
+}
+
+// addSentencePieceMerges 推导SentencePiece BPE的merges：每次合并拼接结果得分最高的相邻piece，
+// 因此normal piece按得分由高到低（得分相同时按ID）排列后的序号即为其各种两段拆分的rank
+func addSentencePieceMerges(config *TokenizerConfig, pieces []spPiece) {
+	var ids []int
+	for id, piece := range pieces {
+		if piece.typ == spNormal {
+			ids = append(ids, id)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool { return pieces[ids[i]].score > pieces[ids[j]].score })
+
+	for rank, id := range ids {
+		token := pieces[id].piece
+		for i := range token {
+			if i == 0 {
+				continue
+			}
+			if _, exists := config.Vocabulary[token[:i]]; !exists {
+				continue
+			}
+			if _, exists := config.Vocabulary[token[i:]]; !exists {
+				continue
+			}
+			config.Merges[[2]string{token[:i], token[i:]}] = rank
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package tokenizer_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// protoMessage 测试中用于构造SentencePiece ModelProto的protobuf编码
+type protoMessage []byte
+
+func (m protoMessage) uvarint(v uint64) protoMessage {
+	var buf [binary.MaxVarintLen64]byte
+	return append(m, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func (m protoMessage) varint(field int, v uint64) protoMessage {
+	return m.uvarint(uint64(field << 3)).uvarint(v)
+}
+
+func (m protoMessage) bytes(field int, data []byte) protoMessage {
+	return append(m.uvarint(uint64(field<<3|2)).uvarint(uint64(len(data))), data...)
+}
+
+func (m protoMessage) float(field int, f float32) protoMessage {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], math.Float32bits(f))
+	return append(m.uvarint(uint64(field<<3|5)), buf[:]...)
+}
+
+// spPiece piece、得分与类型（1 normal，2 unknown，3 control，4 user_defined，5 unused，6 byte）
+type spPiece struct {
+	piece string
+	score float32
+	typ   uint64
+}
+
+// writeSentencePieceModel 将pieces与trainer_spec、normalizer_spec写入模型目录下的tokenizer.model
+func writeSentencePieceModel(t *testing.T, pieces []spPiece, trainerSpec, normalizerSpec protoMessage) string {
+	var model protoMessage
+	for _, p := range pieces {
+		model = model.bytes(1, protoMessage{}.bytes(1, []byte(p.piece)).float(2, p.score).varint(3, p.typ))
+	}
+	model = model.bytes(2, trainerSpec).bytes(3, normalizerSpec)
+
+	dir := filepath.Join(t.TempDir(), "spm")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tokenizer.model"), model, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestSentencePieceUnigram 测试unigram模型：多余空白的去除、dummy prefix、user_defined piece与unused piece
+func TestSentencePieceUnigram(t *testing.T) {
+	pieces := []spPiece{
+		{"<unk>", 0, 2}, {"<s>", 0, 3}, {"</s>", 0, 3}, {"<sep>", 0, 4}, {"▁helloworld", 0, 5},
+		{"▁", -2, 1}, {"▁hello", -3, 1}, {"▁he", -4, 1}, {"llo", -4, 1}, {"h", -5, 1}, {"e", -5, 1}, {"l", -5, 1},
+		{"o", -5, 1}, {"▁world", -3, 1}, {"w", -5, 1}, {"r", -5, 1}, {"d", -5, 1},
+	}
+	dir := writeSentencePieceModel(t, pieces, protoMessage{}.varint(3, 1), protoMessage{})
+	tk, err := tokenizer.NewTokenizer(dir)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	if tk.GetModelName() != "spm" || tk.GetModelType() != "Unigram" {
+		t.Errorf("model = %s (%s), want spm (Unigram)", tk.GetModelName(), tk.GetModelType())
+	}
+
+	tests := []struct {
+		text   string
+		tokens []string
+		ids    []int
+	}{
+		{"  hello   world ", []string{"▁hello", "▁world"}, []int{6, 13}},
+		{"hellx", []string{"▁he", "l", "l", "x"}, []int{7, 11, 11, 0}},
+		{"hello<sep>world", []string{"▁hello", "<sep>", "▁world"}, []int{6, 3, 13}},
+		{"helloworld", []string{"▁hello", "w", "o", "r", "l", "d"}, []int{6, 14, 12, 15, 11, 16}},
+	}
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", tt.text, err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) || !reflect.DeepEqual(result.TokenIDs, tt.ids) {
+			t.Errorf("Tokenize(%q) = %q %v, want %q %v", tt.text, result.Tokens, result.TokenIDs, tt.tokens, tt.ids)
+		}
+	}
+
+	text, err := tk.DecodeWithOptions([]int{1, 6, 13, 2}, tokenizer.DecodeOptions{SkipSpecialTokens: true})
+	if err != nil || text != "hello world" {
+		t.Errorf("Decode = %q, %v, want %q", text, err, "hello world")
+	}
+}
+
+// TestSentencePieceBPE 测试BPE模型：按piece得分而非ID决定合并顺序，未知字符按byte fallback拆为字节
+func TestSentencePieceBPE(t *testing.T) {
+	pieces := []spPiece{{"<unk>", 0, 2}, {"<s>", 0, 3}, {"</s>", 0, 3}}
+	for b := 0; b < 256; b++ {
+		pieces = append(pieces, spPiece{fmt.Sprintf("<0x%02X>", b), 0, 6})
+	}
+	// 259起为normal piece：el的ID更小但得分低于he
+	for i, p := range []string{"el", "he", "ll", "▁he", "llo", "▁hello", "▁", "h", "e", "l", "o", "w", "r", "d"} {
+		pieces = append(pieces, spPiece{p, -float32(i), 1})
+	}
+	pieces[259].score = -100
+	dir := writeSentencePieceModel(t, pieces, protoMessage{}.varint(3, 2).varint(35, 1), protoMessage{})
+	tk, err := tokenizer.NewTokenizer(dir)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	tests := []struct {
+		text   string
+		tokens []string
+	}{
+		{"hello world", []string{"▁hello", "▁", "w", "o", "r", "l", "d"}},
+		{"hel", []string{"▁he", "l"}},
+		{"hé", []string{"▁", "h", "<0xC3>", "<0xA9>"}},
+	}
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", tt.text, err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) {
+			t.Errorf("Tokenize(%q) tokens = %q, want %q", tt.text, result.Tokens, tt.tokens)
+		}
+		if text, err := tk.Decode(result.TokenIDs); err != nil || text != tt.text {
+			t.Errorf("Decode(%v) = %q, %v, want %q", result.TokenIDs, text, err, tt.text)
+		}
+	}
+}
+
+// TestSentencePieceWithoutUnk 测试没有unknown piece的模型：未知字符被丢弃，而不是编码为ID 0
+func TestSentencePieceWithoutUnk(t *testing.T) {
+	pieces := []spPiece{{"<s>", 0, 3}, {"</s>", 0, 3}, {"▁", 0, 1}, {"h", -1, 1}, {"i", -2, 1}}
+	dir := writeSentencePieceModel(t, pieces, protoMessage{}.varint(3, 2), protoMessage{})
+	tk, err := tokenizer.NewTokenizer(dir)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	result, err := tk.Tokenize("hix")
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	if want := []string{"▁", "h", "i"}; !reflect.DeepEqual(result.Tokens, want) {
+		t.Errorf("Tokens = %q, want %q", result.Tokens, want)
+	}
+	if want := []int{2, 3, 4}; !reflect.DeepEqual(result.TokenIDs, want) {
+		t.Errorf("TokenIDs = %v, want %v", result.TokenIDs, want)
+	}
+}
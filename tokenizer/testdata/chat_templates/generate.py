@@ -0,0 +1,95 @@
+"""用Jinja2按transformers apply_chat_template的渲染环境生成chat template测试的期望输出。
+
+每个子目录包含模型的chat_template.jinja与cases.json，脚本读取各用例的输入
+（variables、messages、tools、add_generation_prompt），重新计算expected（渲染失败时为error）后写回：
+
+    pip install "jinja2>=3.1"
+    python tokenizer/testdata/chat_templates/generate.py
+
+加--check时不写回，只对比并列出与提交的期望输出不同的子目录，有差异时退出码为1。
+
+渲染环境与transformers的_compile_jinja_template一致：ImmutableSandboxedEnvironment，
+trim_blocks与lstrip_blocks，loopcontrols扩展，tojson使用ensure_ascii=False，
+以及全局函数raise_exception与strftime_now。
+"""
+import json
+import os
+import sys
+from datetime import datetime
+
+import jinja2
+from jinja2.ext import loopcontrols
+from jinja2.sandbox import ImmutableSandboxedEnvironment
+
+ROOT = os.path.dirname(os.path.abspath(__file__))
+INPUT_FIELDS = ("name", "variables", "messages", "tools", "add_generation_prompt")
+
+
+def raise_exception(message):
+    raise jinja2.exceptions.TemplateError(message)
+
+
+def tojson(x, ensure_ascii=False, indent=None, separators=None, sort_keys=False):
+    return json.dumps(x, ensure_ascii=ensure_ascii, indent=indent, separators=separators, sort_keys=sort_keys)
+
+
+def strftime_now(format):
+    return datetime.now().strftime(format)
+
+
+def compile_template(source):
+    env = ImmutableSandboxedEnvironment(trim_blocks=True, lstrip_blocks=True, extensions=[loopcontrols])
+    env.filters["tojson"] = tojson
+    env.globals["raise_exception"] = raise_exception
+    env.globals["strftime_now"] = strftime_now
+    return env.from_string(source)
+
+
+def expected(template, case):
+    result = {field: case[field] for field in INPUT_FIELDS if field in case}
+    variables = dict(case.get("variables", {}))
+    variables["messages"] = case["messages"]
+    variables["add_generation_prompt"] = case["add_generation_prompt"]
+    if case.get("tools"):
+        variables["tools"] = case["tools"]
+    try:
+        result["expected"] = template.render(**variables)
+    except jinja2.exceptions.TemplateError as e:
+        result["error"] = e.message
+    return result
+
+
+def dump(cases):
+    """每个用例一个对象，每个字段一行"""
+    lines = []
+    for case in cases:
+        fields = ["    %s: %s" % (json.dumps(k), json.dumps(v, ensure_ascii=False)) for k, v in case.items()]
+        lines.append("  {\n" + ",\n".join(fields) + "\n  }")
+    return "[\n" + ",\n".join(lines) + "\n]\n"
+
+
+def main(check):
+    failed = False
+    for name in sorted(os.listdir(ROOT)):
+        path = os.path.join(ROOT, name, "cases.json")
+        if not os.path.exists(path):
+            continue
+        with open(os.path.join(ROOT, name, "chat_template.jinja"), encoding="utf-8") as f:
+            template = compile_template(f.read())
+        with open(path, encoding="utf-8") as f:
+            cases = json.load(f)
+        output = dump([expected(template, case) for case in cases])
+        if check:
+            with open(path, encoding="utf-8") as f:
+                same = f.read() == output
+            print("%s: %d cases, %s" % (name, len(cases), "ok" if same else "differs"))
+            failed = failed or not same
+            continue
+        with open(path, "w", encoding="utf-8") as f:
+            f.write(output)
+        print("%s: %d cases" % (name, len(cases)))
+    return 1 if failed else 0
+
+
+if __name__ == "__main__":
+    sys.exit(main("--check" in sys.argv[1:]))
@@ -0,0 +1,68 @@
+"""用HF tokenizers生成golden测试的期望输出。
+
+每个子目录包含一个tokenizer.json与expected.json，脚本读取expected.json中各用例的输入
+（text、pair、add_special_tokens），用HF tokenizers重新计算其余字段后写回：
+
+    pip install "tokenizers==0.19.1"
+    python tokenizer/testdata/golden/generate.py
+
+加--check时不写回，只对比并列出与提交的期望输出不同的子目录，有差异时退出码为1。
+"""
+import json
+import os
+import sys
+
+from tokenizers import Tokenizer
+
+ROOT = os.path.dirname(os.path.abspath(__file__))
+INPUT_FIELDS = ("text", "pair", "add_special_tokens")
+
+
+def expected(tokenizer, case):
+    encoding = tokenizer.encode(case["text"], case.get("pair") or None,
+                                add_special_tokens=case["add_special_tokens"])
+    result = {field: case[field] for field in INPUT_FIELDS if field in case}
+    result.update(
+        ids=encoding.ids,
+        tokens=encoding.tokens,
+        type_ids=encoding.type_ids,
+        offsets=[list(offsets) for offsets in encoding.offsets],
+        decoded=tokenizer.decode(encoding.ids, skip_special_tokens=False),
+        decoded_skip_special=tokenizer.decode(encoding.ids, skip_special_tokens=True),
+    )
+    return result
+
+
+def dump(cases):
+    """每个用例一个对象，每个字段一行"""
+    lines = []
+    for case in cases:
+        fields = ["    %s: %s" % (json.dumps(k), json.dumps(v, ensure_ascii=False)) for k, v in case.items()]
+        lines.append("  {\n" + ",\n".join(fields) + "\n  }")
+    return "[\n" + ",\n".join(lines) + "\n]\n"
+
+
+def main(check):
+    failed = False
+    for name in sorted(os.listdir(ROOT)):
+        path = os.path.join(ROOT, name, "expected.json")
+        if not os.path.exists(path):
+            continue
+        tokenizer = Tokenizer.from_file(os.path.join(ROOT, name, "tokenizer.json"))
+        with open(path, encoding="utf-8") as f:
+            cases = json.load(f)
+        output = dump([expected(tokenizer, case) for case in cases])
+        if check:
+            with open(path, encoding="utf-8") as f:
+                same = f.read() == output
+            print("%s: %d cases, %s" % (name, len(cases), "ok" if same else "differs"))
+            failed = failed or not same
+            continue
+        with open(path, "w", encoding="utf-8") as f:
+            f.write(output)
+        print("%s: %d cases" % (name, len(cases)))
+    return 1 if failed else 0
+
+
+if __name__ == "__main__":
+    sys.exit(main("--check" in sys.argv[1:]))
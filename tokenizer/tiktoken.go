@@ -0,0 +1,140 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tiktoken各编码的预分词正则。原始正则中的占有量词（?+、++）在这些位置不影响匹配结果，
+// 改写为regexp2支持的普通量词
+const (
+	cl100kSplitPattern = `'(?i:[sdmt]|ll|ve|re)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]|\s+(?!\S)|\s+`
+	o200kSplitPattern  = `[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+		`|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+`
+)
+
+// tiktokenEncoding tiktoken编码的预分词正则与特殊token，pattern为空时使用GPT-2的ByteLevel正则
+type tiktokenEncoding struct {
+	name          string
+	pattern       string
+	specialTokens map[string]int
+	vocabSize     int // 不含特殊token的词汇表大小，用于按大小识别未知文件名
+}
+
+// tiktokenEncodings 按词汇表从大到小排列，p50k_edit排在p50k_base之前以便按名称优先匹配
+var tiktokenEncodings = []tiktokenEncoding{
+	{name: "o200k_base", pattern: o200kSplitPattern, vocabSize: 199998,
+		specialTokens: map[string]int{"<|endoftext|>": 199999, "<|endofprompt|>": 200018}},
+	{name: "cl100k_base", pattern: cl100kSplitPattern, vocabSize: 100256,
+		specialTokens: map[string]int{"<|endoftext|>": 100257, "<|fim_prefix|>": 100258, "<|fim_middle|>": 100259,
+			"<|fim_suffix|>": 100260, "<|endofprompt|>": 100276}},
+	{name: "p50k_edit", vocabSize: 50281,
+		specialTokens: map[string]int{"<|endoftext|>": 50256, "<|fim_prefix|>": 50281, "<|fim_middle|>": 50282, "<|fim_suffix|>": 50283}},
+	{name: "p50k_base", vocabSize: 50280, specialTokens: map[string]int{"<|endoftext|>": 50256}},
+	{name: "r50k_base", vocabSize: 50256, specialTokens: map[string]int{"<|endoftext|>": 50256}},
+}
+
+// tiktokenEncodingFor 按文件名中的编码名称（如cl100k_base.tiktoken，可省略_base后缀）选择编码，
+// 文件名中没有编码名称时按词汇表大小选择不小于它的最小编码，并记录推断结果
+func tiktokenEncodingFor(name string, vocabSize int) tiktokenEncoding {
+	for _, encoding := range tiktokenEncodings {
+		if strings.Contains(name, strings.TrimSuffix(encoding.name, "_base")) {
+			return encoding
+		}
+	}
+	match := tiktokenEncodings[0]
+	for _, encoding := range tiktokenEncodings {
+		if encoding.vocabSize >= vocabSize {
+			match = encoding
+		}
+	}
+	log.Printf("No encoding name in tiktoken file %s, guessed %s from vocabulary size %d", name, match.name, vocabSize)
+	return match
+}
+
+// loadTiktokenConfig 加载tiktoken的rank文件：每行为base64编码的token字节与其rank，rank即token ID。
+// token按GPT-2字节级字母表转换为字符串，按所选编码的正则预分词，merges由词汇表推导
+func loadTiktokenConfig(path string) (*TokenizerConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config := &TokenizerConfig{
+		Vocabulary:    make(map[string]int),
+		ReverseVocab:  make(map[int]string),
+		SpecialTokens: make(map[string]string),
+		ModelName:     modelNameFromPath(path),
+		IsBPE:         true,
+		ModelType:     "BPE",
+		IgnoreMerges:  true,
+		RankedMerges:  true,
+		Decoder:       map[string]interface{}{"type": "ByteLevel"},
+	}
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid tiktoken line %d", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(string(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tiktoken token on line %d: %v", line, err)
+		}
+		rank, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tiktoken rank on line %d: %v", line, err)
+		}
+
+		var sb strings.Builder
+		for _, c := range token {
+			sb.WriteRune(byteEncoder[c])
+		}
+		config.Vocabulary[sb.String()] = rank
+		config.ReverseVocab[rank] = sb.String()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(config.Vocabulary) == 0 {
+		return nil, fmt.Errorf("empty tiktoken file")
+	}
+	for c := 0; c < 256; c++ {
+		if _, exists := config.Vocabulary[string(byteEncoder[c])]; !exists {
+			return nil, fmt.Errorf("tiktoken file is missing the token for byte 0x%02x", c)
+		}
+	}
+
+	encoding := tiktokenEncodingFor(filepath.Base(path), len(config.Vocabulary))
+	if encoding.pattern == "" {
+		config.PreTokenizer = map[string]interface{}{"type": "ByteLevel", "add_prefix_space": false, "use_regex": true}
+	} else {
+		config.PreTokenizer = map[string]interface{}{"type": "Sequence", "pretokenizers": []interface{}{
+			map[string]interface{}{"type": "Split", "pattern": map[string]interface{}{"Regex": encoding.pattern}, "behavior": "Isolated"},
+			map[string]interface{}{"type": "ByteLevel", "add_prefix_space": false, "use_regex": false},
+		}}
+	}
+
+	for content, id := range encoding.specialTokens {
+		config.AddedTokens = append(config.AddedTokens, AddedToken{ID: id, Content: content, Special: true})
+		config.Vocabulary[content] = id
+		config.ReverseVocab[id] = content
+	}
+	sort.Slice(config.AddedTokens, func(i, j int) bool { return config.AddedTokens[i].ID < config.AddedTokens[j].ID })
+	config.SpecialTokens["eos"] = "<|endoftext|>"
+	return config, nil
+}
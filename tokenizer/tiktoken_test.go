@@ -0,0 +1,83 @@
+package tokenizer_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestTiktoken 测试tiktoken rank文件的加载：cl100k正则预分词、rank推导的merges、整词直接匹配与特殊token。
+// 测试文件是手工构造的小词汇表，只有前256个字节token的rank与真实的cl100k_base相同
+func TestTiktoken(t *testing.T) {
+	path := filepath.Join("tokenizer", "testdata", "tiktoken", "tiny_cl100k_pattern.tiktoken")
+	tk, err := tokenizer.NewTokenizer(path)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	if tk.GetModelName() != "tiny_cl100k_pattern" {
+		t.Errorf("model name = %q, want %q", tk.GetModelName(), "tiny_cl100k_pattern")
+	}
+
+	tests := []struct {
+		text string
+		ids  []int
+	}{
+		{"hellos worlds", []int{259, 82, 264, 82}},
+		{"He's 12345", []int{39, 68, 270, 220, 269, 19, 20}},
+		{"hello<|endoftext|>xyz", []int{259, 100257, 271}},
+		{"hi!\n", []int{71, 72, 272}},
+		{"你好\n\n", []int{267, 161, 98, 121, 265}},
+	}
+	for _, tt := range tests {
+		ids, err := tk.Encode(tt.text)
+		if err != nil {
+			t.Fatalf("Encode(%q) failed: %v", tt.text, err)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("Encode(%q) = %v, want %v", tt.text, ids, tt.ids)
+		}
+		if text, err := tk.Decode(ids); err != nil || text != tt.text {
+			t.Errorf("Decode(%v) = %q, %v, want %q", ids, text, err, tt.text)
+		}
+	}
+
+	registry, err := tokenizer.LoadRegistry(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{"tiny_cl100k_pattern"}) {
+		t.Errorf("registered models = %v, want [tiny_cl100k_pattern]", names)
+	}
+
+	text, err := tk.DecodeWithOptions([]int{259, 100257, 271}, tokenizer.DecodeOptions{SkipSpecialTokens: true})
+	if err != nil || text != "helloxyz" {
+		t.Errorf("Decode skipping special tokens = %q, %v, want %q", text, err, "helloxyz")
+	}
+}
+
+// TestTiktokenEncodingBySize 测试文件名中没有编码名称时按词汇表大小选择编码：小词汇表使用GPT-2正则
+func TestTiktokenEncodingBySize(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("tokenizer", "testdata", "tiktoken", "tiny_cl100k_pattern.tiktoken"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "custom.tiktoken")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tk, err := tokenizer.NewTokenizer(path)
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	ids, err := tk.Encode("hi!\n<|endoftext|>")
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if want := []int{71, 72, 0, 198, 50256}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Encode = %v, want %v", ids, want)
+	}
+}
@@ -1,19 +1,21 @@
 package tokenizer
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
+	"path/filepath"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // HuggingFaceTokenizerConfig Hugging Face tokenizer配置结构
 type HuggingFaceTokenizerConfig struct {
 	Version       string                 `json:"version"`
-	Truncation    interface{}            `json:"truncation"`
-	Padding       interface{}            `json:"padding"`
+	Truncation    *TruncationOptions     `json:"truncation"`
+	Padding       *hfPadding             `json:"padding"`
 	AddedTokens   []AddedToken           `json:"added_tokens"`
 	Normalizer    map[string]interface{} `json:"normalizer"`
 	PreTokenizer  map[string]interface{} `json:"pre_tokenizer"`
@@ -44,7 +46,49 @@ type HFModel struct {
 	ByteFallback            bool           `json:"byte_fallback"`
 	IgnoreMerges            bool           `json:"ignore_merges"`
 	Vocab                   map[string]int `json:"vocab"`
-	Merges                  [][]string     `json:"merges"`
+	Merges                  MergeList      `json:"merges"`
+	MaxInputCharsPerWord    int            `json:"max_input_chars_per_word"` // WordPiece
+	UnkID                   *int           `json:"unk_id"`                   // Unigram
+	Scores                  []float64      `json:"-"`                        // Unigram: 按ID索引的piece得分
+}
+
+// UnmarshalJSON 解析模型配置，vocab兼容 {"token": id}（BPE/WordPiece）
+// 与 [["piece", score], ...]（Unigram）两种格式
+func (m *HFModel) UnmarshalJSON(data []byte) error {
+	type plainModel HFModel
+	var raw struct {
+		plainModel
+		Vocab json.RawMessage `json:"vocab"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = HFModel(raw.plainModel)
+
+	vocab := bytes.TrimSpace(raw.Vocab)
+	if len(vocab) == 0 || bytes.Equal(vocab, []byte("null")) {
+		return nil
+	}
+	if vocab[0] == '{' {
+		return json.Unmarshal(vocab, &m.Vocab)
+	}
+
+	var pieces [][2]interface{}
+	if err := json.Unmarshal(vocab, &pieces); err != nil {
+		return fmt.Errorf("invalid vocab: %v", err)
+	}
+	m.Vocab = make(map[string]int, len(pieces))
+	m.Scores = make([]float64, len(pieces))
+	for id, piece := range pieces {
+		token, ok := piece[0].(string)
+		score, ok2 := piece[1].(float64)
+		if !ok || !ok2 {
+			return fmt.Errorf("invalid vocab entry: %v", piece)
+		}
+		m.Vocab[token] = id
+		m.Scores[id] = score
+	}
+	return nil
 }
 
 // TokenizerConfig tokenizer配置结构
@@ -53,43 +97,185 @@ type TokenizerConfig struct {
 	ReverseVocab  map[int]string    `json:"reverse_vocab"`
 	SpecialTokens map[string]string `json:"special_tokens"`
 	ModelName     string            `json:"model_name"`
-	MaxTokens     int               `json:"max_tokens"`
-	Merges        map[string]bool   `json:"merges"` // BPE merges
-	IsBPE         bool              `json:"is_bpe"` // 是否为BPE模型
+	MaxTokens     int               `json:"max_tokens"`           // 模型最大输入长度，0表示没有限制
+	Merges        MergeRanks        `json:"merges"`               // BPE merges，值为合并优先级(rank)，越小越先合并
+	IsBPE         bool              `json:"is_bpe"`               // 是否为BPE模型
+	ModelType     string            `json:"model_type,omitempty"` // BPE, WordPiece, WordLevel, Unigram
+
+	Scores               []float64 `json:"scores,omitempty"`                   // Unigram: 按ID索引的piece得分
+	MaxInputCharsPerWord int       `json:"max_input_chars_per_word,omitempty"` // WordPiece: 超长的词直接视为unknown
+
+	ContinuingSubwordPrefix string `json:"continuing_subword_prefix,omitempty"`
+	EndOfWordSuffix         string `json:"end_of_word_suffix,omitempty"`
+	IgnoreMerges            bool   `json:"ignore_merges,omitempty"` // 整词在词汇表中时跳过merges
+	RankedMerges            bool   `json:"ranked_merges,omitempty"` // merges由词汇表推导：拼接后在词汇表中的相邻token可以合并，rank为拼接结果的ID（tiktoken）
+	ByteFallback            bool   `json:"byte_fallback,omitempty"` // 未知字符拆为 <0xNN> 字节token
+	FuseUnk                 bool   `json:"fuse_unk,omitempty"`      // 连续的未知字符合并为一个unknown token
+
+	Normalizer    map[string]interface{} `json:"normalizer,omitempty"`     // HF规范化器配置
+	PreTokenizer  map[string]interface{} `json:"pre_tokenizer,omitempty"`  // HF预分词器配置
+	Decoder       map[string]interface{} `json:"decoder,omitempty"`        // HF解码器配置
+	PostProcessor map[string]interface{} `json:"post_processor,omitempty"` // HF后处理器配置
+	AddedTokens   []AddedToken           `json:"added_tokens,omitempty"`
+	Truncation    *TruncationOptions     `json:"truncation,omitempty"` // tokenizer.json中的默认截断参数
+	Padding       *PaddingOptions        `json:"padding,omitempty"`    // tokenizer.json中的默认填充参数
+
+	ChatTemplates map[string]string `json:"chat_templates,omitempty"` // 按名称保存的chat template，单个模板为default
+
+	PaddingSide               string   `json:"padding_side,omitempty"`                 // tokenizer_config.json: left或right
+	TruncationSide            string   `json:"truncation_side,omitempty"`              // tokenizer_config.json: left或right
+	CleanUpTokenizationSpaces bool     `json:"clean_up_tokenization_spaces,omitempty"` // 解码后去除标点前多余的空格
+	AdditionalSpecialTokens   []string `json:"additional_special_tokens,omitempty"`
+
+	Approximate bool `json:"approximate,omitempty"` // 内置的后备tokenizer，结果只是近似值
 }
 
 // Tokenizer tokenizer结构
 type Tokenizer struct {
-	config *TokenizerConfig
+	config        *TokenizerConfig
+	normalizer    normalizer
+	preTokenizer  preTokenizer
+	decoder       decoder
+	postProcessor postProcessor
+	addedVocab    *addedVocabulary
+	specialIDs    map[int]bool // 特殊token的ID集合
+
+	unigramMaxLen   int     // Unigram: 最长piece的字节长度
+	unigramMinScore float64 // Unigram: 最低piece得分
+
+	vocab *vocabIndex // 词汇表索引，加载时构建
+	bpe   *bpeModel   // BPE: 以token ID表示的merges与词缓存
+}
+
+// DecodeOptions 解码选项
+type DecodeOptions struct {
+	SkipSpecialTokens bool // 跳过特殊token
 }
 
 // TokenizerResult tokenizer结果结构
 type TokenizerResult struct {
-	Tokens       []string `json:"tokens"`
-	TokenIDs     []int    `json:"token_ids"`
-	TokenCount   int      `json:"token_count"`
-	CharCount    int      `json:"char_count"`
-	WordCount    int      `json:"word_count"`
-	LineCount    int      `json:"line_count"`
-	VocabSize    int      `json:"vocab_size"`
-	UnknownCount int      `json:"unknown_count"`
-	ModelName    string   `json:"model_name"`
-}
-
-// NewTokenizer 创建新的tokenizer实例
-func NewTokenizer(configPath string) (*Tokenizer, error) {
+	Tokens            []string `json:"tokens"`
+	TokenIDs          []int    `json:"token_ids"`
+	TypeIDs           []int    `json:"type_ids,omitempty"`
+	SpecialTokensMask []int    `json:"special_tokens_mask,omitempty"` // 1表示特殊token
+	Offsets           [][2]int `json:"offsets"`                       // token在原始文本中的字符区间
+	ByteOffsets       [][2]int `json:"byte_offsets"`                  // token在原始文本中的字节区间
+	WordIDs           []int    `json:"word_ids"`                      // token所属词的序号，-1表示后处理添加的特殊token
+	AttentionMask     []int    `json:"attention_mask"`                // 0表示填充的token
+	TokenCount        int      `json:"token_count"`
+	CharCount         int      `json:"char_count"`
+	WordCount         int      `json:"word_count"`
+	LineCount         int      `json:"line_count"`
+	VocabSize         int      `json:"vocab_size"`
+	UnknownCount      int      `json:"unknown_count"`       // 词汇表外的token数，含byte fallback拆出的字节token
+	ByteFallbackCount int      `json:"byte_fallback_count"` // 通过byte fallback保留下来的字节数
+	ModelName         string   `json:"model_name"`
+	Approximate       bool     `json:"approximate,omitempty"` // 由内置的后备tokenizer得到的近似结果
+
+	Overflowing []*Encoding `json:"overflowing,omitempty"` // 截断后溢出的片段
+}
+
+// NewTokenizer 创建新的tokenizer实例。path可以是模型目录、其中的tokenizer.json或tokenizer.model、单个配置文件或tiktoken文件，
+// 模型目录中的tokenizer_config.json与chat_template.jinja会合并到配置中
+func NewTokenizer(path string) (*Tokenizer, error) {
+	configPath, pretrainedPath, err := resolveModelPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenizer config: %v", err)
+	}
 	config, err := loadConfig(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tokenizer config: %v", err)
 	}
 
-	return &Tokenizer{
-		config: config,
-	}, nil
+	if pretrainedPath != "" {
+		pretrained, err := loadPretrainedConfig(pretrainedPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tokenizer_config.json: %v", err)
+		}
+		if err := mergePretrainedConfig(config, pretrained); err != nil {
+			return nil, fmt.Errorf("failed to load tokenizer_config.json: %v", err)
+		}
+	}
+	if base := filepath.Base(configPath); base == "tokenizer.json" || base == "tokenizer.model" {
+		loadChatTemplateFile(config, filepath.Dir(configPath))
+	}
+	return newTokenizerFromConfig(config)
+}
+
+// newTokenizerFromConfig 由配置构建规范化器、预分词器、后处理器、解码器与模型
+func newTokenizerFromConfig(config *TokenizerConfig) (*Tokenizer, error) {
+	var err error
+	tk := &Tokenizer{
+		config:     config,
+		specialIDs: make(map[int]bool),
+	}
+
+	for _, addedToken := range config.AddedTokens {
+		if addedToken.Special {
+			tk.specialIDs[addedToken.ID] = true
+		}
+	}
+	for _, specialToken := range config.SpecialTokens {
+		if id, exists := config.Vocabulary[specialToken]; exists {
+			tk.specialIDs[id] = true
+		}
+	}
+	for _, specialToken := range config.AdditionalSpecialTokens {
+		if id, exists := config.Vocabulary[specialToken]; exists {
+			tk.specialIDs[id] = true
+		}
+	}
+
+	if config.Normalizer != nil {
+		tk.normalizer, err = newNormalizer(config.Normalizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build normalizer: %v", err)
+		}
+	}
+
+	tk.addedVocab = newAddedVocabulary(config.AddedTokens, tk.normalizer)
+
+	if config.ModelType == "Unigram" {
+		tk.unigramMaxLen, tk.unigramMinScore = unigramBounds(config)
+	}
+
+	if config.PreTokenizer != nil {
+		tk.preTokenizer, err = newPreTokenizer(config.PreTokenizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build pre_tokenizer: %v", err)
+		}
+	}
+
+	if config.PostProcessor != nil {
+		tk.postProcessor, err = newPostProcessor(config.PostProcessor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build post_processor: %v", err)
+		}
+	}
+
+	if config.Decoder != nil {
+		tk.decoder, err = newDecoder(config.Decoder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build decoder: %v", err)
+		}
+	}
+
+	if config.IsBPE {
+		tk.bpe = newBPEModel(config)
+	}
+	tk.vocab = newVocabIndex(tk)
+	return tk, nil
 }
 
 // loadConfig 加载tokenizer配置
 func loadConfig(configPath string) (*TokenizerConfig, error) {
+	switch filepath.Ext(configPath) {
+	case ".tiktoken":
+		return loadTiktokenConfig(configPath)
+	case ".model":
+		return loadSentencePieceConfig(configPath)
+	}
+
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, err
@@ -98,7 +284,12 @@ func loadConfig(configPath string) (*TokenizerConfig, error) {
 	// 尝试解析为Hugging Face格式
 	var hfConfig HuggingFaceTokenizerConfig
 	if err := json.Unmarshal(data, &hfConfig); err == nil {
-		return convertHuggingFaceConfig(&hfConfig)
+		config, err := convertHuggingFaceConfig(&hfConfig)
+		if err != nil {
+			return nil, err
+		}
+		config.ModelName = modelNameFromPath(configPath)
+		return config, nil
 	}
 
 	// 如果不是Hugging Face格式，尝试解析为旧格式
@@ -134,22 +325,62 @@ func loadConfig(configPath string) (*TokenizerConfig, error) {
 		}
 	}
 
+	if config.ModelName == "" {
+		config.ModelName = modelNameFromPath(configPath)
+	}
+
 	return &config, nil
 }
 
+// modelNameFromPath 根据配置文件路径推断模型名称：
+// <name>/tokenizer.json 与 <name>/tokenizer.model 取目录名，<name>.json 取文件名
+func modelNameFromPath(configPath string) string {
+	base := filepath.Base(configPath)
+	if base == "tokenizer.json" || base == "tokenizer.model" {
+		if dir := filepath.Base(filepath.Dir(configPath)); dir != "." && dir != string(filepath.Separator) {
+			return dir
+		}
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
 // convertHuggingFaceConfig 转换Hugging Face配置为内部配置
 func convertHuggingFaceConfig(hfConfig *HuggingFaceTokenizerConfig) (*TokenizerConfig, error) {
 	config := &TokenizerConfig{
 		Vocabulary:    make(map[string]int),
 		ReverseVocab:  make(map[int]string),
 		SpecialTokens: make(map[string]string),
-		Merges:        make(map[string]bool),
-		ModelName:     "GLM4.5",
-		MaxTokens:     512,
+		Merges:        make(MergeRanks),
 	}
 
 	// 检查是否为BPE模型
 	config.IsBPE = hfConfig.Model.Type == "BPE"
+	config.ModelType = hfConfig.Model.Type
+	config.Scores = hfConfig.Model.Scores
+	config.MaxInputCharsPerWord = hfConfig.Model.MaxInputCharsPerWord
+	if config.MaxInputCharsPerWord == 0 {
+		config.MaxInputCharsPerWord = 100
+	}
+	config.ContinuingSubwordPrefix = hfConfig.Model.ContinuingSubwordPrefix
+	config.EndOfWordSuffix = hfConfig.Model.EndOfWordSuffix
+	config.IgnoreMerges = hfConfig.Model.IgnoreMerges
+	config.ByteFallback = hfConfig.Model.ByteFallback
+	config.FuseUnk = hfConfig.Model.FuseUnk
+
+	config.Normalizer = hfConfig.Normalizer
+	config.Decoder = hfConfig.Decoder
+	config.PostProcessor = hfConfig.PostProcessor
+	config.AddedTokens = hfConfig.AddedTokens
+	config.Truncation = hfConfig.Truncation
+	if hfConfig.Padding != nil {
+		config.Padding = hfConfig.Padding.options()
+	}
+
+	// 预分词器配置为null时不做切分，整段文本交给模型
+	config.PreTokenizer = hfConfig.PreTokenizer
+	if config.PreTokenizer == nil {
+		config.PreTokenizer = map[string]interface{}{"type": "Sequence", "pretokenizers": []interface{}{}}
+	}
 
 	// 添加基础词汇表
 	if hfConfig.Model.Vocab != nil {
@@ -159,13 +390,11 @@ func convertHuggingFaceConfig(hfConfig *HuggingFaceTokenizerConfig) (*TokenizerC
 		}
 	}
 
-	// 处理BPE merges
+	// 处理BPE merges，按出现顺序记录rank
 	if config.IsBPE && len(hfConfig.Model.Merges) > 0 {
-		for _, merge := range hfConfig.Model.Merges {
-			// merge是数组格式，如 ["Ġ", "ĠĠĠ"]
-			if len(merge) == 2 {
-				mergeKey := merge[0] + " " + merge[1]
-				config.Merges[mergeKey] = true
+		for rank, merge := range hfConfig.Model.Merges {
+			if _, exists := config.Merges[merge]; !exists {
+				config.Merges[merge] = rank
 			}
 		}
 	}
@@ -184,8 +413,6 @@ func convertHuggingFaceConfig(hfConfig *HuggingFaceTokenizerConfig) (*TokenizerC
 				config.SpecialTokens["eos"] = addedToken.Content
 			case "<pad>":
 				config.SpecialTokens["pad"] = addedToken.Content
-			case "<unk>":
-				config.SpecialTokens["unk"] = addedToken.Content
 			case "<mask>":
 				config.SpecialTokens["mask"] = addedToken.Content
 			case "[MASK]":
@@ -194,195 +421,428 @@ func convertHuggingFaceConfig(hfConfig *HuggingFaceTokenizerConfig) (*TokenizerC
 		}
 	}
 
-	// 确保有基本的特殊token
-	if _, exists := config.SpecialTokens["unk"]; !exists {
-		unkToken := hfConfig.Model.UnknownToken
-		if unkToken == "" {
-			unkToken = "<unk>"
-		}
+	// unknown token由模型决定，模型没有unknown token时不设置，编码时与HF一致丢弃未知piece
+	unkToken := hfConfig.Model.UnknownToken
+	if hfConfig.Model.UnkID != nil {
+		unkToken = config.ReverseVocab[*hfConfig.Model.UnkID]
+	}
+	if _, exists := config.Vocabulary[unkToken]; exists && unkToken != "" {
 		config.SpecialTokens["unk"] = unkToken
-		if _, exists := config.Vocabulary[unkToken]; !exists {
-			config.Vocabulary[unkToken] = len(config.Vocabulary)
-		}
 	}
 
 	return config, nil
 }
 
-// Encode 将文本编码为token IDs
+// Encode 将文本编码为token IDs（不添加特殊token）
 func (t *Tokenizer) Encode(text string) ([]int, error) {
-	tokens := t.tokenize(text)
-	var tokenIDs []int
+	encoding, err := t.EncodeWithOptions(text, EncodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return encoding.IDs, nil
+}
 
-	for _, token := range tokens {
-		if id, exists := t.config.Vocabulary[token]; exists {
-			tokenIDs = append(tokenIDs, id)
-		} else {
-			// 使用unknown token
-			if unkID, exists := t.config.Vocabulary[t.config.SpecialTokens["unk"]]; exists {
-				tokenIDs = append(tokenIDs, unkID)
-			} else {
-				tokenIDs = append(tokenIDs, 0)
+// EncodeWithOptions 按选项编码单句或句对：截断（为特殊token预留位置）后由post_processor
+// 添加特殊token，最后填充
+func (t *Tokenizer) EncodeWithOptions(text string, opts EncodeOptions) (*Encoding, error) {
+	encodings := []*Encoding{t.encodeSequence(text, 0)}
+	if opts.Pair != "" {
+		encodings = append(encodings, t.encodeSequence(opts.Pair, 1))
+	}
+
+	if opts.Truncation != nil {
+		truncation, err := t.truncationOptions(*opts.Truncation)
+		if err != nil {
+			return nil, err
+		}
+		maxLength := truncation.MaxLength
+		if opts.AddSpecialTokens {
+			maxLength = maxInt(maxLength-t.numSpecialTokensToAdd(len(encodings) > 1), 0)
+		}
+		if err := truncateEncodings(encodings, truncation, maxLength); err != nil {
+			return nil, err
+		}
+	}
+
+	encoding, err := t.postProcess(encodings, opts.AddSpecialTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Padding != nil {
+		padding, err := t.paddingOptions(*opts.Padding)
+		if err != nil {
+			return nil, err
+		}
+		padEncodings([]*Encoding{encoding}, padding)
+	}
+	return encoding, nil
+}
+
+// postProcess 应用后处理器并合并为一个编码结果。与HF一致，溢出片段与另一序列
+// （及其溢出片段）组合后同样经过后处理
+func (t *Tokenizer) postProcess(encodings []*Encoding, addSpecialTokens bool) (*Encoding, error) {
+	var overflowing [][]*Encoding
+	first := encodings[0]
+	if len(encodings) == 1 {
+		for _, overflow := range first.Overflowing {
+			overflowing = append(overflowing, []*Encoding{overflow})
+		}
+	} else {
+		pair := encodings[1]
+		for _, overflow := range first.Overflowing {
+			overflowing = append(overflowing, []*Encoding{overflow, pair})
+			for _, pairOverflow := range pair.Overflowing {
+				overflowing = append(overflowing, []*Encoding{overflow, pairOverflow})
 			}
 		}
+		for _, pairOverflow := range pair.Overflowing {
+			overflowing = append(overflowing, []*Encoding{first, pairOverflow})
+		}
+	}
+
+	// 有溢出片段时原序列会再次参与组合，后处理器只能作用于副本
+	encoding, err := t.processEncodings(encodings, addSpecialTokens, len(overflowing) > 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, sequences := range overflowing {
+		overflow, err := t.processEncodings(sequences, addSpecialTokens, true)
+		if err != nil {
+			return nil, err
+		}
+		encoding.Overflowing = append(encoding.Overflowing, overflow)
 	}
+	return encoding, nil
+}
 
-	return tokenIDs, nil
+// processEncodings 应用后处理器并合并编码结果。后处理器会修改类型ID与偏移，
+// copied为true时作用于副本，保留原编码结果
+func (t *Tokenizer) processEncodings(encodings []*Encoding, addSpecialTokens bool, copied bool) (*Encoding, error) {
+	if copied {
+		copies := make([]*Encoding, len(encodings))
+		for i, encoding := range encodings {
+			copies[i] = encoding.slice(0, encoding.Len())
+		}
+		encodings = copies
+	}
+	if t.postProcessor != nil {
+		var err error
+		encodings, err = t.postProcessor.process(encodings, addSpecialTokens)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mergeEncodings(encodings), nil
+}
+
+// numSpecialTokensToAdd 后处理器为单句或句对添加的特殊token数
+func (t *Tokenizer) numSpecialTokensToAdd(isPair bool) int {
+	encodings := []*Encoding{{}}
+	if isPair {
+		encodings = append(encodings, &Encoding{})
+	}
+	encoding, err := t.processEncodings(encodings, true, false)
+	if err != nil {
+		return 0
+	}
+	return encoding.Len()
+}
+
+// encodeSequence 对单段文本分词并查找token IDs，added token直接使用其ID。
+// 每个added token与每个预分词片段各算一个词
+func (t *Tokenizer) encodeSequence(text string, typeID int) *Encoding {
+	encoding := newEncoding(len(text)/encodingBytesPerToken + 1)
+	word := 0
+	for _, segment := range t.splitAddedTokens(text) {
+		if segment.token != nil {
+			encoding.appendToken(segment.token.ID, segment.token.Content, typeID, segment.token.Special,
+				segment.text.originalOffsets(), word)
+			word++
+			continue
+		}
+		word += t.encodeSegment(encoding, segment.text, typeID, word)
+	}
+	encoding.setCharOffsets(text)
+	return encoding
+}
+
+// encodeSegment 对不含added token的规范化片段进行分词并追加到编码结果，
+// firstWord为片段中第一个词的序号，返回片段中的词数
+func (t *Tokenizer) encodeSegment(encoding *Encoding, n *normalizedString, typeID int, firstWord int) int {
+	return t.tokenize(n, func(token modelToken) {
+		id, exists := t.tokenID(token)
+		if !exists {
+			// 使用unknown token，模型没有unknown token时与HF一致丢弃该piece
+			if id, exists = t.unkID(); !exists {
+				return
+			}
+		}
+		encoding.appendToken(id, token.value, typeID, false, token.offsets, firstWord+token.word)
+	})
+}
+
+// unkID 返回unknown token的ID，unknown token未配置或不在词汇表中时返回false
+func (t *Tokenizer) unkID() (int, bool) {
+	unk, exists := t.config.SpecialTokens["unk"]
+	if !exists {
+		return 0, false
+	}
+	id, exists := t.config.Vocabulary[unk]
+	return id, exists
 }
 
 // Decode 将token IDs解码为文本
 func (t *Tokenizer) Decode(tokenIDs []int) (string, error) {
+	return t.DecodeWithOptions(tokenIDs, DecodeOptions{})
+}
+
+// DecodeWithOptions 按选项将token IDs解码为文本，
+// 配置了解码器时由解码器还原文本，否则用空格连接tokens
+func (t *Tokenizer) DecodeWithOptions(tokenIDs []int, opts DecodeOptions) (string, error) {
 	var tokens []string
 
 	for _, id := range tokenIDs {
+		if opts.SkipSpecialTokens && t.specialIDs[id] {
+			continue
+		}
+		// 与HF一致跳过词汇表中没有的ID
 		if token, exists := t.config.ReverseVocab[id]; exists {
 			tokens = append(tokens, token)
-		} else {
-			tokens = append(tokens, t.config.SpecialTokens["unk"])
 		}
 	}
 
-	return strings.Join(tokens, " "), nil
+	var text string
+	if t.decoder == nil {
+		text = strings.Join(tokens, " ")
+	} else {
+		text = strings.Join(t.decoder.decodeChain(tokens), "")
+	}
+	if t.config.CleanUpTokenizationSpaces {
+		text = cleanupTokenizationSpaces(text)
+	}
+	return text, nil
 }
 
 // Tokenize 对文本进行完整的tokenize处理
 func (t *Tokenizer) Tokenize(text string) (*TokenizerResult, error) {
-	tokens := t.tokenize(text)
-	tokenIDs, err := t.Encode(text)
+	return t.TokenizeWithOptions(text, EncodeOptions{})
+}
+
+// TokenizeWithOptions 按编码选项对文本进行完整的tokenize处理
+func (t *Tokenizer) TokenizeWithOptions(text string, opts EncodeOptions) (*TokenizerResult, error) {
+	encoding, err := t.EncodeWithOptions(text, opts)
 	if err != nil {
 		return nil, err
 	}
+	return t.newResult(text, encoding), nil
+}
+
+// newResult 根据编码结果与原始文本构造tokenize结果及统计信息
+func (t *Tokenizer) newResult(text string, encoding *Encoding) *TokenizerResult {
+	tokens := encoding.Tokens
 
 	// 统计信息
-	charCount := len([]rune(text))
-	wordCount := len(strings.Fields(text))
-	lineCount := len(strings.Split(text, "\n"))
-
-	// 统计unknown tokens
-	unknownCount := 0
-	for _, token := range tokens {
-		if _, exists := t.config.Vocabulary[token]; !exists {
+	charCount := utf8.RuneCountInString(text)
+	wordCount := countFields(text)
+	lineCount := strings.Count(text, "\n") + 1
+
+	// 统计unknown tokens（模型输出的unknown token ID，不含文本中作为added token出现的unknown token），
+	// byte fallback拆出的字节token同样来自词汇表外的字符
+	unkID, hasUnk := t.unkID()
+	unknownCount, byteFallbackCount := 0, 0
+	for i, token := range tokens {
+		if encoding.SpecialTokensMask[i] != 0 {
+			continue
+		}
+		if hasUnk && encoding.IDs[i] == unkID {
 			unknownCount++
+		} else if t.config.ByteFallback {
+			if _, ok := parseByteToken(token); ok {
+				byteFallbackCount++
+			}
 		}
 	}
 
 	return &TokenizerResult{
-		Tokens:       tokens,
-		TokenIDs:     tokenIDs,
-		TokenCount:   len(tokens),
-		CharCount:    charCount,
-		WordCount:    wordCount,
-		LineCount:    lineCount,
-		VocabSize:    len(t.config.Vocabulary),
-		UnknownCount: unknownCount,
-		ModelName:    t.config.ModelName,
-	}, nil
-}
-
-// tokenize 基础tokenization逻辑
-func (t *Tokenizer) tokenize(text string) []string {
-	// 如果是BPE模型，使用BPE算法
-	if t.config.IsBPE {
-		return t.bpeTokenize(text)
+		Tokens:            tokens,
+		TokenIDs:          encoding.IDs,
+		TypeIDs:           encoding.TypeIDs,
+		SpecialTokensMask: encoding.SpecialTokensMask,
+		Offsets:           encoding.Offsets,
+		ByteOffsets:       encoding.ByteOffsets,
+		WordIDs:           encoding.WordIDs,
+		AttentionMask:     encoding.AttentionMask,
+		TokenCount:        len(tokens),
+		CharCount:         charCount,
+		WordCount:         wordCount,
+		LineCount:         lineCount,
+		VocabSize:         len(t.config.Vocabulary),
+		UnknownCount:      unknownCount + byteFallbackCount,
+		ByteFallbackCount: byteFallbackCount,
+		ModelName:         t.config.ModelName,
+		Approximate:       t.config.Approximate,
+		Overflowing:       encoding.Overflowing,
 	}
+}
 
-	// 首先尝试直接分词（适用于预训练tokenizer）
-	if tokens := t.directTokenize(text); len(tokens) > 0 {
-		return tokens
+// countFields 返回strings.Fields(text)的长度，不分配切片
+func countFields(text string) int {
+	count := 0
+	inField := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			inField = false
+		} else if !inField {
+			inField = true
+			count++
+		}
 	}
-
-	// 回退到基础分词
-	return t.basicTokenize(text)
+	return count
 }
 
-// bpeTokenize BPE分词算法
-func (t *Tokenizer) bpeTokenize(text string) []string {
-	// 预处理：转换为字符级别
-	words := t.preTokenize(text)
-	var tokens []string
+// modelToken 模型输出的token。模型返回时offsets为token在词中的字节区间，
+// 经tokenize转换后为原始文本中的字节区间，word为所属预分词片段的序号
+type modelToken struct {
+	value   string
+	offsets [2]int
+	word    int
+	id      int // hasID为true时为token ID，模型已知ID时不必再查找词汇表
+	hasID   bool
+}
 
-	for _, word := range words {
-		if word == "" {
-			continue
+// tokenize 对规范化后的文本片段进行预分词和模型分词，按顺序将每个token传给emit，
+// 返回预分词得到的词数
+func (t *Tokenizer) tokenize(n *normalizedString, emit func(token modelToken)) int {
+	model := t.wordModel()
+	if model == nil {
+		tokens, words := t.legacyTokenize(n)
+		for _, token := range tokens {
+			emit(token)
 		}
+		return words
+	}
 
-		// 如果整个词在词汇表中，直接使用
-		if _, exists := t.config.Vocabulary[word]; exists {
-			tokens = append(tokens, word)
-			continue
+	pieces := t.preTokenize(n)
+	for word, piece := range pieces {
+		for _, token := range model(piece.text) {
+			token.offsets = piece.offsets(token.offsets[0], token.offsets[1])
+			token.word = word
+			emit(token)
 		}
-
-		// 否则使用BPE算法
-		wordTokens := t.applyBPE(word)
-		tokens = append(tokens, wordTokens...)
 	}
+	return len(pieces)
+}
 
-	return tokens
+// tokenID 返回token的ID，模型已给出ID时不再查找词汇表
+func (t *Tokenizer) tokenID(token modelToken) (int, bool) {
+	if token.hasID {
+		return token.id, true
+	}
+	id, exists := t.config.Vocabulary[token.value]
+	return id, exists
 }
 
-// preTokenize 预分词处理
-func (t *Tokenizer) preTokenize(text string) []string {
-	// 简单的预分词：按空格和标点符号分割
-	var words []string
-	var currentWord strings.Builder
+// wordModel 返回对单个词进行分词的模型，旧格式的非BPE配置返回nil
+func (t *Tokenizer) wordModel() func(word string) []modelToken {
+	// 如果是BPE模型，使用BPE算法
+	if t.config.IsBPE {
+		return t.bpeWord
+	}
 
-	for _, r := range text {
-		if unicode.IsSpace(r) {
-			if currentWord.Len() > 0 {
-				words = append(words, currentWord.String())
-				currentWord.Reset()
-			}
-		} else if unicode.IsPunct(r) {
-			if currentWord.Len() > 0 {
-				words = append(words, currentWord.String())
-				currentWord.Reset()
-			}
-			words = append(words, string(r))
-		} else {
-			currentWord.WriteRune(r)
-		}
+	switch t.config.ModelType {
+	case "WordPiece":
+		return t.applyWordPiece
+	case "WordLevel":
+		return t.wordLevelWord
+	case "Unigram":
+		return t.unigramWord
 	}
+	return nil
+}
 
-	if currentWord.Len() > 0 {
-		words = append(words, currentWord.String())
+// bpeWord BPE分词算法，结果按词缓存
+func (t *Tokenizer) bpeWord(word string) []modelToken {
+	// ignore_merges时，整个词在词汇表中则直接使用
+	if t.config.IgnoreMerges {
+		if id, exists := t.config.Vocabulary[word]; exists {
+			return []modelToken{{value: word, offsets: [2]int{0, len(word)}, id: id, hasID: true}}
+		}
 	}
 
-	return words
+	if t.bpe == nil || len(word) > maxCachedWordLength {
+		return t.resolveUnknown(t.applyBPE(word))
+	}
+	if tokens, exists := t.bpe.cache.get(word); exists {
+		return tokens
+	}
+	tokens := t.resolveUnknown(t.applyBPE(word))
+	t.bpe.cache.add(word, tokens)
+	return tokens
 }
 
-// applyBPE 应用BPE算法
-func (t *Tokenizer) applyBPE(word string) []string {
-	// 转换为字符列表
-	characters := strings.Split(word, "")
+// legacyTokenize 旧格式配置的分词：首先尝试直接分词，失败时回退到基础分词。
+// 这两种分词不跟踪位置，token的区间通过在文本中依次查找得到，词按空白切分计数
+func (t *Tokenizer) legacyTokenize(n *normalizedString) ([]modelToken, int) {
+	values := t.directTokenize(n.text)
+	if len(values) == 0 {
+		values = t.basicTokenize(n.text)
+	}
+
+	lower := strings.ToLower(n.text)
+	tokens := make([]modelToken, 0, len(values))
+	pos := 0
+	for _, value := range values {
+		start := strings.Index(n.text[pos:], value)
+		if start < 0 && len(lower) == len(n.text) {
+			start = strings.Index(lower[pos:], value)
+		}
+		end := pos
+		if start >= 0 {
+			start += pos
+			end = start + len(value)
+		} else {
+			start = pos
+		}
 
-	// 如果没有merges，回退到字符级别
-	if len(t.config.Merges) == 0 {
-		return characters
+		word := len(strings.Fields(n.text[:start]))
+		if r, size := utf8.DecodeLastRuneInString(n.text[:start]); size > 0 && !unicode.IsSpace(r) && word > 0 {
+			word-- // token位于词的中间
+		}
+		tokens = append(tokens, modelToken{value: value, offsets: n.offsets(start, end), word: word})
+		pos = end
 	}
+	return tokens, len(strings.Fields(n.text))
+}
 
-	// 应用BPE合并规则
-	for {
-		// 寻找可以合并的相邻字符对
-		bestMergeIndex := -1
+// preTokenize 对规范化后的文本进行预分词处理
+func (t *Tokenizer) preTokenize(n *normalizedString) []*normalizedString {
+	if t.preTokenizer != nil {
+		return t.preTokenizer.preTokenize([]*normalizedString{n})
+	}
 
-		for i := 0; i < len(characters)-1; i++ {
-			pair := characters[i] + " " + characters[i+1]
-			if t.config.Merges[pair] {
-				bestMergeIndex = i
-				break // 找到第一个可以合并的就处理
+	// 没有配置预分词器时：按空格和标点符号分割
+	var words []*normalizedString
+	wordStart := -1
+	for i, r := range n.text {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) {
+			if wordStart >= 0 {
+				words = append(words, n.slice(wordStart, i))
+				wordStart = -1
 			}
+			if unicode.IsPunct(r) {
+				words = append(words, n.slice(i, i+utf8.RuneLen(r)))
+			}
+		} else if wordStart < 0 {
+			wordStart = i
 		}
+	}
 
-		if bestMergeIndex == -1 {
-			break // 没有更多可以合并的
-		}
-
-		// 合并字符
-		merged := characters[bestMergeIndex] + characters[bestMergeIndex+1]
-		characters = append(characters[:bestMergeIndex], append([]string{merged}, characters[bestMergeIndex+2:]...)...)
+	if wordStart >= 0 {
+		words = append(words, n.slice(wordStart, len(n.text)))
 	}
 
-	return characters
+	return words
 }
 
 // directTokenize 直接使用词汇表进行分词
@@ -397,11 +857,13 @@ func (t *Tokenizer) directTokenize(text string) []string {
 			continue
 		}
 
-		// 尝试小写匹配
-		lowerWord := strings.ToLower(word)
-		if _, exists := t.config.Vocabulary[lowerWord]; exists {
-			tokens = append(tokens, lowerWord)
-			continue
+		// 没有配置规范化器时，尝试小写匹配
+		if t.normalizer == nil {
+			lowerWord := strings.ToLower(word)
+			if _, exists := t.config.Vocabulary[lowerWord]; exists {
+				tokens = append(tokens, lowerWord)
+				continue
+			}
 		}
 
 		// 如果没有找到，尝试子词匹配
@@ -415,26 +877,27 @@ func (t *Tokenizer) directTokenize(text string) []string {
 // subwordTokenize 子词分词
 func (t *Tokenizer) subwordTokenize(word string) []string {
 	var tokens []string
-	lowerWord := strings.ToLower(word)
-
-	// 简单的子词匹配：尝试从长到短匹配
-	for length := len(lowerWord); length > 0; length-- {
-		for i := 0; i <= len(lowerWord)-length; i++ {
-			subword := lowerWord[i : i+length]
-			if _, exists := t.config.Vocabulary[subword]; exists {
-				tokens = append(tokens, subword)
-				// 跳过已匹配的部分
-				i += length - 1
+	lowerWord := word
+	if t.normalizer == nil {
+		lowerWord = strings.ToLower(word)
+	}
+
+	// 从左到右贪心匹配最长的子词，无法匹配时取单个字符
+	for start := 0; start < len(lowerWord); {
+		end := len(lowerWord)
+		for end > start {
+			if _, exists := t.config.Vocabulary[lowerWord[start:end]]; exists {
 				break
 			}
+			_, size := utf8.DecodeLastRuneInString(lowerWord[start:end])
+			end -= size
 		}
-	}
-
-	// 如果没有匹配到任何子词，回退到字符级别
-	if len(tokens) == 0 {
-		for _, r := range lowerWord {
-			tokens = append(tokens, string(r))
+		if end == start {
+			_, size := utf8.DecodeRuneInString(lowerWord[start:])
+			end = start + size
 		}
+		tokens = append(tokens, lowerWord[start:end])
+		start = end
 	}
 
 	return tokens
@@ -486,34 +949,36 @@ func (t *Tokenizer) GetVocabSize() int {
 	return len(t.config.Vocabulary)
 }
 
-// GetVocabulary 获取词汇表
-func (t *Tokenizer) GetVocabulary() map[string]int {
-	return t.config.Vocabulary
+// GetModelName 获取模型名称
+func (t *Tokenizer) GetModelName() string {
+	return t.config.ModelName
 }
 
-// GetTopTokens 获取前N个最常用的token
-func (t *Tokenizer) GetTopTokens(n int) []string {
-	type tokenCount struct {
-		token string
-		id    int
-	}
+// IsApproximate 是否为内置的后备tokenizer，其token数只是近似值
+func (t *Tokenizer) IsApproximate() bool {
+	return t.config.Approximate
+}
 
-	var tokens []tokenCount
-	for token, id := range t.config.Vocabulary {
-		tokens = append(tokens, tokenCount{token, id})
+// GetModelType 获取模型类型，旧格式配置返回Legacy
+func (t *Tokenizer) GetModelType() string {
+	if t.config.ModelType != "" {
+		return t.config.ModelType
 	}
-
-	// 按ID排序（通常ID越小越常用）
-	sort.Slice(tokens, func(i, j int) bool {
-		return tokens[i].id < tokens[j].id
-	})
-
-	var result []string
-	for i := 0; i < n && i < len(tokens); i++ {
-		result = append(result, tokens[i].token)
+	if t.config.IsBPE {
+		return "BPE"
 	}
+	return "Legacy"
+}
 
-	return result
+// GetVocabulary 获取词汇表
+func (t *Tokenizer) GetVocabulary() map[string]int {
+	return t.config.Vocabulary
+}
+
+// GetTopTokens 获取前N个最常用的token（按ID升序，通常ID越小越常用）
+func (t *Tokenizer) GetTopTokens(n int) []string {
+	n = maxInt(minInt(n, len(t.vocab.tokens)), 0)
+	return append([]string(nil), t.vocab.tokens[:n]...)
 }
 
 // FindTokenByID 根据ID查找token
@@ -522,13 +987,11 @@ func (t *Tokenizer) FindTokenByID(id int) (string, bool) {
 	return token, exists
 }
 
-// FindTokensByPrefix 根据前缀查找tokens
+// FindTokensByPrefix 根据前缀查找tokens，按字典序返回
 func (t *Tokenizer) FindTokensByPrefix(prefix string) []string {
 	var result []string
-	for token := range t.config.Vocabulary {
-		if strings.HasPrefix(token, prefix) {
-			result = append(result, token)
-		}
+	for _, i := range prefixRange(t.vocab.byToken, t.vocab.tokens, prefix) {
+		result = append(result, t.vocab.tokens[i])
 	}
 	return result
 }
@@ -0,0 +1,194 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TruncationOptions 截断选项，字段与tokenizer.json中的truncation一致
+type TruncationOptions struct {
+	MaxLength int    `json:"max_length"`          // 含特殊token的最大长度，为0时使用tokenizer.json或模型的最大长度
+	Strategy  string `json:"strategy,omitempty"`  // longest_first（默认）、only_first、only_second
+	Stride    int    `json:"stride,omitempty"`    // 溢出片段与前一片段重叠的token数
+	Direction string `json:"direction,omitempty"` // 截掉右侧（right，默认）或左侧（left）的token
+}
+
+// normalizeOption 统一HF配置（LongestFirst、Right）与API（longest_first、right）两种写法
+func normalizeOption(value string) string {
+	var sb strings.Builder
+	for i, r := range value {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// truncationOptions 补全截断选项：未指定的字段依次取tokenizer.json的truncation、
+// tokenizer_config.json的truncation_side与模型最大长度
+func (t *Tokenizer) truncationOptions(opts TruncationOptions) (TruncationOptions, error) {
+	defaults := TruncationOptions{MaxLength: t.config.MaxTokens, Direction: t.config.TruncationSide}
+	if t.config.Truncation != nil {
+		defaults = *t.config.Truncation
+		if defaults.Direction == "" {
+			defaults.Direction = t.config.TruncationSide
+		}
+	}
+	if opts.MaxLength == 0 {
+		opts.MaxLength = defaults.MaxLength
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = defaults.Strategy
+	}
+	if opts.Direction == "" {
+		opts.Direction = defaults.Direction
+	}
+	opts.Strategy = normalizeOption(opts.Strategy)
+	opts.Direction = normalizeOption(opts.Direction)
+	if opts.Strategy == "" {
+		opts.Strategy = "longest_first"
+	}
+	if opts.Direction == "" {
+		opts.Direction = "right"
+	}
+
+	switch {
+	case opts.MaxLength <= 0:
+		return opts, fmt.Errorf("truncation requires max_length")
+	case opts.Stride < 0:
+		return opts, fmt.Errorf("invalid truncation stride: %d", opts.Stride)
+	case opts.Strategy != "longest_first" && opts.Strategy != "only_first" && opts.Strategy != "only_second":
+		return opts, fmt.Errorf("unsupported truncation strategy: %q", opts.Strategy)
+	case opts.Direction != "right" && opts.Direction != "left":
+		return opts, fmt.Errorf("unsupported truncation direction: %q", opts.Direction)
+	}
+	return opts, nil
+}
+
+// truncateEncodings 将单句或句对截断到maxLength（已减去将要添加的特殊token数），
+// 截掉的部分按stride切分为溢出片段保存在Overflowing中
+func truncateEncodings(encodings []*Encoding, opts TruncationOptions, maxLength int) error {
+	total := 0
+	for _, encoding := range encodings {
+		total += encoding.Len()
+	}
+	if total <= maxLength {
+		return nil
+	}
+	if maxLength == 0 {
+		for _, encoding := range encodings {
+			if err := encoding.truncate(0, opts.Stride, opts.Direction); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	toRemove := total - maxLength
+
+	switch opts.Strategy {
+	case "longest_first":
+		if len(encodings) == 1 {
+			return encodings[0].truncate(maxLength, opts.Stride, opts.Direction)
+		}
+		// n1为较短序列的长度：较短序列能完整保留时只截断较长序列，否则两者各取一半
+		n1, n2 := encodings[0].Len(), encodings[1].Len()
+		swap := n1 > n2
+		if swap {
+			n1, n2 = n2, n1
+		}
+		if n1 > maxLength {
+			n2 = n1
+		} else {
+			n2 = maxInt(n1, maxLength-n1)
+		}
+		if n1+n2 > maxLength {
+			n1 = maxLength / 2
+			n2 = n1 + maxLength%2
+		}
+		if swap {
+			n1, n2 = n2, n1
+		}
+		if err := encodings[0].truncate(n1, opts.Stride, opts.Direction); err != nil {
+			return err
+		}
+		return encodings[1].truncate(n2, opts.Stride, opts.Direction)
+
+	default:
+		target := 0
+		if opts.Strategy == "only_second" {
+			if len(encodings) < 2 {
+				return fmt.Errorf("truncation strategy only_second requires a sequence pair")
+			}
+			target = 1
+		}
+		length := encodings[target].Len()
+		if length <= toRemove {
+			return fmt.Errorf("sequence is too short to remove %d tokens with strategy %s", toRemove, opts.Strategy)
+		}
+		return encodings[target].truncate(length-toRemove, opts.Stride, opts.Direction)
+	}
+}
+
+// truncate 保留maxLength个token，截掉的部分按 maxLength-stride 的步长切分为溢出片段，
+// 相邻片段重叠stride个token
+func (e *Encoding) truncate(maxLength, stride int, direction string) error {
+	n := e.Len()
+	if maxLength >= n {
+		return nil
+	}
+	if maxLength == 0 {
+		overflow := *e
+		*e = Encoding{Overflowing: []*Encoding{&overflow}}
+		return nil
+	}
+	if stride >= maxLength {
+		return fmt.Errorf("truncation stride (%d) must be smaller than max_length (%d)", stride, maxLength)
+	}
+
+	step := maxLength - stride
+	var ranges [][2]int
+	if direction == "left" {
+		for stop := n; stop > 0; stop -= step {
+			start := maxInt(stop-maxLength, 0)
+			ranges = append(ranges, [2]int{start, stop})
+			if start == 0 {
+				break
+			}
+		}
+	} else {
+		for start := 0; start < n; start += step {
+			stop := minInt(start+maxLength, n)
+			ranges = append(ranges, [2]int{start, stop})
+			if stop == n {
+				break
+			}
+		}
+	}
+
+	parts := make([]*Encoding, len(ranges))
+	for i, r := range ranges {
+		parts[i] = e.slice(r[0], r[1])
+	}
+	*e = *parts[0]
+	e.Overflowing = parts[1:]
+	return nil
+}
+
+// slice 返回[start, stop)区间内token组成的新编码结果，不包含溢出片段
+func (e *Encoding) slice(start, stop int) *Encoding {
+	return &Encoding{
+		IDs:               append([]int{}, e.IDs[start:stop]...),
+		Tokens:            append([]string{}, e.Tokens[start:stop]...),
+		TypeIDs:           append([]int{}, e.TypeIDs[start:stop]...),
+		SpecialTokensMask: append([]int{}, e.SpecialTokensMask[start:stop]...),
+		AttentionMask:     append([]int{}, e.AttentionMask[start:stop]...),
+		Offsets:           append([][2]int{}, e.Offsets[start:stop]...),
+		ByteOffsets:       append([][2]int{}, e.ByteOffsets[start:stop]...),
+		WordIDs:           append([]int{}, e.WordIDs[start:stop]...),
+	}
+}
@@ -0,0 +1,80 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// overflowTokens 返回各溢出片段的tokens
+func overflowTokens(encoding *Encoding) [][]string {
+	var tokens [][]string
+	for _, overflow := range encoding.Overflowing {
+		tokens = append(tokens, overflow.Tokens)
+	}
+	return tokens
+}
+
+// TestTruncate 测试单个序列按方向与stride截断并切分溢出片段
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		maxLength, stride int
+		direction         string
+		tokens            []string
+		overflowing       [][]string
+	}{
+		{3, 0, "right", []string{"a", "b", "c"}, [][]string{{"d", "e"}}},
+		{2, 1, "right", []string{"a", "b"}, [][]string{{"b", "c"}, {"c", "d"}, {"d", "e"}}},
+		{3, 1, "left", []string{"c", "d", "e"}, [][]string{{"a", "b", "c"}}},
+		{5, 0, "right", []string{"a", "b", "c", "d", "e"}, nil},
+	}
+	for _, tt := range tests {
+		encoding := newTestEncoding(0, "a", "b", "c", "d", "e")
+		if err := encoding.truncate(tt.maxLength, tt.stride, tt.direction); err != nil {
+			t.Fatalf("truncate(%d, %d, %s) failed: %v", tt.maxLength, tt.stride, tt.direction, err)
+		}
+		if !reflect.DeepEqual(encoding.Tokens, tt.tokens) {
+			t.Errorf("truncate(%d, %d, %s) tokens = %q, want %q", tt.maxLength, tt.stride, tt.direction, encoding.Tokens, tt.tokens)
+		}
+		if got := overflowTokens(encoding); !reflect.DeepEqual(got, tt.overflowing) {
+			t.Errorf("truncate(%d, %d, %s) overflowing = %q, want %q", tt.maxLength, tt.stride, tt.direction, got, tt.overflowing)
+		}
+	}
+
+	if err := newTestEncoding(0, "a", "b", "c").truncate(2, 2, "right"); err == nil {
+		t.Error("expected error for stride not smaller than max_length")
+	}
+}
+
+// TestTruncatePair 测试句对的截断策略
+func TestTruncatePair(t *testing.T) {
+	tests := []struct {
+		strategy      string
+		maxLength     int
+		first, second []string
+		wantErr       bool
+	}{
+		{"longest_first", 4, []string{"a", "b"}, []string{"c", "d"}, false},
+		{"longest_first", 3, []string{"a", "b"}, []string{"c"}, false},
+		{"only_first", 3, []string{"a"}, []string{"c", "d"}, false},
+		{"only_second", 6, []string{"a", "b", "c", "d", "e"}, []string{"c"}, false},
+		{"only_second", 4, nil, nil, true},
+	}
+	for _, tt := range tests {
+		encodings := []*Encoding{newTestEncoding(0, "a", "b", "c", "d", "e"), newTestEncoding(1, "c", "d")}
+		opts := TruncationOptions{Strategy: tt.strategy, Direction: "right"}
+		err := truncateEncodings(encodings, opts, tt.maxLength)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s(%d): expected error", tt.strategy, tt.maxLength)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s(%d) failed: %v", tt.strategy, tt.maxLength, err)
+		}
+		if !reflect.DeepEqual(encodings[0].Tokens, tt.first) || !reflect.DeepEqual(encodings[1].Tokens, tt.second) {
+			t.Errorf("%s(%d) = %q %q, want %q %q", tt.strategy, tt.maxLength,
+				encodings[0].Tokens, encodings[1].Tokens, tt.first, tt.second)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tokenizer
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// unigramUnkPenalty 未知字符相对最低piece得分的惩罚（与SentencePiece的kUnkPenalty一致）
+const unigramUnkPenalty = 10.0
+
+// unigramNode Viterbi格中以某位置结尾的最优路径
+type unigramNode struct {
+	start int     // 最后一个piece的起始字节位置，-1表示不可达
+	score float64 // 路径得分
+	unk   bool    // 最后一个piece是否为未知字符
+}
+
+// unigramWord Unigram分词：求得分最高的切分，再处理未知字符
+func (t *Tokenizer) unigramWord(word string) []modelToken {
+	return t.resolveUnknown(t.applyUnigram(word))
+}
+
+// applyUnigram 用Viterbi算法在所有piece切分中选出得分之和最大的一种。
+// 不在词汇表中的单个字符按最低得分减去惩罚计分，连续的未知字符合并为一个token
+func (t *Tokenizer) applyUnigram(word string) []modelToken {
+	maxLen := t.unigramMaxLen
+	unkScore := t.unigramMinScore - unigramUnkPenalty
+
+	best := make([]unigramNode, len(word)+1)
+	for i := 1; i < len(best); i++ {
+		best[i].start = -1
+	}
+
+	for pos := 0; pos < len(word); {
+		_, charLen := utf8.DecodeRuneInString(word[pos:])
+		hasSingle := false
+		for end := pos + charLen; end <= len(word) && end-pos <= maxLen; {
+			if id, exists := t.config.Vocabulary[word[pos:end]]; exists && id < len(t.config.Scores) {
+				score := best[pos].score + t.config.Scores[id]
+				if best[end].start == -1 || score > best[end].score {
+					best[end] = unigramNode{start: pos, score: score}
+				}
+				if end == pos+charLen {
+					hasSingle = true
+				}
+			}
+			_, size := utf8.DecodeRuneInString(word[end:])
+			if size == 0 {
+				break
+			}
+			end += size
+		}
+
+		if !hasSingle {
+			end := pos + charLen
+			score := best[pos].score + unkScore
+			if best[end].start == -1 || score > best[end].score {
+				best[end] = unigramNode{start: pos, score: score, unk: true}
+			}
+		}
+		pos += charLen
+	}
+
+	// 回溯最优路径，合并连续的未知字符
+	var reversed []modelToken
+	unkEnd := -1
+	for end := len(word); end > 0; end = best[end].start {
+		node := best[end]
+		if node.unk {
+			if unkEnd == -1 {
+				unkEnd = end
+			}
+			if node.start > 0 && best[node.start].unk {
+				continue
+			}
+			reversed = append(reversed, modelToken{value: word[node.start:unkEnd], offsets: [2]int{node.start, unkEnd}})
+			unkEnd = -1
+			continue
+		}
+		reversed = append(reversed, modelToken{value: word[node.start:end], offsets: [2]int{node.start, end}})
+	}
+
+	tokens := make([]modelToken, len(reversed))
+	for i, token := range reversed {
+		tokens[len(reversed)-1-i] = token
+	}
+	return tokens
+}
+
+// unigramBounds 返回最长piece的字节长度与最低piece得分
+func unigramBounds(config *TokenizerConfig) (maxLen int, minScore float64) {
+	minScore = math.Inf(1)
+	for token, id := range config.Vocabulary {
+		if len(token) > maxLen {
+			maxLen = len(token)
+		}
+		if id < len(config.Scores) && config.Scores[id] < minScore {
+			minScore = config.Scores[id]
+		}
+	}
+	return maxLen, minScore
+}
@@ -0,0 +1,52 @@
+package tokenizer_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+// t5Config T5风格的Unigram配置，vocab为 [piece, score] 列表
+const t5Config = `{
+	"added_tokens": [{"id": 0, "content": "<unk>", "special": true}],
+	"pre_tokenizer": {"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always", "split": true},
+	"decoder": {"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always", "split": true},
+	"model": {"type": "Unigram", "unk_id": 0, "vocab": [
+		["<unk>", 0.0], ["▁", -2.0], ["▁hello", -3.0], ["▁he", -4.0], ["llo", -4.0], ["h", -5.0],
+		["e", -5.0], ["l", -5.0], ["o", -5.0], ["▁world", -3.0], ["w", -5.0], ["r", -5.0], ["d", -5.0]]}
+}`
+
+// TestUnigram 测试Unigram的Viterbi切分与连续未知字符的合并
+func TestUnigram(t *testing.T) {
+	tk := writeConfig(t, t5Config)
+
+	tests := []struct {
+		text   string
+		tokens []string
+		ids    []int
+	}{
+		{"hello world", []string{"▁hello", "▁world"}, []int{2, 9}},
+		{"hellx", []string{"▁he", "l", "l", "x"}, []int{3, 7, 7, 0}},
+		{"xyz", []string{"▁", "xyz"}, []int{1, 0}},
+		{"world是", []string{"▁world", "是"}, []int{9, 0}},
+	}
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", tt.text, err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) {
+			t.Errorf("Tokenize(%q) tokens = %q, want %q", tt.text, result.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(result.TokenIDs, tt.ids) {
+			t.Errorf("Tokenize(%q) ids = %v, want %v", tt.text, result.TokenIDs, tt.ids)
+		}
+	}
+
+	text, err := tk.Decode([]int{2, 9})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if text != "hello world" {
+		t.Errorf("Decode = %q, want %q", text, "hello world")
+	}
+}
@@ -0,0 +1,209 @@
+package tokenizer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 词汇表分页的默认与最大条数
+const (
+	defaultVocabLimit = 100
+	maxVocabLimit     = 1000
+)
+
+// VocabQuery 词汇表查询。ID或Token非空时按精确值查找，否则按ID区间与Prefix、Contains、Regex过滤，
+// 过滤条件同时匹配原始token与可读形式
+type VocabQuery struct {
+	ID       *int
+	Token    string
+	MinID    int
+	MaxID    *int // 为nil时没有上限
+	Prefix   string
+	Contains string
+	Regex    string
+	Offset   int
+	Limit    int // 为0时取默认条数
+}
+
+// VocabEntry 词汇表中的一个token
+type VocabEntry struct {
+	ID        int      `json:"id"`
+	Token     string   `json:"token"`
+	Display   string   `json:"display"`         // 解码后的可读形式，无效UTF-8字节显示为\xNN
+	Bytes     string   `json:"bytes,omitempty"` // 字节级token对应的原始字节（十六进制）
+	Special   bool     `json:"special"`
+	MergeRank *int     `json:"merge_rank,omitempty"` // 生成该token的BPE merge的rank
+	Merge     []string `json:"merge,omitempty"`      // 生成该token的两个子token
+}
+
+// VocabPage 词汇表查询结果，按ID升序分页
+type VocabPage struct {
+	Entries   []VocabEntry `json:"entries"`
+	Total     int          `json:"total"` // 满足条件的token总数
+	Offset    int          `json:"offset"`
+	Limit     int          `json:"limit"`
+	VocabSize int          `json:"vocab_size"`
+	ModelName string       `json:"model_name"`
+}
+
+// SearchVocab 按条件查询词汇表
+func (t *Tokenizer) SearchVocab(q VocabQuery) (*VocabPage, error) {
+	if q.Offset < 0 || q.Limit < 0 {
+		return nil, fmt.Errorf("invalid offset or limit")
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultVocabLimit
+	}
+	q.Limit = minInt(q.Limit, maxVocabLimit)
+
+	var re *regexp.Regexp
+	if q.Regex != "" {
+		var err error
+		if re, err = regexp.Compile(q.Regex); err != nil {
+			return nil, fmt.Errorf("invalid regex: %v", err)
+		}
+	}
+
+	page := &VocabPage{
+		Entries:   []VocabEntry{},
+		Offset:    q.Offset,
+		Limit:     q.Limit,
+		VocabSize: len(t.config.Vocabulary),
+		ModelName: t.config.ModelName,
+	}
+	// collect 按ID升序依次传入满足条件的下标，只为当前页构造条目
+	collect := func(i int) bool {
+		if page.Total >= q.Offset && page.Total < q.Offset+q.Limit {
+			page.Entries = append(page.Entries, t.vocabEntry(i))
+		}
+		page.Total++
+		return true
+	}
+
+	idx := t.vocab
+	switch {
+	case q.ID != nil:
+		if i, exists := idx.position(*q.ID); exists {
+			collect(i)
+		}
+	case q.Token != "":
+		if id, exists := t.config.Vocabulary[q.Token]; exists {
+			if i, exists := idx.position(id); exists {
+				collect(i)
+			}
+		}
+	default:
+		maxID := -1
+		if q.MaxID != nil {
+			maxID = *q.MaxID
+		}
+		start, end := idx.idRange(q.MinID, maxID)
+		filter := func(i int) bool {
+			if i < start || i >= end || !idx.matches(i, q.Contains, re) {
+				return true
+			}
+			return collect(i)
+		}
+
+		switch {
+		case q.Prefix != "":
+			candidates := newPositionSet(len(idx.ids))
+			for _, i := range prefixRange(idx.byToken, idx.tokens, q.Prefix) {
+				candidates.add(i)
+			}
+			for _, i := range prefixRange(idx.byDisplay, idx.displays, q.Prefix) {
+				candidates.add(i)
+			}
+			candidates.each(filter)
+		case q.Contains != "" || re != nil:
+			for i := start; i < end; i++ {
+				filter(i)
+			}
+		default:
+			page.Total = end - start
+			for i := start + q.Offset; i < end && i < start+q.Offset+q.Limit; i++ {
+				page.Entries = append(page.Entries, t.vocabEntry(i))
+			}
+		}
+	}
+	return page, nil
+}
+
+// vocabEntry 构造索引中第i个token的词汇表条目
+func (t *Tokenizer) vocabEntry(i int) VocabEntry {
+	idx := t.vocab
+	id, token := idx.ids[i], idx.tokens[i]
+	entry := VocabEntry{ID: id, Token: token, Display: idx.displays[i], Special: t.specialIDs[id]}
+	if raw, ok := t.tokenBytes(token); ok {
+		entry.Bytes = hex.EncodeToString(raw)
+	}
+	if merge, exists := idx.merges[token]; exists {
+		rank := merge.rank
+		entry.MergeRank = &rank
+		entry.Merge = []string{merge.left, merge.right}
+	}
+	return entry
+}
+
+// displayToken 返回token的可读形式：字节级token还原为原始字节，其余token经解码器处理
+func (t *Tokenizer) displayToken(token string) string {
+	if raw, ok := t.tokenBytes(token); ok {
+		return escapeInvalidUTF8(raw)
+	}
+	if t.decoder == nil {
+		return token
+	}
+	return strings.Join(t.decoder.decodeChain([]string{token}), "")
+}
+
+// tokenBytes 返回字节级或byte fallback token对应的原始字节
+func (t *Tokenizer) tokenBytes(token string) ([]byte, bool) {
+	if b, ok := parseByteToken(token); ok {
+		return []byte{b}, true
+	}
+	if !hasByteLevelDecoder(t.decoder) || token == "" {
+		return nil, false
+	}
+	raw := make([]byte, 0, len(token))
+	for _, r := range token {
+		b, ok := byteDecoder[r]
+		if !ok {
+			return nil, false
+		}
+		raw = append(raw, b)
+	}
+	return raw, true
+}
+
+// hasByteLevelDecoder 判断解码器（或解码器序列）中是否包含ByteLevel
+func hasByteLevelDecoder(d decoder) bool {
+	switch d := d.(type) {
+	case byteLevelDecoder:
+		return true
+	case sequenceDecoder:
+		for _, child := range d {
+			if hasByteLevelDecoder(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// escapeInvalidUTF8 保留有效的UTF-8字符，无效字节显示为\xNN
+func escapeInvalidUTF8(raw []byte) string {
+	var sb strings.Builder
+	for len(raw) > 0 {
+		r, size := utf8.DecodeRune(raw)
+		if r == utf8.RuneError && size == 1 {
+			fmt.Fprintf(&sb, "\\x%02x", raw[0])
+		} else {
+			sb.Write(raw[:size])
+		}
+		raw = raw[size:]
+	}
+	return sb.String()
+}
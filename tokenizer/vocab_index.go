@@ -0,0 +1,119 @@
+package tokenizer
+
+import (
+	"math/bits"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// vocabIndex 加载时构建的词汇表索引：按ID排序的条目，以及按token与可读形式字典序排列的下标，
+// 前缀查找与ID区间查找都只需二分查找，不必扫描整个词汇表
+type vocabIndex struct {
+	ids       []int    // 全部ID，升序
+	tokens    []string // tokens[i] 为ids[i]对应的token
+	displays  []string // displays[i] 为tokens[i]的可读形式
+	byToken   []int32  // 按token字典序排列的下标
+	byDisplay []int32  // 按可读形式字典序排列的下标
+	merges    map[string]mergeInfo
+}
+
+// mergeInfo 生成某个token的merge
+type mergeInfo struct {
+	rank        int
+	left, right string
+}
+
+// newVocabIndex 为tokenizer的词汇表构建索引
+func newVocabIndex(t *Tokenizer) *vocabIndex {
+	idx := &vocabIndex{ids: make([]int, 0, len(t.config.ReverseVocab))}
+	for id := range t.config.ReverseVocab {
+		idx.ids = append(idx.ids, id)
+	}
+	sort.Ints(idx.ids)
+
+	idx.tokens = make([]string, len(idx.ids))
+	idx.displays = make([]string, len(idx.ids))
+	idx.byToken = make([]int32, len(idx.ids))
+	idx.byDisplay = make([]int32, len(idx.ids))
+	for i, id := range idx.ids {
+		idx.tokens[i] = t.config.ReverseVocab[id]
+		idx.displays[i] = t.displayToken(idx.tokens[i])
+		idx.byToken[i] = int32(i)
+		idx.byDisplay[i] = int32(i)
+	}
+	sort.Slice(idx.byToken, func(a, b int) bool {
+		return idx.tokens[idx.byToken[a]] < idx.tokens[idx.byToken[b]]
+	})
+	sort.Slice(idx.byDisplay, func(a, b int) bool {
+		return idx.displays[idx.byDisplay[a]] < idx.displays[idx.byDisplay[b]]
+	})
+
+	// 同一token可由多个merge生成时取rank最小的
+	idx.merges = make(map[string]mergeInfo, len(t.config.Merges))
+	for pair, rank := range t.config.Merges {
+		merged := pair[0] + strings.TrimPrefix(pair[1], t.config.ContinuingSubwordPrefix)
+		if existing, exists := idx.merges[merged]; !exists || rank < existing.rank {
+			idx.merges[merged] = mergeInfo{rank: rank, left: pair[0], right: pair[1]}
+		}
+	}
+	return idx
+}
+
+// position 返回ID在索引中的下标
+func (idx *vocabIndex) position(id int) (int, bool) {
+	i := sort.SearchInts(idx.ids, id)
+	return i, i < len(idx.ids) && idx.ids[i] == id
+}
+
+// matches 判断第i个token的原始token或可读形式是否同时满足子串与正则条件
+func (idx *vocabIndex) matches(i int, contains string, re *regexp.Regexp) bool {
+	token, display := idx.tokens[i], idx.displays[i]
+	if contains != "" && !strings.Contains(token, contains) && !strings.Contains(display, contains) {
+		return false
+	}
+	return re == nil || re.MatchString(token) || re.MatchString(display)
+}
+
+// prefixRange 返回sorted（按keys字典序排列的下标）中以prefix开头的区间
+func prefixRange(sorted []int32, keys []string, prefix string) []int32 {
+	start := sort.Search(len(sorted), func(i int) bool { return keys[sorted[i]] >= prefix })
+	end := start + sort.Search(len(sorted)-start, func(i int) bool {
+		return !strings.HasPrefix(keys[sorted[start+i]], prefix)
+	})
+	return sorted[start:end]
+}
+
+// idRange 返回ID在[minID, maxID]内的下标区间，maxID小于0表示没有上限
+func (idx *vocabIndex) idRange(minID, maxID int) (int, int) {
+	start := sort.SearchInts(idx.ids, minID)
+	end := len(idx.ids)
+	if maxID >= 0 {
+		end = sort.SearchInts(idx.ids, maxID+1)
+	}
+	return start, maxInt(start, end)
+}
+
+// positionSet ids下标的位集合，按下标（即ID）升序遍历
+type positionSet []uint64
+
+func newPositionSet(n int) positionSet {
+	return make(positionSet, (n+63)/64)
+}
+
+func (s positionSet) add(i int32) {
+	s[i/64] |= 1 << (uint(i) % 64)
+}
+
+// each 按升序对集合中的下标调用fn，fn返回false时停止
+func (s positionSet) each(fn func(i int) bool) {
+	for w, word := range s {
+		for word != 0 {
+			i := w*64 + bits.TrailingZeros64(word)
+			if !fn(i) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
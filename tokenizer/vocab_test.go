@@ -0,0 +1,115 @@
+package tokenizer_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// TestSearchVocab 测试词汇表的精确查找、过滤、分页与可读形式
+func TestSearchVocab(t *testing.T) {
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	id := 256
+	page, err := tk.SearchVocab(tokenizer.VocabQuery{ID: &id})
+	if err != nil {
+		t.Fatalf("SearchVocab failed: %v", err)
+	}
+	if len(page.Entries) != 1 {
+		t.Fatalf("entries = %+v, want one", page.Entries)
+	}
+	entry := page.Entries[0]
+	if entry.Token != "Ġt" || entry.Display != " t" || entry.Bytes != "2074" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.MergeRank == nil || *entry.MergeRank != 0 || !reflect.DeepEqual(entry.Merge, []string{"Ġ", "t"}) {
+		t.Errorf("merge = %v %v, want rank 0 of [Ġ t]", entry.MergeRank, entry.Merge)
+	}
+
+	// 不完整的UTF-8字节显示为\xNN
+	page, err = tk.SearchVocab(tokenizer.VocabQuery{Token: "Ã"})
+	if err != nil || len(page.Entries) != 1 || page.Entries[0].Display != `\xc3` {
+		t.Errorf("SearchVocab(Ã) = %+v, %v", page, err)
+	}
+
+	// 前缀同时匹配可读形式，结果按ID升序
+	page, err = tk.SearchVocab(tokenizer.VocabQuery{Prefix: " w"})
+	if err != nil {
+		t.Fatalf("SearchVocab failed: %v", err)
+	}
+	if page.Total == 0 {
+		t.Fatal("no tokens with prefix \" w\"")
+	}
+	for i, entry := range page.Entries {
+		if !strings.HasPrefix(entry.Token, "Ġw") {
+			t.Errorf("token %q does not match prefix", entry.Token)
+		}
+		if i > 0 && entry.ID <= page.Entries[i-1].ID {
+			t.Errorf("entries are not sorted by id: %d after %d", entry.ID, page.Entries[i-1].ID)
+		}
+	}
+
+	page, err = tk.SearchVocab(tokenizer.VocabQuery{Offset: 300, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchVocab failed: %v", err)
+	}
+	if len(page.Entries) != 5 || page.Total < 305 {
+		t.Errorf("page = %d entries of %d, vocab size %d", len(page.Entries), page.Total, page.VocabSize)
+	}
+
+	minID, maxID := 256, 259
+	page, err = tk.SearchVocab(tokenizer.VocabQuery{MinID: minID, MaxID: &maxID, Offset: 1})
+	if err != nil {
+		t.Fatalf("SearchVocab failed: %v", err)
+	}
+	var ids []int
+	for _, entry := range page.Entries {
+		ids = append(ids, entry.ID)
+	}
+	if want := []int{257, 258, 259}; page.Total != 4 || !reflect.DeepEqual(ids, want) {
+		t.Errorf("range ids = %v of %d, want %v of 4", ids, page.Total, want)
+	}
+
+	page, err = tk.SearchVocab(tokenizer.VocabQuery{Regex: "^Ġ(th|wor)"})
+	if err != nil || page.Total == 0 {
+		t.Errorf("SearchVocab(regex) = %+v, %v", page, err)
+	}
+	if _, err := tk.SearchVocab(tokenizer.VocabQuery{Regex: "("}); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+// TestVocabIndex 测试基于索引的前缀查找与top-N结果的顺序
+func TestVocabIndex(t *testing.T) {
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "test_config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+
+	tokens := tk.FindTokensByPrefix("Ġw")
+	if len(tokens) == 0 || !sort.StringsAreSorted(tokens) {
+		t.Errorf("FindTokensByPrefix(Ġw) = %q, want sorted non-empty result", tokens)
+	}
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, "Ġw") {
+			t.Errorf("token %q does not match prefix", token)
+		}
+	}
+	if tokens := tk.FindTokensByPrefix("no such prefix"); len(tokens) != 0 {
+		t.Errorf("FindTokensByPrefix = %q, want empty", tokens)
+	}
+
+	if want := []string{"!", "\"", "#"}; !reflect.DeepEqual(tk.GetTopTokens(3), want) {
+		t.Errorf("GetTopTokens(3) = %q, want %q", tk.GetTopTokens(3), want)
+	}
+	if n := len(tk.GetTopTokens(100000)); n == 0 || n > tk.GetVocabSize() {
+		t.Errorf("GetTopTokens returned %d tokens", n)
+	}
+}
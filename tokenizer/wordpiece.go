@@ -0,0 +1,47 @@
+package tokenizer
+
+import (
+	"unicode/utf8"
+)
+
+// applyWordPiece WordPiece分词：从左到右每次取词汇表中最长的子串，非词首的子串带ContinuingSubwordPrefix；
+// 任意位置无法匹配或词超过MaxInputCharsPerWord时，整个词作为unknown token
+func (t *Tokenizer) applyWordPiece(word string) []modelToken {
+	unk := []modelToken{{value: t.config.SpecialTokens["unk"], offsets: [2]int{0, len(word)}}}
+	if utf8.RuneCountInString(word) > t.config.MaxInputCharsPerWord {
+		return unk
+	}
+
+	var tokens []modelToken
+	for start := 0; start < len(word); {
+		end := len(word)
+		var match string
+		for start < end {
+			candidate := word[start:end]
+			if start > 0 {
+				candidate = t.config.ContinuingSubwordPrefix + candidate
+			}
+			if _, exists := t.config.Vocabulary[candidate]; exists {
+				match = candidate
+				break
+			}
+			_, size := utf8.DecodeLastRuneInString(word[start:end])
+			end -= size
+		}
+		if match == "" {
+			return unk
+		}
+		tokens = append(tokens, modelToken{value: match, offsets: [2]int{start, end}})
+		start = end
+	}
+	return tokens
+}
+
+// wordLevelWord WordLevel分词：整个词直接查词汇表，不存在则为unknown token
+func (t *Tokenizer) wordLevelWord(word string) []modelToken {
+	value := word
+	if _, exists := t.config.Vocabulary[word]; !exists {
+		value = t.config.SpecialTokens["unk"]
+	}
+	return []modelToken{{value: value, offsets: [2]int{0, len(word)}}}
+}
@@ -0,0 +1,88 @@
+package tokenizer_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/render-examples/go-gin-web-server/tokenizer"
+)
+
+// bertConfig BERT风格的WordPiece配置
+const bertConfig = `{
+	"added_tokens": [
+		{"id": 0, "content": "[UNK]", "special": true},
+		{"id": 1, "content": "[CLS]", "special": true},
+		{"id": 2, "content": "[SEP]", "special": true}
+	],
+	"normalizer": {"type": "BertNormalizer", "clean_text": true, "handle_chinese_chars": true, "strip_accents": null, "lowercase": true},
+	"pre_tokenizer": {"type": "BertPreTokenizer"},
+	"post_processor": {"type": "BertProcessing", "sep": ["[SEP]", 2], "cls": ["[CLS]", 1]},
+	"decoder": {"type": "WordPiece", "prefix": "##", "cleanup": true},
+	"model": {"type": "WordPiece", "unk_token": "[UNK]", "continuing_subword_prefix": "##", "max_input_chars_per_word": 10,
+		"vocab": {"[UNK]": 0, "[CLS]": 1, "[SEP]": 2, "un": 3, "##aff": 4, "##able": 5, "is": 6, "good": 7, ".": 8,
+			"play": 9, "##ing": 10, "##s": 11, "##play": 12}}
+}`
+
+// TestWordPiece 测试WordPiece的最长匹配、unknown与超长词处理
+func TestWordPiece(t *testing.T) {
+	tk := writeConfig(t, bertConfig)
+
+	tests := []struct {
+		text   string
+		tokens []string
+		ids    []int
+	}{
+		{"Unaffable is good.", []string{"un", "##aff", "##able", "is", "good", "."}, []int{3, 4, 5, 6, 7, 8}},
+		{"plays playing", []string{"play", "##s", "play", "##ing"}, []int{9, 11, 9, 10}},
+		{"playz good", []string{"[UNK]", "good"}, []int{0, 7}},
+		{"playplayplays", []string{"[UNK]"}, []int{0}},
+	}
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", tt.text, err)
+		}
+		if !reflect.DeepEqual(result.Tokens, tt.tokens) {
+			t.Errorf("Tokenize(%q) tokens = %q, want %q", tt.text, result.Tokens, tt.tokens)
+		}
+		if !reflect.DeepEqual(result.TokenIDs, tt.ids) {
+			t.Errorf("Tokenize(%q) ids = %v, want %v", tt.text, result.TokenIDs, tt.ids)
+		}
+	}
+
+	encoding, err := tk.EncodeWithOptions("Unaffable", tokenizer.EncodeOptions{AddSpecialTokens: true})
+	if err != nil {
+		t.Fatalf("Encoding failed: %v", err)
+	}
+	if want := []int{1, 3, 4, 5, 2}; !reflect.DeepEqual(encoding.IDs, want) {
+		t.Errorf("ids with special tokens = %v, want %v", encoding.IDs, want)
+	}
+	if text, err := tk.Decode(encoding.IDs[1:4]); err != nil || text != "unaffable" {
+		t.Errorf("Decode = %q, %v, want %q", text, err, "unaffable")
+	}
+}
+
+// TestWordPieceUnknownCount 测试WordPiece输出的[UNK]计入unknown_count，文本中的"[UNK]"作为added token不计入
+func TestWordPieceUnknownCount(t *testing.T) {
+	tk, err := tokenizer.NewTokenizer(filepath.Join("tokenizer", "testdata", "golden", "wordpiece"))
+	if err != nil {
+		t.Fatalf("Failed to load tokenizer: %v", err)
+	}
+	tests := []struct {
+		text    string
+		unknown int
+	}{
+		{"hello ☃☃ zzzq", 2},
+		{"hello [UNK]", 0},
+	}
+	for _, tt := range tests {
+		result, err := tk.Tokenize(tt.text)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", tt.text, err)
+		}
+		if result.UnknownCount != tt.unknown {
+			t.Errorf("Tokenize(%q) tokens %q: unknown = %d, want %d", tt.text, result.Tokens, result.UnknownCount, tt.unknown)
+		}
+	}
+}